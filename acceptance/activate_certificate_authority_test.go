@@ -74,7 +74,7 @@ var _ = Describe("activate certificate authority", func() {
 			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
 			Expect(err).NotTo(HaveOccurred())
 
-			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session).Should(gexec.Exit(4))
 			Expect(string(session.Out.Contents())).To(Not(ContainSubstring("Certificate authority 'missing-id' activated\n")))
 		})
 	})
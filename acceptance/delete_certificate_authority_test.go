@@ -77,7 +77,7 @@ var _ = Describe("delete certificate authority", func() {
 			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
 			Expect(err).NotTo(HaveOccurred())
 
-			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session).Should(gexec.Exit(4))
 			Expect(string(session.Err.Contents())).To(ContainSubstring("Certificate with specified guid not found"))
 		})
 	})
@@ -96,7 +96,7 @@ var _ = Describe("delete certificate authority", func() {
 			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
 			Expect(err).NotTo(HaveOccurred())
 
-			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session).Should(gexec.Exit(2))
 			Expect(string(session.Err.Contents())).To(ContainSubstring("Active certificates cannot be deleted"))
 		})
 	})
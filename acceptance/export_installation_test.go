@@ -118,7 +118,7 @@ var _ = Describe("export-installation command", func() {
 				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
 				Expect(err).NotTo(HaveOccurred())
 
-				Eventually(session, 3).Should(gexec.Exit(1))
+				Eventually(session, 3).Should(gexec.Exit(6))
 				Eventually(session.Err, 3).Should(gbytes.Say(`.*request canceled \(Client\.Timeout exceeded while awaiting headers\)`))
 			})
 		})
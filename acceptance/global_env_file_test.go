@@ -3,6 +3,8 @@ package acceptance
 import (
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 
@@ -14,8 +16,13 @@ import (
 
 var _ = Describe("global env file", func() {
 	Context("when provided config file flag", func() {
-		var configFile *os.File
-		configContent := `
+		var (
+			configFile    *os.File
+			configContent string
+		)
+
+		BeforeEach(func() {
+			configContent = `
 ---
 password: some-env-provided-password
 username: some-env-provided-username
@@ -23,6 +30,7 @@ target: %s
 skip-ssl-validation: true
 connect-timeout: 10
 `
+		})
 
 		createConfigFile := func(target string) {
 			var err error
@@ -77,11 +85,127 @@ connect-timeout: 10
 				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
 				Expect(err).NotTo(HaveOccurred())
 
-				Eventually(session).Should(gexec.Exit(1))
+				Eventually(session).Should(gexec.Exit(2))
 				Expect(string(session.Err.Contents())).To(ContainSubstring("could not parse env file: "))
 			})
 		})
 
+		Context("when the env file has no environments", func() {
+			It("does not interpolate its values", func() {
+				server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+
+					switch req.URL.Path {
+					case "/uaa/oauth/token":
+						req.ParseForm()
+						if req.PostForm.Get("password") != "some((password))" {
+							w.WriteHeader(http.StatusUnauthorized)
+							return
+						}
+
+						w.Write([]byte(`{
+							"access_token": "some-opsman-token",
+							"token_type": "bearer",
+							"expires_in": 3600
+						}`))
+					case "/api/v0/available_products":
+						w.Write([]byte(`[ { "name": "p-bosh", "product_version": "999.99" } ]`))
+					default:
+						w.WriteHeader(http.StatusNotFound)
+					}
+				}))
+
+				configContent = `
+---
+password: some((password))
+username: some-env-provided-username
+target: %s
+skip-ssl-validation: true
+`
+				createConfigFile(server.URL)
+
+				command := exec.Command(pathToMain,
+					"--env", configFile.Name(),
+					"curl",
+					"-p", "/api/v0/available_products",
+				)
+
+				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(session).Should(gexec.Exit(0))
+				Expect(string(session.Out.Contents())).To(MatchJSON(`[ { "name": "p-bosh", "product_version": "999.99" } ]`))
+			})
+		})
+
+		Context("when the env file has named environments", func() {
+			BeforeEach(func() {
+				configContent = `
+---
+username: some-env-provided-username
+password: ((opsman_password))
+skip-ssl-validation: true
+environments:
+  sandbox:
+    target: %s
+  prod:
+    target: https://prod.example.com
+`
+			})
+
+			It("uses the selected environment with the shared options and interpolated variables", func() {
+				server := testServer(true)
+
+				createConfigFile(server.URL)
+
+				command := exec.Command(pathToMain,
+					"--env", configFile.Name(),
+					"curl",
+					"-p", "/api/v0/available_products",
+				)
+				command.Env = append(os.Environ(), "OM_ENV_NAME=sandbox", "OM_VAR_opsman_password=some-env-provided-password")
+
+				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(session).Should(gexec.Exit(0))
+				Expect(string(session.Out.Contents())).To(MatchJSON(`[ { "name": "p-bosh", "product_version": "999.99" } ]`))
+			})
+
+			It("returns an error when no environment is selected", func() {
+				createConfigFile("https://sandbox.example.com")
+
+				command := exec.Command(pathToMain,
+					"--env", configFile.Name(),
+					"curl",
+					"-p", "/api/v0/available_products",
+				)
+
+				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(session).Should(gexec.Exit(2))
+				Expect(string(session.Err.Contents())).To(ContainSubstring("env file defines the environments prod, sandbox, select one with --env-name or OM_ENV_NAME"))
+			})
+
+			It("returns an error when a variable is missing", func() {
+				createConfigFile("https://sandbox.example.com")
+
+				command := exec.Command(pathToMain,
+					"--env", configFile.Name(),
+					"--env-name", "sandbox",
+					"curl",
+					"-p", "/api/v0/available_products",
+				)
+
+				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(session).Should(gexec.Exit(2))
+				Expect(string(session.Err.Contents())).To(ContainSubstring("could not interpolate env file: Expected to find variables: opsman_password"))
+			})
+		})
+
 		Context("when given an env file that does not exist", func() {
 			It("returns an error", func() {
 				command := exec.Command(pathToMain,
@@ -93,7 +217,7 @@ connect-timeout: 10
 				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
 				Expect(err).NotTo(HaveOccurred())
 
-				Eventually(session).Should(gexec.Exit(1))
+				Eventually(session).Should(gexec.Exit(2))
 				Expect(string(session.Err.Contents())).To(ContainSubstring("env file does not exist: "))
 			})
 		})
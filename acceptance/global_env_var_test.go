@@ -106,7 +106,7 @@ connect-timeout: 10
 
 		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
-		Eventually(session).Should(gexec.Exit(1))
+		Eventually(session).Should(gexec.Exit(3))
 	})
 })
 
@@ -18,7 +18,7 @@ var _ = Describe("global flags", func() {
 			session, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
 			Expect(err).NotTo(HaveOccurred())
 
-			Eventually(session).Should(gexec.Exit(1))
+			Eventually(session).Should(gexec.Exit(2))
 			Expect(session.Err).Should(gbytes.Say("flag provided but not defined: -?"))
 		})
 	})
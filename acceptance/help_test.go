@@ -13,25 +13,38 @@ const GLOBAL_USAGE = `ॐ
 om helps you interact with an Ops Manager
 
 Usage: om [options] <command> [<args>]
-  --client-id, -c, OM_CLIENT_ID                          string  Client ID for the Ops Manager VM (not required for unauthenticated commands)
-  --client-secret, -s, OM_CLIENT_SECRET                  string  Client Secret for the Ops Manager VM (not required for unauthenticated commands)
-  --connect-timeout, -o                                  int     timeout in seconds to make TCP connections (default: 5)
-  --decryption-passphrase, -d, OM_DECRYPTION_PASSPHRASE  string  Passphrase to decrypt the installation if the Ops Manager VM has been rebooted (optional for most commands)
-  --env, -e                                              string  env file with login credentials
-  --help, -h                                             bool    prints this usage information (default: false)
-  --password, -p, OM_PASSWORD                            string  admin password for the Ops Manager VM (not required for unauthenticated commands)
-  --request-timeout, -r                                  int     timeout in seconds for HTTP requests to Ops Manager (default: 1800)
-  --skip-ssl-validation, -k                              bool    skip ssl certificate validation during http requests (default: false)
-  --target, -t, OM_TARGET                                string  location of the Ops Manager VM
-  --trace, -tr                                           bool    prints HTTP requests and response payloads
-  --username, -u, OM_USERNAME                            string  admin username for the Ops Manager VM (not required for unauthenticated commands)
-  --version, -v                                          bool    prints the om release version (default: false)
+  --ca-cert, OM_CA_CERT                                  string             OpsManager CA certificate path or value, added to the system trust pool
+  --client-id, -c, OM_CLIENT_ID                          string             Client ID for the Ops Manager VM (not required for unauthenticated commands)
+  --client-secret, -s, OM_CLIENT_SECRET                  string             Client Secret for the Ops Manager VM (not required for unauthenticated commands)
+  --connect-timeout, -o                                  int                timeout in seconds to make TCP connections (default: 5)
+  --decryption-passphrase, -d, OM_DECRYPTION_PASSPHRASE  string             Passphrase to decrypt the installation if the Ops Manager VM has been rebooted (optional for most commands)
+  --env, -e                                              string             env file with login credentials
+  --env-name, OM_ENV_NAME                                string             name of the environment to use from an env file with environments
+  --help, -h                                             bool               prints this usage information (default: false)
+  --max-retries                                          int                number of times to retry idempotent requests that fail with a connection error or a 502, 503 or 504 (default: 3)
+  --output, OM_OUTPUT                                    string             output of commands that change Ops Manager: text, or json for one JSON result on stdout with the logs on stderr (default: text)
+  --password, -p, OM_PASSWORD                            string             admin password for the Ops Manager VM (not required for unauthenticated commands)
+  --replay-file                                          string             serves the responses recorded with --trace-file instead of contacting Ops Manager
+  --request-timeout, -r                                  int                timeout in seconds for HTTP requests to Ops Manager (default: 1800)
+  --retry-backoff                                        int                initial delay in seconds between retries, doubled for every retry (default: 1)
+  --skip-ssl-validation, -k                              bool               skip ssl certificate validation during http requests (default: false)
+  --target, -t, OM_TARGET                                string             location of the Ops Manager VM
+  --tls-client-cert, OM_TLS_CLIENT_CERT                  string             client certificate path or value for mutual TLS
+  --tls-client-key, OM_TLS_CLIENT_KEY                    string             client private key path or value for mutual TLS
+  --token-cache, OM_TOKEN_CACHE                          bool               cache UAA tokens in $OM_TOKEN_CACHE_DIR (default: ~/.om/tokens) and reuse them across invocations
+  --trace, -tr                                           bool               prints HTTP requests and response payloads, with credentials redacted
+  --trace-file                                           string             records HTTP requests and responses to a file, in the HAR format if it ends in .har and as JSON lines otherwise
+  --trace-unredacted                                     bool               prints HTTP requests and response payloads without redacting credentials (do not share the output)
+  --username, -u, OM_USERNAME                            string             admin username for the Ops Manager VM (not required for unauthenticated commands)
+  --vars-store                                           string (variadic)  store to look up variables not provided with --vars-file or --vars-env (e.g.: credhub://credhub.example.com/prefix, file-exec:./get-secret)
+  --version, -v                                          bool               prints the om release version (default: false)
 
 Commands:
   activate-certificate-authority  activates a certificate authority on the Ops Manager
   apply-changes                   triggers an install on the Ops Manager targeted
   assign-stemcell                 assigns an uploaded stemcell to a product in the targeted Ops Manager
   available-products              list available products
+  backup                          backs up the installation and configuration of the target Ops Manager
   certificate-authorities         lists certificates managed by Ops Manager
   certificate-authority           prints requested certificate authority
   config-template                 **EXPERIMENTAL** generates a config template for the product
@@ -39,6 +52,7 @@ Commands:
   configure-director              configures the director
   configure-product               configures a staged product
   configure-saml-authentication   configures Ops Manager with SAML authentication
+  converge                        **EXPERIMENTAL** converges the Ops Manager to the state described in a foundation file
   create-certificate-authority    creates a certificate authority on the Ops Manager
   create-vm-extension             creates/updates a VM extension
   credential-references           list credential references for a deployed product
@@ -50,6 +64,7 @@ Commands:
   delete-unused-products          deletes unused products on the Ops Manager targeted
   deployed-manifest               prints the deployed manifest for a product
   deployed-products               lists deployed products
+  diff-product-config             **EXPERIMENTAL** compares a product config file against the staged product
   download-product                downloads a specified product file from Pivotal Network
   errands                         list errands for a product
   export-installation             exports the installation of the target Ops Manager
@@ -57,9 +72,13 @@ Commands:
   generate-certificate-authority  generates a certificate authority on the Opsman
   help                            prints this usage information
   import-installation             imports a given installation to the Ops Manager targeted
+  info                            prints the version and supported features of Ops Manager
   installation-log                output installation logs
   installations                   list recent installation events
   interpolate                     Interpolates variables into a manifest
+  lint-product-config             **EXPERIMENTAL** validates a product config file against a product file
+  login                           logs in to the Ops Manager targeted and caches the token
+  logout                          removes the cached token of the Ops Manager targeted
   pending-changes                 lists pending changes
   regenerate-certificates         deletes all non-configurable certificates in Ops Manager so they will automatically be regenerated on the next apply-changes
   revert-staged-changes           reverts staged changes on the Ops Manager targeted
@@ -73,25 +92,38 @@ Commands:
   upload-product                  uploads a given product to the Ops Manager targeted
   upload-stemcell                 uploads a given stemcell to the Ops Manager targeted
   version                         prints the om release version
+  wait-for-ready                  waits for Ops Manager to be ready
 `
 
 const CONFIGURE_AUTHENTICATION_USAGE = `ॐ  configure-authentication
 This unauthenticated command helps setup the internal userstore authentication mechanism for your Ops Manager.
 
 Usage: om [options] configure-authentication [<args>]
-  --client-id, -c, OM_CLIENT_ID                          string  Client ID for the Ops Manager VM (not required for unauthenticated commands)
-  --client-secret, -s, OM_CLIENT_SECRET                  string  Client Secret for the Ops Manager VM (not required for unauthenticated commands)
-  --connect-timeout, -o                                  int     timeout in seconds to make TCP connections (default: 5)
-  --decryption-passphrase, -d, OM_DECRYPTION_PASSPHRASE  string  Passphrase to decrypt the installation if the Ops Manager VM has been rebooted (optional for most commands)
-  --env, -e                                              string  env file with login credentials
-  --help, -h                                             bool    prints this usage information (default: false)
-  --password, -p, OM_PASSWORD                            string  admin password for the Ops Manager VM (not required for unauthenticated commands)
-  --request-timeout, -r                                  int     timeout in seconds for HTTP requests to Ops Manager (default: 1800)
-  --skip-ssl-validation, -k                              bool    skip ssl certificate validation during http requests (default: false)
-  --target, -t, OM_TARGET                                string  location of the Ops Manager VM
-  --trace, -tr                                           bool    prints HTTP requests and response payloads
-  --username, -u, OM_USERNAME                            string  admin username for the Ops Manager VM (not required for unauthenticated commands)
-  --version, -v                                          bool    prints the om release version (default: false)
+  --ca-cert, OM_CA_CERT                                  string             OpsManager CA certificate path or value, added to the system trust pool
+  --client-id, -c, OM_CLIENT_ID                          string             Client ID for the Ops Manager VM (not required for unauthenticated commands)
+  --client-secret, -s, OM_CLIENT_SECRET                  string             Client Secret for the Ops Manager VM (not required for unauthenticated commands)
+  --connect-timeout, -o                                  int                timeout in seconds to make TCP connections (default: 5)
+  --decryption-passphrase, -d, OM_DECRYPTION_PASSPHRASE  string             Passphrase to decrypt the installation if the Ops Manager VM has been rebooted (optional for most commands)
+  --env, -e                                              string             env file with login credentials
+  --env-name, OM_ENV_NAME                                string             name of the environment to use from an env file with environments
+  --help, -h                                             bool               prints this usage information (default: false)
+  --max-retries                                          int                number of times to retry idempotent requests that fail with a connection error or a 502, 503 or 504 (default: 3)
+  --output, OM_OUTPUT                                    string             output of commands that change Ops Manager: text, or json for one JSON result on stdout with the logs on stderr (default: text)
+  --password, -p, OM_PASSWORD                            string             admin password for the Ops Manager VM (not required for unauthenticated commands)
+  --replay-file                                          string             serves the responses recorded with --trace-file instead of contacting Ops Manager
+  --request-timeout, -r                                  int                timeout in seconds for HTTP requests to Ops Manager (default: 1800)
+  --retry-backoff                                        int                initial delay in seconds between retries, doubled for every retry (default: 1)
+  --skip-ssl-validation, -k                              bool               skip ssl certificate validation during http requests (default: false)
+  --target, -t, OM_TARGET                                string             location of the Ops Manager VM
+  --tls-client-cert, OM_TLS_CLIENT_CERT                  string             client certificate path or value for mutual TLS
+  --tls-client-key, OM_TLS_CLIENT_KEY                    string             client private key path or value for mutual TLS
+  --token-cache, OM_TOKEN_CACHE                          bool               cache UAA tokens in $OM_TOKEN_CACHE_DIR (default: ~/.om/tokens) and reuse them across invocations
+  --trace, -tr                                           bool               prints HTTP requests and response payloads, with credentials redacted
+  --trace-file                                           string             records HTTP requests and responses to a file, in the HAR format if it ends in .har and as JSON lines otherwise
+  --trace-unredacted                                     bool               prints HTTP requests and response payloads without redacting credentials (do not share the output)
+  --username, -u, OM_USERNAME                            string             admin username for the Ops Manager VM (not required for unauthenticated commands)
+  --vars-store                                           string (variadic)  store to look up variables not provided with --vars-file or --vars-env (e.g.: credhub://credhub.example.com/prefix, file-exec:./get-secret)
+  --version, -v                                          bool               prints the om release version (default: false)
 
 Command Arguments:
   --config, -c                  string             path to yml file for configuration (keys must match the following command line flags)
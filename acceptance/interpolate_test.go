@@ -38,6 +38,30 @@ name: bob
 `))
 		})
 
+		Context("with a vars store", func() {
+			It("replaces the vars with values from the store", func() {
+				script := createFile("#!/bin/sh\necho \"from-$1\"\n")
+				script.Close()
+				defer os.Remove(script.Name())
+				Expect(os.Chmod(script.Name(), 0700)).To(Succeed())
+
+				yamlFile := createFile("---\nname: ((name1))")
+				defer yamlFile.Close()
+
+				command := exec.Command(pathToMain,
+					"--vars-store", "file-exec:"+script.Name(),
+					"interpolate",
+					"--config", yamlFile.Name(),
+				)
+
+				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(session, 5).Should(gexec.Exit(0))
+				Expect(session.Out.Contents()).To(MatchYAML(`name: from-name1`))
+			})
+		})
+
 		Context("with vars defined in the manifest", func() {
 			It("successfully replaces the vars", func() {
 				varsFile := createFile("---\nname1: moe\nage1: 500")
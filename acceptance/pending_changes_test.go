@@ -64,6 +64,8 @@ var _ = Describe("pending_changes command", func() {
 				"token_type": "bearer",
 				"expires_in": 3600
 			}`))
+			case "/api/v0/info":
+				w.Write([]byte(`{"info": {"version": "2.2-build.1"}}`))
 			case "/api/v0/staged/pending_changes":
 				w.Write([]byte(`{
 					"product_changes": [{
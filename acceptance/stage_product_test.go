@@ -64,13 +64,16 @@ var _ = Describe("stage-product command", func() {
 					}
 					if req.Method == "GET" {
 						responseString = `[]`
+						if stageRequestMethod != "" {
+							responseString = `[{"type": "cf", "guid": "cf-some-guid"}]`
+						}
 					} else {
 						responseString = `{}`
 						stageRequestMethod = req.Method
+						reqBody, err := ioutil.ReadAll(req.Body)
+						Expect(err).NotTo(HaveOccurred())
+						stageRequest = string(reqBody)
 					}
-					reqBody, err := ioutil.ReadAll(req.Body)
-					Expect(err).NotTo(HaveOccurred())
-					stageRequest = string(reqBody)
 				case "/api/v0/diagnostic_report":
 					responseString = `{}`
 				default:
@@ -85,6 +88,7 @@ var _ = Describe("stage-product command", func() {
 
 		AfterEach(func() {
 			server.Close()
+			stageRequestMethod = ""
 		})
 
 		It("successfully stages a product to the Ops Manager", func() {
@@ -111,6 +115,31 @@ var _ = Describe("stage-product command", func() {
 					"product_version": "1.8.7-build.3"
 			}`))
 		})
+
+		It("writes the staged product to stdout and the logs to stderr with the json output", func() {
+			command := exec.Command(pathToMain,
+				"--target", server.URL,
+				"--username", "some-username",
+				"--password", "some-password",
+				"--skip-ssl-validation",
+				"--output", "json",
+				"stage-product",
+				"--product-name", "cf",
+				"--product-version", "1.8.7-build.3",
+			)
+
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(session).Should(gexec.Exit(0))
+			Expect(session.Err).To(gbytes.Say("finished staging"))
+			Expect(session.Out.Contents()).To(MatchJSON(`{
+				"product_name": "cf",
+				"product_version": "1.8.7-build.3",
+				"guid": "cf-some-guid",
+				"already_staged": false
+			}`))
+		})
 	})
 
 	Context("when the same type of product is already deployed", func() {
@@ -164,10 +193,11 @@ var _ = Describe("stage-product command", func() {
 					}
 					if req.Method == "GET" {
 						responseString = `[]`
+					} else {
+						reqBody, err := ioutil.ReadAll(req.Body)
+						Expect(err).NotTo(HaveOccurred())
+						stageRequest = string(reqBody)
 					}
-					reqBody, err := ioutil.ReadAll(req.Body)
-					Expect(err).NotTo(HaveOccurred())
-					stageRequest = string(reqBody)
 				case "/api/v0/staged/products/cf-some-guid":
 					auth := req.Header.Get("Authorization")
 					if auth != "Bearer some-opsman-token" {
@@ -401,7 +431,7 @@ var _ = Describe("stage-product command", func() {
 				session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
 				Expect(err).NotTo(HaveOccurred())
 
-				Eventually(session).Should(gexec.Exit(1))
+				Eventually(session).Should(gexec.Exit(4))
 				Eventually(session.Err).Should(gbytes.Say("cannot find product bosh 2.0"))
 			})
 		})
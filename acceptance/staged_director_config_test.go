@@ -0,0 +1,251 @@
+package acceptance
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("staged-director-config command", func() {
+	var (
+		source    *httptest.Server
+		target    *httptest.Server
+		targetPut map[string]string
+		tempDir   string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "staged-director-config")
+		Expect(err).NotTo(HaveOccurred())
+
+		source = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch req.URL.Path {
+			case "/uaa/oauth/token":
+				w.Write([]byte(`{
+					"access_token": "some-opsman-token",
+					"token_type": "bearer",
+					"expires_in": 3600
+				}`))
+			case "/api/v0/staged/products":
+				w.Write([]byte(`[{"installation_name":"p-bosh","guid":"p-bosh-guid","type":"p-bosh","product_version":"2.2.0"}]`))
+			case "/api/v0/staged/director/properties":
+				w.Write([]byte(`{
+					"iaas_configuration": {
+						"guid": "source-iaas-guid",
+						"name": "default",
+						"project": "my-project",
+						"auth_json": "***"
+					},
+					"director_configuration": {
+						"ntp_servers_string": "ntp.example.com",
+						"resurrector_enabled": true,
+						"database_type": "internal"
+					},
+					"security_configuration": {
+						"trusted_certificates": "some-certificate",
+						"generate_vm_passwords": true
+					},
+					"syslog_configuration": {
+						"enabled": false
+					}
+				}`))
+			case "/api/v0/staged/director/availability_zones":
+				w.Write([]byte(`{"availability_zones": [{"guid": "source-az-guid", "name": "us-central1-a"}]}`))
+			case "/api/v0/staged/director/networks":
+				w.Write([]byte(`{
+					"icmp_checks_enabled": false,
+					"networks": [{
+						"guid": "source-network-guid",
+						"name": "infrastructure",
+						"subnets": [{
+							"guid": "source-subnet-guid",
+							"iaas_identifier": "my-network/my-subnet",
+							"cidr": "10.0.0.0/24",
+							"dns": "8.8.8.8",
+							"gateway": "10.0.0.1",
+							"reserved_ip_ranges": "10.0.0.1-10.0.0.10",
+							"availability_zone_names": ["us-central1-a"]
+						}]
+					}]
+				}`))
+			case "/api/v0/staged/products/p-bosh-guid/networks_and_azs":
+				w.Write([]byte(`{
+					"networks_and_azs": {
+						"singleton_availability_zone": {"name": "us-central1-a"},
+						"network": {"name": "infrastructure"}
+					}
+				}`))
+			case "/api/v0/staged/products/p-bosh-guid/jobs":
+				w.Write([]byte(`{"jobs": [{"name": "director", "guid": "source-director-guid"}]}`))
+			case "/api/v0/staged/products/p-bosh-guid/jobs/source-director-guid/resource_config":
+				w.Write([]byte(`{
+					"instances": 1,
+					"instance_type": {"id": "large.disk"},
+					"persistent_disk": {"size_mb": "102400"},
+					"internet_connected": false
+				}`))
+			case "/api/v0/staged/vm_extensions":
+				w.Write([]byte(`{"vm_extensions": [{"name": "public_ip", "cloud_properties": {"ephemeral_external_ip": true}}]}`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+
+		targetPut = map[string]string{}
+		target = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			if req.Method == "PUT" {
+				body, err := ioutil.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+				targetPut[req.URL.Path] = string(body)
+
+				w.Write([]byte(`{}`))
+				return
+			}
+
+			switch req.URL.Path {
+			case "/uaa/oauth/token":
+				w.Write([]byte(`{
+					"access_token": "some-opsman-token",
+					"token_type": "bearer",
+					"expires_in": 3600
+				}`))
+			case "/api/v0/staged/products":
+				w.Write([]byte(`[{"installation_name":"p-bosh","guid":"p-bosh-guid","type":"p-bosh","product_version":"2.2.0"}]`))
+			case "/api/v0/staged/director/availability_zones":
+				w.Write([]byte(`{"availability_zones": []}`))
+			case "/api/v0/staged/director/networks":
+				w.Write([]byte(`{"networks": []}`))
+			case "/api/v0/deployed/director/credentials":
+				w.WriteHeader(http.StatusNotFound)
+			case "/api/v0/staged/products/p-bosh-guid/jobs":
+				w.Write([]byte(`{"jobs": [{"name": "director", "guid": "target-director-guid"}]}`))
+			case "/api/v0/staged/products/p-bosh-guid/jobs/target-director-guid/resource_config":
+				w.Write([]byte(`{"instances": "automatic", "instance_type": {"id": "automatic"}}`))
+			case "/api/v0/staged/vm_extensions":
+				w.Write([]byte(`{"vm_extensions": []}`))
+			default:
+				out, err := httputil.DumpRequest(req, true)
+				Expect(err).NotTo(HaveOccurred())
+				Fail(fmt.Sprintf("unexpected request: %s", out))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		source.Close()
+		target.Close()
+		os.RemoveAll(tempDir)
+	})
+
+	It("outputs a config that configure-director applies to another director unchanged", func() {
+		command := exec.Command(pathToMain,
+			"--target", source.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"staged-director-config",
+			"--include-placeholders",
+		)
+
+		session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(session, "10s").Should(gexec.Exit(0))
+
+		configFile := filepath.Join(tempDir, "director.yml")
+		err = ioutil.WriteFile(configFile, session.Out.Contents(), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		varsFile := filepath.Join(tempDir, "vars.yml")
+		err = ioutil.WriteFile(varsFile, []byte(`
+iaas-configuration_name: default
+iaas-configuration_project: my-project
+iaas-configuration_auth_json: '{"type": "service_account"}'
+`), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		command = exec.Command(pathToMain,
+			"--target", target.URL,
+			"--username", "some-username",
+			"--password", "some-password",
+			"--skip-ssl-validation",
+			"configure-director",
+			"--config", configFile,
+			"--vars-file", varsFile,
+		)
+
+		session, err = gexec.Start(command, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(session, "10s").Should(gexec.Exit(0))
+
+		Expect(targetPut).To(HaveLen(6))
+		Expect(targetPut["/api/v0/staged/director/properties"]).To(MatchJSON(`{
+			"iaas_configuration": {
+				"name": "default",
+				"project": "my-project",
+				"auth_json": "{\"type\": \"service_account\"}"
+			},
+			"director_configuration": {
+				"ntp_servers_string": "ntp.example.com",
+				"resurrector_enabled": true,
+				"database_type": "internal"
+			},
+			"security_configuration": {
+				"trusted_certificates": "some-certificate",
+				"generate_vm_passwords": true
+			},
+			"syslog_configuration": {
+				"enabled": false
+			}
+		}`))
+		Expect(targetPut["/api/v0/staged/director/availability_zones"]).To(MatchJSON(`{
+			"availability_zones": [{"name": "us-central1-a"}]
+		}`))
+		Expect(targetPut["/api/v0/staged/director/networks"]).To(MatchJSON(`{
+			"icmp_checks_enabled": false,
+			"networks": [{
+				"name": "infrastructure",
+				"subnets": [{
+					"iaas_identifier": "my-network/my-subnet",
+					"cidr": "10.0.0.0/24",
+					"dns": "8.8.8.8",
+					"gateway": "10.0.0.1",
+					"reserved_ip_ranges": "10.0.0.1-10.0.0.10",
+					"availability_zone_names": ["us-central1-a"]
+				}]
+			}]
+		}`))
+		Expect(targetPut["/api/v0/staged/director/network_and_az"]).To(MatchJSON(`{
+			"network_and_az": {
+				"singleton_availability_zone": {"name": "us-central1-a"},
+				"network": {"name": "infrastructure"}
+			}
+		}`))
+		Expect(targetPut["/api/v0/staged/products/p-bosh-guid/jobs/target-director-guid/resource_config"]).To(MatchJSON(`{
+			"instances": 1,
+			"instance_type": {"id": "large.disk"},
+			"persistent_disk": {"size_mb": "102400"},
+			"internet_connected": false,
+			"elb_names": null
+		}`))
+		Expect(targetPut["/api/v0/staged/vm_extensions/public_ip"]).To(MatchJSON(`{
+			"name": "public_ip",
+			"cloud_properties": {"ephemeral_external_ip": true}
+		}`))
+	})
+})
@@ -17,7 +17,7 @@ var _ = Describe("unknown command", func() {
 		session, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
 
-		Eventually(session).Should(gexec.Exit(1))
+		Eventually(session).Should(gexec.Exit(2))
 		Expect(session.Err).Should(gbytes.Say("unknown command: banana"))
 	})
 })
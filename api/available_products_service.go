@@ -49,7 +49,7 @@ func (a Api) UploadAvailableProduct(input UploadAvailableProductInput) (UploadAv
 
 	resp, err := a.progressClient.Do(req)
 	if err != nil {
-		return UploadAvailableProductOutput{}, fmt.Errorf("could not make api request to available_products endpoint: %s", err)
+		return UploadAvailableProductOutput{}, fmt.Errorf("could not make api request to available_products endpoint: %w", err)
 	}
 
 	defer resp.Body.Close()
@@ -64,13 +64,13 @@ func (a Api) UploadAvailableProduct(input UploadAvailableProductInput) (UploadAv
 func (a Api) ListAvailableProducts() (AvailableProductsOutput, error) {
 	resp, err := a.sendAPIRequest("GET", availableProductsEndpoint, nil)
 	if err != nil {
-		return AvailableProductsOutput{}, fmt.Errorf("could not make api request to available_products endpoint: %s", err)
+		return AvailableProductsOutput{}, fmt.Errorf("could not make api request to available_products endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
 	var availableProducts []ProductInfo
 	if err := json.NewDecoder(resp.Body).Decode(&availableProducts); err != nil {
-		return AvailableProductsOutput{}, fmt.Errorf("could not unmarshal available_products response: %s", err)
+		return AvailableProductsOutput{}, fmt.Errorf("could not unmarshal available_products response: %w", err)
 	}
 
 	return AvailableProductsOutput{ProductsList: availableProducts}, nil
@@ -89,7 +89,7 @@ func (a Api) DeleteAvailableProducts(input DeleteAvailableProductsInput) error {
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("could not make api request to available_products endpoint: %s", err)
+		return fmt.Errorf("could not make api request to available_products endpoint: %w", err)
 	}
 
 	defer resp.Body.Close()
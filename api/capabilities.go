@@ -0,0 +1,60 @@
+package api
+
+import "fmt"
+
+const (
+	CapabilitySelectiveDeploys = "selective-deploys"
+	CapabilityPendingChanges   = "pending-changes"
+)
+
+// Capability is a feature of Ops Manager that is only available starting
+// with a minimum version. Commands and flags that depend on such a feature
+// check it with Info.Supports before making any changes. The other commands
+// use endpoints that every supported version of Ops Manager has.
+type Capability struct {
+	Name         string
+	Description  string
+	MinimumMajor int
+	MinimumMinor int
+}
+
+func (c Capability) MinimumVersion() string {
+	return fmt.Sprintf("%d.%d", c.MinimumMajor, c.MinimumMinor)
+}
+
+// Capabilities lists every feature of Ops Manager that om depends on and
+// that is not available in every version.
+var Capabilities = []Capability{
+	{
+		Name:         CapabilitySelectiveDeploys,
+		Description:  "deploy only some of the products (apply-changes --product-name)",
+		MinimumMajor: 2,
+		MinimumMinor: 2,
+	},
+	{
+		Name:         CapabilityPendingChanges,
+		Description:  "list the pending changes of each product (pending-changes, converge, apply-changes --skip-unchanged-products)",
+		MinimumMajor: 2,
+		MinimumMinor: 2,
+	},
+}
+
+func LookupCapability(name string) (Capability, error) {
+	for _, capability := range Capabilities {
+		if capability.Name == name {
+			return capability, nil
+		}
+	}
+
+	return Capability{}, fmt.Errorf("unknown capability %q", name)
+}
+
+// Supports reports whether the version of Ops Manager has the capability.
+func (i Info) Supports(name string) (bool, error) {
+	capability, err := LookupCapability(name)
+	if err != nil {
+		return false, err
+	}
+
+	return i.VersionAtLeast(capability.MinimumMajor, capability.MinimumMinor)
+}
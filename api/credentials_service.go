@@ -26,13 +26,13 @@ type Credential struct {
 func (a Api) GetDeployedProductCredential(input GetDeployedProductCredentialInput) (GetDeployedProductCredentialOutput, error) {
 	resp, err := a.sendAPIRequest("GET", fmt.Sprintf("/api/v0/deployed/products/%s/credentials/%s", input.DeployedGUID, input.CredentialReference), nil)
 	if err != nil {
-		return GetDeployedProductCredentialOutput{}, fmt.Errorf("could not make api request to credentials endpoint: %s", err)
+		return GetDeployedProductCredentialOutput{}, fmt.Errorf("could not make api request to credentials endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
 	var credentialOutput GetDeployedProductCredentialOutput
 	if err := json.NewDecoder(resp.Body).Decode(&credentialOutput); err != nil {
-		return GetDeployedProductCredentialOutput{}, fmt.Errorf("could not unmarshal credentials response: %s", err)
+		return GetDeployedProductCredentialOutput{}, fmt.Errorf("could not unmarshal credentials response: %w", err)
 	}
 
 	return credentialOutput, nil
@@ -41,13 +41,13 @@ func (a Api) GetDeployedProductCredential(input GetDeployedProductCredentialInpu
 func (a Api) ListDeployedProductCredentials(deployedGUID string) (CredentialReferencesOutput, error) {
 	resp, err := a.sendAPIRequest("GET", fmt.Sprintf("/api/v0/deployed/products/%s/credentials", deployedGUID), nil)
 	if err != nil {
-		return CredentialReferencesOutput{}, fmt.Errorf("could not make api request to credentials endpoint: %s", err)
+		return CredentialReferencesOutput{}, fmt.Errorf("could not make api request to credentials endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
 	var credentialReferences CredentialReferencesOutput
 	if err := json.NewDecoder(resp.Body).Decode(&credentialReferences); err != nil {
-		return CredentialReferencesOutput{}, fmt.Errorf("could not unmarshal credentials response: %s", err)
+		return CredentialReferencesOutput{}, fmt.Errorf("could not unmarshal credentials response: %w", err)
 	}
 
 	return credentialReferences, nil
@@ -25,13 +25,19 @@ func (du DiagnosticReportUnavailable) Error() string {
 	return "diagnostic report is currently unavailable"
 }
 
+// Temporary reports that the diagnostic report may become available, for
+// example once an installation has finished.
+func (du DiagnosticReportUnavailable) Temporary() bool {
+	return true
+}
+
 func (a Api) GetDiagnosticReport() (DiagnosticReport, error) {
 	resp, err := a.sendAPIRequest("GET", "/api/v0/diagnostic_report", nil)
 	if err != nil {
 		if resp.StatusCode == http.StatusInternalServerError {
 			return DiagnosticReport{}, DiagnosticReportUnavailable{}
 		}
-		return DiagnosticReport{}, fmt.Errorf("could not make api request to diagnostic_report endpoint: %s", err)
+		return DiagnosticReport{}, fmt.Errorf("could not make api request to diagnostic_report endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -43,7 +49,7 @@ func (a Api) GetDiagnosticReport() (DiagnosticReport, error) {
 		} `json:"added_products"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return DiagnosticReport{}, fmt.Errorf("invalid json received from server: %s", err)
+		return DiagnosticReport{}, fmt.Errorf("invalid json received from server: %w", err)
 	}
 
 	return DiagnosticReport{
@@ -61,7 +61,7 @@ func (a Api) UpdateStagedDirectorAvailabilityZones(input AvailabilityZoneInput)
 	azs := AvailabilityZones{}
 	err := yaml.Unmarshal(input.AvailabilityZones, &azs.AvailabilityZones)
 	if err != nil {
-		return fmt.Errorf("provided AZ config is not well-formed JSON: %s", err)
+		return fmt.Errorf("provided AZ config is not well-formed JSON: %w", err)
 	}
 
 	for i, az := range azs.AvailabilityZones {
@@ -77,12 +77,12 @@ func (a Api) UpdateStagedDirectorAvailabilityZones(input AvailabilityZoneInput)
 
 	decoratedConfig, err := yaml.Marshal(azs)
 	if err != nil {
-		return fmt.Errorf("problem marshalling request: %s", err) // un-tested
+		return fmt.Errorf("problem marshalling request: %w", err) // un-tested
 	}
 
 	jsonData, err := yamlConverter.YAMLToJSON(decoratedConfig)
 	if err != nil {
-		return fmt.Errorf("problem converting request to JSON: %s", err) // un-tested
+		return fmt.Errorf("problem converting request to JSON: %w", err) // un-tested
 	}
 
 	_, err = a.sendAPIRequest("PUT", "/api/v0/staged/director/availability_zones", jsonData)
@@ -93,7 +93,7 @@ func (a Api) UpdateStagedDirectorNetworks(input NetworkInput) error {
 	networks := Networks{}
 	err := yaml.Unmarshal(input.Networks, &networks)
 	if err != nil {
-		return fmt.Errorf("provided networks config is not well-formed JSON: %s", err)
+		return fmt.Errorf("provided networks config is not well-formed JSON: %w", err)
 	}
 
 	for i, network := range networks.Networks {
@@ -109,12 +109,12 @@ func (a Api) UpdateStagedDirectorNetworks(input NetworkInput) error {
 
 	decoratedConfig, err := yaml.Marshal(networks)
 	if err != nil {
-		return fmt.Errorf("problem marshalling request: %s", err) // un-tested
+		return fmt.Errorf("problem marshalling request: %w", err) // un-tested
 	}
 
 	jsonData, err := yamlConverter.YAMLToJSON(decoratedConfig)
 	if err != nil {
-		return fmt.Errorf("problem converting request to JSON: %s", err) // un-tested
+		return fmt.Errorf("problem converting request to JSON: %w", err) // un-tested
 	}
 
 	_, err = a.sendAPIRequest("PUT", "/api/v0/staged/director/networks", jsonData)
@@ -129,7 +129,7 @@ func (a Api) UpdateStagedDirectorNetworkAndAZ(input NetworkAndAZConfiguration) e
 	}
 	jsonData, err := json.Marshal(&input)
 	if err != nil {
-		return fmt.Errorf("could not marshal json: %s", err)
+		return fmt.Errorf("could not marshal json: %w", err)
 	}
 
 	_, err = a.sendAPIRequest("PUT", "/api/v0/staged/director/network_and_az", jsonData)
@@ -139,7 +139,7 @@ func (a Api) UpdateStagedDirectorNetworkAndAZ(input NetworkAndAZConfiguration) e
 func (a Api) UpdateStagedDirectorProperties(input DirectorProperties) error {
 	jsonData, err := json.Marshal(&input)
 	if err != nil {
-		return fmt.Errorf("could not marshal json: %s", err)
+		return fmt.Errorf("could not marshal json: %w", err)
 	}
 
 	_, err = a.sendAPIRequest("PUT", "/api/v0/staged/director/properties", jsonData)
@@ -150,7 +150,7 @@ func (a Api) addGUIDToExistingNetworks(networks Networks) (Networks, error) {
 	existingNetworksResponse, err := a.sendAPIRequest("GET", "/api/v0/staged/director/networks", nil)
 	if err != nil {
 		if existingNetworksResponse.StatusCode != http.StatusNotFound {
-			return Networks{}, fmt.Errorf("unable to fetch existing network configuration: %s", err)
+			return Networks{}, fmt.Errorf("unable to fetch existing network configuration: %w", err)
 		}
 	}
 
@@ -161,13 +161,13 @@ func (a Api) addGUIDToExistingNetworks(networks Networks) (Networks, error) {
 
 	existingNetworksJSON, err := ioutil.ReadAll(existingNetworksResponse.Body)
 	if err != nil {
-		return Networks{}, fmt.Errorf("unable to read existing network configuration: %s", err) // un-tested
+		return Networks{}, fmt.Errorf("unable to read existing network configuration: %w", err) // un-tested
 	}
 
 	var existingNetworks Networks
 	err = yaml.Unmarshal(existingNetworksJSON, &existingNetworks)
 	if err != nil {
-		return Networks{}, fmt.Errorf("problem retrieving existing networks: response is not well-formed: %s", err)
+		return Networks{}, fmt.Errorf("problem retrieving existing networks: response is not well-formed: %w", err)
 	}
 
 	for _, network := range networks.Networks {
@@ -185,7 +185,7 @@ func (a Api) addGUIDToExistingAZs(azs AvailabilityZones) (AvailabilityZones, err
 	existingAzsResponse, err := a.sendAPIRequest("GET", "/api/v0/staged/director/availability_zones", nil)
 	if err != nil {
 		if existingAzsResponse.StatusCode != http.StatusNotFound {
-			return AvailabilityZones{}, fmt.Errorf("unable to fetch existing AZ configuration: %s", err)
+			return AvailabilityZones{}, fmt.Errorf("unable to fetch existing AZ configuration: %w", err)
 		}
 	}
 
@@ -196,13 +196,13 @@ func (a Api) addGUIDToExistingAZs(azs AvailabilityZones) (AvailabilityZones, err
 
 	existingAzsJSON, err := ioutil.ReadAll(existingAzsResponse.Body)
 	if err != nil {
-		return AvailabilityZones{}, fmt.Errorf("unable to read existing AZ configuration: %s", err) // un-tested
+		return AvailabilityZones{}, fmt.Errorf("unable to read existing AZ configuration: %w", err) // un-tested
 	}
 
 	var existingAZs AvailabilityZones
 	err = yaml.Unmarshal(existingAzsJSON, &existingAZs)
 	if err != nil {
-		return AvailabilityZones{}, fmt.Errorf("problem retrieving existing AZs: response is not well-formed: %s", err)
+		return AvailabilityZones{}, fmt.Errorf("problem retrieving existing AZs: response is not well-formed: %w", err)
 	}
 
 	for _, az := range azs.AvailabilityZones {
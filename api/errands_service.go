@@ -36,7 +36,7 @@ func (a Api) UpdateStagedProductErrands(productID string, errandName string, pos
 	path := fmt.Sprintf("/api/v0/staged/products/%s/errands", productID)
 	_, err = a.sendAPIRequest("PUT", path, payload)
 	if err != nil {
-		return fmt.Errorf("failed to set errand state: %s", err)
+		return fmt.Errorf("failed to set errand state: %w", err)
 	}
 
 	return nil
@@ -47,7 +47,7 @@ func (a Api) ListStagedProductErrands(productID string) (ErrandsListOutput, erro
 
 	resp, err := a.sendAPIRequest("GET", fmt.Sprintf("/api/v0/staged/products/%s/errands", productID), nil)
 	if err != nil {
-		return errandsListOutput, fmt.Errorf("failed to list errands: %s", err)
+		return errandsListOutput, fmt.Errorf("failed to list errands: %w", err)
 	}
 
 	err = json.NewDecoder(resp.Body).Decode(&errandsListOutput)
@@ -3,8 +3,8 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
-	"strings"
 )
 
 // Info contains information about Ops Manager itself.
@@ -12,26 +12,39 @@ type Info struct {
 	Version string `json:"version"`
 }
 
-func (i Info) VersionAtLeast(major, minor int) bool {
-	// Given: X.Y-build.Z
-	// Extract X and Y
-	idx := strings.Index(i.Version, ".")
-	majv := i.Version[:idx]                                  // take substring up to '.'
-	minv := i.Version[idx+1 : strings.Index(i.Version, "-")] // take substring between '.' and '-'
+var versionRegexp = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:[.-]|$)`)
 
-	maj, err := strconv.Atoi(majv)
+// MajorMinor returns the major and minor version of Ops Manager, given a
+// version like 2.3-build.79.
+func (i Info) MajorMinor() (int, int, error) {
+	matches := versionRegexp.FindStringSubmatch(i.Version)
+	if matches == nil {
+		return 0, 0, fmt.Errorf("invalid Ops Manager version: %q", i.Version)
+	}
+
+	major, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid Ops Manager version: %q", i.Version)
+	}
+
+	minor, err := strconv.Atoi(matches[2])
 	if err != nil {
-		panic("invalid version: " + i.Version)
+		return 0, 0, fmt.Errorf("invalid Ops Manager version: %q", i.Version)
 	}
-	min, err := strconv.Atoi(minv)
+
+	return major, minor, nil
+}
+
+func (i Info) VersionAtLeast(major, minor int) (bool, error) {
+	maj, min, err := i.MajorMinor()
 	if err != nil {
-		panic("invalid version: " + i.Version)
+		return false, err
 	}
 
 	if maj < major || (maj == major && min < minor) {
-		return false
+		return false, nil
 	}
-	return true
+	return true, nil
 }
 
 // Info gets information about Ops Manager.
@@ -42,7 +55,7 @@ func (a Api) Info() (Info, error) {
 
 	resp, err := a.sendAPIRequest("GET", "/api/v0/info", nil)
 	if err != nil {
-		return r.Info, fmt.Errorf("could not make request to info endpoint: %v", err)
+		return r.Info, fmt.Errorf("could not make request to info endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -25,11 +26,40 @@ var _ = Describe("Info Service", func() {
 				{"1.12-build1", false},
 				{"2.0-build1", false},
 				{"2.3-build33", true},
+				{"v2.2-build.79", true},
+				{"2.10.1-build.3", true},
+				{"2.4", true},
 			}
 			for _, test := range tests {
-				Expect(api.Info{Version: test.ver}.VersionAtLeast(2, 2)).To(Equal(test.result))
+				atLeast, err := api.Info{Version: test.ver}.VersionAtLeast(2, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(atLeast).To(Equal(test.result), test.ver)
 			}
 		})
+
+		It("returns an error instead of panicking for an invalid version", func() {
+			for _, version := range []string{"", "2", "2-build.1", "a.b-build.1", "2.x"} {
+				_, err := api.Info{Version: version}.VersionAtLeast(2, 2)
+				Expect(err).To(MatchError(fmt.Sprintf("invalid Ops Manager version: %q", version)))
+			}
+		})
+	})
+
+	Context("Supports()", func() {
+		It("checks the minimum version of the capability", func() {
+			supported, err := api.Info{Version: "2.2-build.1"}.Supports(api.CapabilitySelectiveDeploys)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(supported).To(BeTrue())
+
+			supported, err = api.Info{Version: "2.1-build.1"}.Supports(api.CapabilitySelectiveDeploys)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(supported).To(BeFalse())
+		})
+
+		It("returns an error for an unknown capability", func() {
+			_, err := api.Info{Version: "2.2-build.1"}.Supports("some-capability")
+			Expect(err).To(MatchError(`unknown capability "some-capability"`))
+		})
 	})
 
 	Context("Info()", func() {
@@ -18,18 +18,18 @@ type ImportInstallationInput struct {
 func (a Api) DownloadInstallationAssetCollection(outputFile string, pollingInterval int) error {
 	resp, err := a.sendProgressAPIRequest("GET", "/api/v0/installation_asset_collection", nil)
 	if err != nil {
-		return fmt.Errorf("could not make api request to installation_asset_collection endpoint: %s", err)
+		return fmt.Errorf("could not make api request to installation_asset_collection endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
 	outputFileHandle, err := os.Create(outputFile)
 	if err != nil {
-		return fmt.Errorf("cannot create output file: %s", err)
+		return fmt.Errorf("cannot create output file: %w", err)
 	}
 
 	bytesWritten, err := io.Copy(outputFileHandle, resp.Body)
 	if err != nil {
-		return fmt.Errorf("cannot write output file: %s", err)
+		return fmt.Errorf("cannot write output file: %w", err)
 	}
 
 	if bytesWritten != resp.ContentLength {
@@ -50,7 +50,7 @@ func (a Api) UploadInstallationAssetCollection(input ImportInstallationInput) er
 
 	resp, err := a.unauthedProgressClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("could not make api request to installation_asset_collection endpoint: %s", err)
+		return fmt.Errorf("could not make api request to installation_asset_collection endpoint: %w", err)
 	}
 
 	defer resp.Body.Close()
@@ -68,7 +68,7 @@ func (a Api) DeleteInstallationAssetCollection() (InstallationsServiceOutput, er
 		if resp.StatusCode == http.StatusGone {
 			return InstallationsServiceOutput{}, nil
 		}
-		return InstallationsServiceOutput{}, fmt.Errorf("could not make api request to installation_asset_collection endpoint: %s", err)
+		return InstallationsServiceOutput{}, fmt.Errorf("could not make api request to installation_asset_collection endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -80,7 +80,7 @@ func (a Api) DeleteInstallationAssetCollection() (InstallationsServiceOutput, er
 
 	err = json.NewDecoder(resp.Body).Decode(&installation)
 	if err != nil {
-		return InstallationsServiceOutput{}, fmt.Errorf("could not read response from installation_asset_collection endpoint: %s", err)
+		return InstallationsServiceOutput{}, fmt.Errorf("could not read response from installation_asset_collection endpoint: %w", err)
 	}
 
 	return InstallationsServiceOutput{ID: installation.Install.ID}, nil
@@ -41,7 +41,7 @@ type Job struct {
 func (a Api) ListStagedProductJobs(productGUID string) (map[string]string, error) {
 	resp, err := a.sendAPIRequest("GET", fmt.Sprintf("/api/v0/staged/products/%s/jobs", productGUID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not make api request to jobs endpoint: %s", err)
+		return nil, fmt.Errorf("could not make api request to jobs endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -51,7 +51,7 @@ func (a Api) ListStagedProductJobs(productGUID string) (map[string]string, error
 
 	err = json.NewDecoder(resp.Body).Decode(&jobsOutput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode jobs json response: %s", err)
+		return nil, fmt.Errorf("failed to decode jobs json response: %w", err)
 	}
 
 	jobGUIDMap := make(map[string]string)
@@ -65,7 +65,7 @@ func (a Api) ListStagedProductJobs(productGUID string) (map[string]string, error
 func (a Api) GetStagedProductJobResourceConfig(productGUID, jobGUID string) (JobProperties, error) {
 	resp, err := a.sendAPIRequest("GET", fmt.Sprintf("/api/v0/staged/products/%s/jobs/%s/resource_config", productGUID, jobGUID), nil)
 	if err != nil {
-		return JobProperties{}, fmt.Errorf("could not make api request to resource_config endpoint: %s", err)
+		return JobProperties{}, fmt.Errorf("could not make api request to resource_config endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -85,7 +85,7 @@ func (a Api) UpdateStagedProductJobResourceConfig(productGUID, jobGUID string, j
 
 	_, err = a.sendAPIRequest("PUT", fmt.Sprintf("/api/v0/staged/products/%s/jobs/%s/resource_config", productGUID, jobGUID), jsonPayload)
 	if err != nil {
-		return fmt.Errorf("could not make api request to jobs resource_config endpoint: %s", err)
+		return fmt.Errorf("could not make api request to jobs resource_config endpoint: %w", err)
 	}
 
 	return nil
@@ -20,13 +20,13 @@ type ProductChange struct {
 func (a Api) ListStagedPendingChanges() (PendingChangesOutput, error) {
 	resp, err := a.sendAPIRequest("GET", pendingChangesEndpoint, nil)
 	if err != nil {
-		return PendingChangesOutput{}, fmt.Errorf("failed to submit request: %s", err)
+		return PendingChangesOutput{}, fmt.Errorf("failed to submit request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	var pendingChanges PendingChangesOutput
 	if err := json.NewDecoder(resp.Body).Decode(&pendingChanges); err != nil {
-		return PendingChangesOutput{}, fmt.Errorf("could not unmarshal pending_changes response: %s", err)
+		return PendingChangesOutput{}, fmt.Errorf("could not unmarshal pending_changes response: %w", err)
 	}
 
 	return pendingChanges, nil
@@ -22,13 +22,13 @@ type RequestServiceCurlOutput struct {
 func (a Api) Curl(input RequestServiceCurlInput) (RequestServiceCurlOutput, error) {
 	request, err := http.NewRequest(input.Method, input.Path, input.Data)
 	if err != nil {
-		return RequestServiceCurlOutput{}, fmt.Errorf("failed constructing request: %s", err)
+		return RequestServiceCurlOutput{}, fmt.Errorf("failed constructing request: %w", err)
 	}
 
 	request.Header = input.Headers
 	response, err := a.client.Do(request)
 	if err != nil {
-		return RequestServiceCurlOutput{}, fmt.Errorf("failed submitting request: %s", err)
+		return RequestServiceCurlOutput{}, fmt.Errorf("failed submitting request: %w", err)
 	}
 
 	output := RequestServiceCurlOutput{
@@ -12,13 +12,13 @@ type certResponse struct {
 func (a Api) GetSecurityRootCACertificate() (string, error) {
 	resp, err := a.sendAPIRequest("GET", "/api/v0/security/root_ca_certificate", nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to submit request: %s", err)
+		return "", fmt.Errorf("failed to submit request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	var certResponse certResponse
 	if err := json.NewDecoder(resp.Body).Decode(&certResponse); err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %s", err)
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return certResponse.Cert, nil
@@ -21,14 +21,14 @@ func (a Api) sendUnauthedAPIRequest(method, endpoint string, jsonData []byte) (*
 func sendRequest(client httpClient, method, endpoint string, jsonData []byte) (*http.Response, error) {
 	req, err := http.NewRequest(method, endpoint, bytes.NewReader(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("could not create api request %s %s: %s", method, endpoint, err.Error())
+		return nil, fmt.Errorf("could not create api request %s %s: %w", method, endpoint, err)
 	}
 
 	req.Header.Add("Content-Type", "application/json")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return resp, fmt.Errorf("could not send api request to %s %s: %s", method, endpoint, err.Error())
+		return resp, fmt.Errorf("could not send api request to %s %s: %w", method, endpoint, err)
 	}
 
 	err = validateStatusOK(resp)
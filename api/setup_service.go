@@ -40,7 +40,7 @@ func (a Api) Setup(input SetupInput) (SetupOutput, error) {
 
 	_, err = a.sendUnauthedAPIRequest("POST", "/api/v0/setup", payload)
 	if err != nil {
-		return SetupOutput{}, fmt.Errorf("could not make api request to setup endpoint: %s", err)
+		return SetupOutput{}, fmt.Errorf("could not make api request to setup endpoint: %w", err)
 	}
 	return SetupOutput{}, nil
 }
@@ -49,6 +49,7 @@ const (
 	EnsureAvailabilityStatusUnstarted = "unstarted"
 	EnsureAvailabilityStatusPending   = "pending"
 	EnsureAvailabilityStatusComplete  = "complete"
+	EnsureAvailabilityStatusLocked    = "locked"
 	EnsureAvailabilityStatusUnknown   = "unknown"
 )
 
@@ -65,7 +66,7 @@ func (a Api) EnsureAvailability(input EnsureAvailabilityInput) (EnsureAvailabili
 
 	response, err := a.unauthedClient.Do(request)
 	if err != nil {
-		return EnsureAvailabilityOutput{}, fmt.Errorf("could not make request round trip: %s", err)
+		return EnsureAvailabilityOutput{}, fmt.Errorf("could not make request round trip: %w", err)
 	}
 
 	defer response.Body.Close()
@@ -75,13 +76,16 @@ func (a Api) EnsureAvailability(input EnsureAvailabilityInput) (EnsureAvailabili
 	case response.StatusCode == http.StatusFound:
 		location, err := url.Parse(response.Header.Get("Location"))
 		if err != nil {
-			return EnsureAvailabilityOutput{}, fmt.Errorf("could not parse redirect url: %s", err)
+			return EnsureAvailabilityOutput{}, fmt.Errorf("could not parse redirect url: %w", err)
 		}
 
 		if location.Path == "/setup" {
 			status = EnsureAvailabilityStatusUnstarted
 		} else if location.Path == "/auth/cloudfoundry" {
 			status = EnsureAvailabilityStatusComplete
+		} else if location.Path == "/unlock" {
+			// the installation has to be decrypted after the VM has rebooted
+			status = EnsureAvailabilityStatusLocked
 		} else {
 			return EnsureAvailabilityOutput{}, fmt.Errorf("Unexpected redirect location: %s", location.Path)
 		}
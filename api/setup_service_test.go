@@ -188,6 +188,24 @@ var _ = Describe("Setup", func() {
 			})
 		})
 
+		Context("when the installation is locked after a reboot", func() {
+			It("returns a locked status", func() {
+				client.DoReturns(&http.Response{
+					StatusCode: http.StatusFound,
+					Header: http.Header{
+						"Location": []string{"https://some-opsman/unlock"},
+					},
+					Body: ioutil.NopCloser(strings.NewReader("")),
+				}, nil)
+
+				output, err := service.EnsureAvailability(api.EnsureAvailabilityInput{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(Equal(api.EnsureAvailabilityOutput{
+					Status: api.EnsureAvailabilityStatusLocked,
+				}))
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when the request fails", func() {
 				It("returns an error", func() {
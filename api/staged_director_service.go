@@ -51,7 +51,7 @@ func (a Api) GetStagedDirectorProperties() (map[string]map[string]interface{}, e
 
 	var properties map[string]map[string]interface{}
 	if err = yaml.NewDecoder(resp.Body).Decode(&properties); err != nil {
-		return nil, fmt.Errorf("could not parse json: %s", err)
+		return nil, fmt.Errorf("could not parse json: %w", err)
 	}
 
 	return properties, nil
@@ -70,7 +70,7 @@ func (a Api) GetStagedDirectorAvailabilityZones() (AvailabilityZonesOutput, erro
 	defer resp.Body.Close()
 
 	if err = yaml.NewDecoder(resp.Body).Decode(&properties); err != nil {
-		return properties, fmt.Errorf("could not parse json: %s", err)
+		return properties, fmt.Errorf("could not parse json: %w", err)
 	}
 
 	return properties, nil
@@ -85,7 +85,7 @@ func (a Api) GetStagedDirectorNetworks() (NetworksConfigurationOutput, error) {
 	defer resp.Body.Close()
 
 	if err = yaml.NewDecoder(resp.Body).Decode(&properties); err != nil {
-		return properties, fmt.Errorf("could not parse json: %s", err)
+		return properties, fmt.Errorf("could not parse json: %w", err)
 	}
 
 	return properties, nil
@@ -21,7 +21,7 @@ type ProductStemcell struct {
 func (a Api) ListStemcells() (ProductStemcells, error) {
 	resp, err := a.sendAPIRequest("GET", "/api/v0/stemcell_assignments", nil)
 	if err != nil {
-		return ProductStemcells{}, fmt.Errorf("could not make api request to list stemcells: %s", err)
+		return ProductStemcells{}, fmt.Errorf("could not make api request to list stemcells: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -33,7 +33,7 @@ func (a Api) ListStemcells() (ProductStemcells, error) {
 func (a Api) AssignStemcell(input ProductStemcells) error {
 	jsonData, err := json.Marshal(&input)
 	if err != nil {
-		return fmt.Errorf("could not marshal json: %s", err)
+		return fmt.Errorf("could not marshal json: %w", err)
 	}
 
 	_, err = a.sendAPIRequest("PATCH", "/api/v0/stemcell_assignments", jsonData)
@@ -25,7 +25,7 @@ func (a Api) UploadStemcell(input StemcellUploadInput) (StemcellUploadOutput, er
 
 	resp, err := a.progressClient.Do(req)
 	if err != nil {
-		return StemcellUploadOutput{}, fmt.Errorf("could not make api request to stemcells endpoint: %s", err)
+		return StemcellUploadOutput{}, fmt.Errorf("could not make api request to stemcells endpoint: %w", err)
 	}
 
 	defer resp.Body.Close()
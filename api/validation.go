@@ -6,14 +6,39 @@ import (
 	"net/http/httputil"
 )
 
+// ResponseError is returned when Ops Manager responds with a status other
+// than 200 OK.
+type ResponseError struct {
+	StatusCode int
+	message    string
+}
+
+func (e ResponseError) Error() string {
+	return e.message
+}
+
+// Temporary reports whether the request may succeed when it is retried,
+// because Ops Manager, or a load balancer in front of it, is unavailable.
+func (e ResponseError) Temporary() bool {
+	return e.StatusCode == http.StatusBadGateway ||
+		e.StatusCode == http.StatusServiceUnavailable ||
+		e.StatusCode == http.StatusGatewayTimeout
+}
+
 func validateStatusOK(resp *http.Response) error {
 	if resp.StatusCode != http.StatusOK {
 		out, err := httputil.DumpResponse(resp, true)
 		if err != nil {
-			return fmt.Errorf("request failed: unexpected response: %s", err)
+			return ResponseError{
+				StatusCode: resp.StatusCode,
+				message:    fmt.Sprintf("request failed: unexpected response: %s", err),
+			}
 		}
 
-		return fmt.Errorf("request failed: unexpected response:\n%s", out)
+		return ResponseError{
+			StatusCode: resp.StatusCode,
+			message:    fmt.Sprintf("request failed: unexpected response:\n%s", out),
+		}
 	}
 	return nil
 }
@@ -23,7 +23,7 @@ type CreateVMExtension struct {
 func (a Api) CreateStagedVMExtension(input CreateVMExtension) error {
 	jsonData, err := json.Marshal(&input)
 	if err != nil {
-		return fmt.Errorf("could not marshal json: %s", err)
+		return fmt.Errorf("could not marshal json: %w", err)
 	}
 
 	resp, err := a.sendAPIRequest("PUT", fmt.Sprintf("/api/v0/staged/vm_extensions/%s", input.Name), jsonData)
@@ -48,7 +48,7 @@ func (a Api) ListStagedVMExtensions() ([]VMExtension, error) {
 	}
 	var vmExtensions VMExtensionResponse
 	if err = json.Unmarshal(body, &vmExtensions); err != nil {
-		return nil, fmt.Errorf("could not parse json: %s", err)
+		return nil, fmt.Errorf("could not parse json: %w", err)
 	}
 
 	return vmExtensions.VMExtensions, nil
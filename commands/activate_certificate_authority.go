@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 )
@@ -10,6 +8,7 @@ import (
 type ActivateCertificateAuthority struct {
 	service activateCertificateAuthorityService
 	logger  logger
+	results resultWriter
 	Options struct {
 		Id string `long:"id" required:"true" description:"certificate authority id"`
 	}
@@ -20,13 +19,13 @@ type activateCertificateAuthorityService interface {
 	ActivateCertificateAuthority(api.ActivateCertificateAuthorityInput) error
 }
 
-func NewActivateCertificateAuthority(service activateCertificateAuthorityService, logger logger) ActivateCertificateAuthority {
-	return ActivateCertificateAuthority{service: service, logger: logger}
+func NewActivateCertificateAuthority(service activateCertificateAuthorityService, logger logger, results resultWriter) ActivateCertificateAuthority {
+	return ActivateCertificateAuthority{service: service, logger: logger, results: results}
 }
 
 func (a ActivateCertificateAuthority) Execute(args []string) error {
 	if _, err := jhanda.Parse(&a.Options, args); err != nil {
-		return fmt.Errorf("could not parse activate-certificate-authority flags: %s", err)
+		return configErrorf("could not parse activate-certificate-authority flags: %w", err)
 	}
 
 	err := a.service.ActivateCertificateAuthority(api.ActivateCertificateAuthorityInput{
@@ -39,7 +38,7 @@ func (a ActivateCertificateAuthority) Execute(args []string) error {
 
 	a.logger.Printf("Certificate authority '%s' activated\n", a.Options.Id)
 
-	return nil
+	return a.results.WriteResult(CertificateAuthorityResult{GUID: a.Options.Id})
 }
 
 func (a ActivateCertificateAuthority) Usage() jhanda.Usage {
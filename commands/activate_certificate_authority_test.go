@@ -16,13 +16,15 @@ var _ = Describe("ActivateCertificateAuthority", func() {
 	var (
 		fakeService *fakes.ActivateCertificateAuthorityService
 		fakeLogger  *fakes.Logger
+		results     *fakes.ResultWriter
 		command     commands.ActivateCertificateAuthority
 	)
 
 	BeforeEach(func() {
 		fakeService = &fakes.ActivateCertificateAuthorityService{}
 		fakeLogger = &fakes.Logger{}
-		command = commands.NewActivateCertificateAuthority(fakeService, fakeLogger)
+		results = &fakes.ResultWriter{}
+		command = commands.NewActivateCertificateAuthority(fakeService, fakeLogger, results)
 	})
 
 	Describe("Execute", func() {
@@ -40,6 +42,11 @@ var _ = Describe("ActivateCertificateAuthority", func() {
 			Expect(fakeLogger.PrintfCallCount()).To(Equal(1))
 			format, content := fakeLogger.PrintfArgsForCall(0)
 			Expect(fmt.Sprintf(format, content...)).To(Equal("Certificate authority 'some-certificate-authority-id' activated\n"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.CertificateAuthorityResult{
+				GUID: "some-certificate-authority-id",
+			}))
 		})
 
 		Context("failure cases", func() {
@@ -3,6 +3,7 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/pivotal-cf/jhanda"
@@ -14,12 +15,16 @@ type ApplyChanges struct {
 	pendingService pendingChangesService
 	logger         logger
 	logWriter      logWriter
+	results        resultWriter
+	liveWriter     liveWriter
 	waitDuration   time.Duration
 	Options        struct {
 		IgnoreWarnings        bool     `short:"i"   long:"ignore-warnings"      description:"ignore issues reported by Ops Manager when applying changes"`
 		SkipDeployProducts    bool     `short:"sdp" long:"skip-deploy-products" description:"skip deploying products when applying changes - just update the director"`
 		SkipUnchangedProducts bool     `short:"sup"   long:"skip-unchanged-products"         description:"skip deploying unchanged products - just run changed or new products --skip-unchanged-products (OM 2.2+)"`
 		ProductNames          []string `short:"n"   long:"product-name"         description:"name of the product(s) to deploy, cannot be used in conjunction with --skip-deploy-products (OM 2.2+)"`
+		Watch                 int      `short:"w"   long:"watch"                description:"id of a running or past installation to follow instead of starting a new one"`
+		Format                string   `short:"f"   long:"format"               default:"raw" description:"format of the installation logs (options: raw,progress,json)"`
 	}
 }
 
@@ -38,19 +43,51 @@ type logWriter interface {
 	Flush(logs string) error
 }
 
-func NewApplyChanges(service applyChangesService, pendingService pendingChangesService, logWriter logWriter, logger logger, waitDuration time.Duration) ApplyChanges {
+// finishingLogWriter is a logWriter that holds back incomplete lines, and
+// needs to be told when no more logs will be received.
+type finishingLogWriter interface {
+	logWriter
+	Finish(logs string) error
+}
+
+func NewApplyChanges(service applyChangesService, pendingService pendingChangesService, logWriter logWriter, logger logger, results resultWriter, waitDuration time.Duration) ApplyChanges {
 	return ApplyChanges{
 		service:        service,
 		pendingService: pendingService,
 		logger:         logger,
 		logWriter:      logWriter,
+		results:        results,
 		waitDuration:   waitDuration,
 	}
 }
 
+// WithLiveWriter shows the step that is running and its elapsed time on a
+// status line of the writer, below the lines printed by --format progress.
+func (ac ApplyChanges) WithLiveWriter(liveWriter liveWriter) ApplyChanges {
+	ac.liveWriter = liveWriter
+	return ac
+}
+
 func (ac ApplyChanges) Execute(args []string) error {
 	if _, err := jhanda.Parse(&ac.Options, args); err != nil {
-		return fmt.Errorf("could not parse apply-changes flags: %s", err)
+		return configErrorf("could not parse apply-changes flags: %w", err)
+	}
+
+	logWriter, err := ac.installationLogWriter()
+	if err != nil {
+		return err
+	}
+	if closer, ok := logWriter.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	if ac.Options.Watch != 0 {
+		if ac.Options.SkipDeployProducts || ac.Options.SkipUnchangedProducts || len(ac.Options.ProductNames) > 0 || ac.Options.IgnoreWarnings {
+			return configErrorf("watch flag can only be passed with the format flag")
+		}
+
+		ac.logger.Printf("watching installation (Installation ID: %d)", ac.Options.Watch)
+		return ac.waitForInstallation(ac.Options.Watch, logWriter)
 	}
 
 	changedProducts := []string{}
@@ -58,34 +95,42 @@ func (ac ApplyChanges) Execute(args []string) error {
 
 	if len(ac.Options.ProductNames) > 0 {
 		if ac.Options.SkipDeployProducts {
-			return fmt.Errorf("product-name flag can not be passed with the skip-deploy-products flag")
+			return configErrorf("product-name flag can not be passed with the skip-deploy-products flag")
 		}
 		if ac.Options.SkipUnchangedProducts {
-			return fmt.Errorf("product-name flag can not be passed with the skip-unchanged-products flag")
+			return configErrorf("product-name flag can not be passed with the skip-unchanged-products flag")
 		}
+	}
+
+	if len(ac.Options.ProductNames) > 0 || ac.Options.SkipUnchangedProducts {
 		info, err := ac.service.Info()
 		if err != nil {
-			return fmt.Errorf("could not retrieve info from targetted ops manager: %v", err)
+			return fmt.Errorf("could not retrieve info from targetted ops manager: %w", err)
 		}
-		if !info.VersionAtLeast(2, 2) {
-			return fmt.Errorf("--product-name is only available with Ops Manager 2.2 or later: you are running %s", info.Version)
+
+		if len(ac.Options.ProductNames) > 0 {
+			err = requireCapability(info, api.CapabilitySelectiveDeploys, "--product-name")
+			if err != nil {
+				return err
+			}
 		}
-		for _, product := range ac.Options.ProductNames {
-			changedProducts = append(changedProducts, product)
+
+		if ac.Options.SkipUnchangedProducts {
+			err = requireCapability(info, api.CapabilityPendingChanges, "--skip-unchanged-products")
+			if err != nil {
+				return err
+			}
 		}
 	}
 
+	for _, product := range ac.Options.ProductNames {
+		changedProducts = append(changedProducts, product)
+	}
+
 	if ac.Options.SkipUnchangedProducts {
 		s, err := ac.pendingService.ListStagedPendingChanges()
 		if err != nil {
-			return fmt.Errorf("could not check for any pending changes installation: %s", err)
-		}
-		info, err := ac.service.Info()
-		if err != nil {
-			return fmt.Errorf("could not retrieve info from targetted ops manager: %v", err)
-		}
-		if !info.VersionAtLeast(2, 2) {
-			return fmt.Errorf("skip-unchanged-products is only available with Ops Manager 2.2 or later: you are running %s", info.Version)
+			return fmt.Errorf("could not check for any pending changes installation: %w", err)
 		}
 		for _, p := range s.ChangeList {
 			ac.logger.Printf("Found product: %s with action of: %s", p.Product, p.Action)
@@ -101,40 +146,76 @@ func (ac ApplyChanges) Execute(args []string) error {
 
 	installation, err := ac.service.RunningInstallation()
 	if err != nil {
-		return fmt.Errorf("could not check for any already running installation: %s", err)
+		return fmt.Errorf("could not check for any already running installation: %w", err)
 	}
 
 	if installation == (api.InstallationsServiceOutput{}) {
 		ac.logger.Printf("attempting to apply changes to the targeted Ops Manager")
 		installation, err = ac.service.CreateInstallation(ac.Options.IgnoreWarnings, deployProducts, changedProducts)
 		if err != nil {
-			return fmt.Errorf("installation failed to trigger: %s", err)
+			return fmt.Errorf("installation failed to trigger: %w", err)
 		}
 	} else {
 		startedAtFormatted := installation.StartedAt.Format(time.UnixDate)
 		ac.logger.Printf("found already running installation...re-attaching (Installation ID: %d, Started: %s)", installation.ID, startedAtFormatted)
 	}
 
+	return ac.waitForInstallation(installation.ID, logWriter)
+}
+
+func (ac ApplyChanges) installationLogWriter() (logWriter, error) {
+	switch ac.Options.Format {
+	case "raw":
+		return ac.logWriter, nil
+	case "progress":
+		if ac.liveWriter != nil {
+			return NewInstallationProgress(ac.liveWriter, time.Second), nil
+		}
+		return NewInstallationEventWriter(presentInstallationProgress(ac.logger)), nil
+	case "json":
+		return NewInstallationEventWriter(presentInstallationEventJSON(ac.logger)), nil
+	default:
+		return nil, configErrorf("unknown format %q: options are raw, progress or json", ac.Options.Format)
+	}
+}
+
+func (ac ApplyChanges) waitForInstallation(id int, logWriter logWriter) error {
 	for {
-		current, err := ac.service.GetInstallation(installation.ID)
+		current, err := ac.service.GetInstallation(id)
 		if err != nil {
-			return fmt.Errorf("installation failed to get status: %s", err)
+			return fmt.Errorf("installation failed to get status: %w", err)
 		}
 
-		install, err := ac.service.GetInstallationLogs(installation.ID)
+		install, err := ac.service.GetInstallationLogs(id)
 		if err != nil {
-			return fmt.Errorf("installation failed to get logs: %s", err)
+			return fmt.Errorf("installation failed to get logs: %w", err)
 		}
 
-		err = ac.logWriter.Flush(install.Logs)
+		finished := current.Status == api.StatusSucceeded || current.Status == api.StatusFailed
+
+		if finisher, ok := logWriter.(finishingLogWriter); ok && finished {
+			err = finisher.Finish(install.Logs)
+		} else {
+			err = logWriter.Flush(install.Logs)
+		}
 		if err != nil {
-			return fmt.Errorf("installation failed to flush logs: %s", err)
+			return fmt.Errorf("installation failed to flush logs: %w", err)
+		}
+
+		if finished {
+			err = ac.results.WriteResult(ApplyChangesResult{
+				InstallationID: id,
+				Status:         current.Status,
+			})
+			if err != nil {
+				return err
+			}
 		}
 
 		if current.Status == api.StatusSucceeded {
 			return nil
 		} else if current.Status == api.StatusFailed {
-			return errors.New("installation was unsuccessful")
+			return InstallationFailedError{InstallationID: id, Err: errors.New("installation was unsuccessful")}
 		}
 
 		time.Sleep(ac.waitDuration)
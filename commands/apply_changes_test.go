@@ -3,6 +3,7 @@ package commands_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pivotal-cf/jhanda"
@@ -12,6 +13,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 type netError struct {
@@ -31,6 +33,7 @@ var _ = Describe("ApplyChanges", func() {
 		service        *fakes.ApplyChangesService
 		pendingService *fakes.PendingChangesService
 		logger         *fakes.Logger
+		results        *fakes.ResultWriter
 		writer         *fakes.LogWriter
 		statusOutputs  []api.InstallationsServiceOutput
 		statusErrors   []error
@@ -44,6 +47,7 @@ var _ = Describe("ApplyChanges", func() {
 		service = &fakes.ApplyChangesService{}
 		pendingService = &fakes.PendingChangesService{}
 		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
 		writer = &fakes.LogWriter{}
 
 		statusCount = 0
@@ -88,7 +92,7 @@ var _ = Describe("ApplyChanges", func() {
 		})
 
 		It("applies changes to the Ops Manager", func() {
-			command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+			command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 			err := command.Execute([]string{})
 			Expect(err).NotTo(HaveOccurred())
@@ -118,7 +122,7 @@ var _ = Describe("ApplyChanges", func() {
 			It("applies changes while ignoring warnings", func() {
 				service.InfoReturns(api.Info{Version: "2.3-build43"}, nil)
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 				err := command.Execute([]string{"--ignore-warnings"})
 				Expect(err).NotTo(HaveOccurred())
@@ -130,7 +134,7 @@ var _ = Describe("ApplyChanges", func() {
 
 		Context("when passed the skip-deploy-products flag", func() {
 			It("applies changes while not deploying products", func() {
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 				err := command.Execute([]string{"--skip-deploy-products"})
 				Expect(err).NotTo(HaveOccurred())
@@ -140,9 +144,10 @@ var _ = Describe("ApplyChanges", func() {
 			})
 
 			It("fails if product names were specified", func() {
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 				err := command.Execute([]string{"--skip-deploy-products", "--product-name", "product1"})
 				Expect(err).To(HaveOccurred())
+				Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
 			})
 		})
 
@@ -169,7 +174,7 @@ var _ = Describe("ApplyChanges", func() {
 							},
 						},
 					}, nil)
-					command = commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command = commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 				})
 				It("applies changes to all unchanged products", func() {
 					err := command.Execute([]string{"--skip-unchanged-products"})
@@ -181,6 +186,7 @@ var _ = Describe("ApplyChanges", func() {
 
 				It("fails if product names were specified", func() {
 					err := command.Execute([]string{"--skip-unchanged-products", "--product-name", "product1"})
+					Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
 					Expect(err).To(HaveOccurred())
 				})
 			})
@@ -207,7 +213,7 @@ var _ = Describe("ApplyChanges", func() {
 					}, nil)
 				})
 				It("deploys no products at all", func() {
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 					err := command.Execute([]string{"--skip-unchanged-products"})
 					Expect(err).NotTo(HaveOccurred())
@@ -224,7 +230,7 @@ var _ = Describe("ApplyChanges", func() {
 				service.CreateInstallationReturns(api.InstallationsServiceOutput{}, errors.New("error"))
 				service.RunningInstallationReturns(api.InstallationsServiceOutput{}, nil)
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 				err := command.Execute([]string{"--product-name", "product1", "--product-name", "product2"})
 				Expect(err).To(HaveOccurred())
 
@@ -242,7 +248,7 @@ var _ = Describe("ApplyChanges", func() {
 				StartedAt: &installationStartedAt,
 			}, nil)
 
-			command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+			command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 			err := command.Execute([]string{})
 			Expect(err).NotTo(HaveOccurred())
@@ -270,10 +276,152 @@ var _ = Describe("ApplyChanges", func() {
 
 			logsErrors = []error{nil}
 
-			command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+			command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 			err := command.Execute([]string{})
 			Expect(err).To(MatchError("installation was unsuccessful"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ApplyChangesResult{
+				InstallationID: 311,
+				Status:         "failed",
+			}))
+		})
+
+		It("writes the installation ID and final status as the result", func() {
+			command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ApplyChangesResult{
+				InstallationID: 311,
+				Status:         "succeeded",
+			}))
+		})
+
+		Context("when passed the watch flag", func() {
+			It("follows the given installation without starting a new one", func() {
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
+
+				err := command.Execute([]string{"--watch", "42"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.RunningInstallationCallCount()).To(Equal(0))
+				Expect(service.CreateInstallationCallCount()).To(Equal(0))
+
+				format, content := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("watching installation (Installation ID: 42)"))
+
+				Expect(service.GetInstallationArgsForCall(0)).To(Equal(42))
+				Expect(service.GetInstallationLogsArgsForCall(0)).To(Equal(42))
+				Expect(writer.FlushCallCount()).To(Equal(3))
+			})
+
+			It("fails if flags for a new installation were specified", func() {
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
+
+				err := command.Execute([]string{"--watch", "42", "--ignore-warnings"})
+				Expect(err).To(MatchError("watch flag can only be passed with the format flag"))
+				Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+			})
+		})
+
+		Context("when passed the format flag", func() {
+			BeforeEach(func() {
+				statusOutputs = []api.InstallationsServiceOutput{
+					{Status: "failed"},
+				}
+				statusErrors = []error{nil}
+				logsOutputs = []api.InstallationsServiceOutput{
+					{Logs: installationLogs},
+				}
+				logsErrors = []error{nil}
+			})
+
+			loggedLines := func() []string {
+				var lines []string
+				for i := 0; i < logger.PrintfCallCount(); i++ {
+					format, content := logger.PrintfArgsForCall(i)
+					lines = append(lines, fmt.Sprintf(format, content...))
+				}
+				return lines
+			}
+
+			It("prints a compact progress view with failures highlighted", func() {
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
+
+				err := command.Execute([]string{"--format", "progress"})
+				Expect(err).To(MatchError("installation was unsuccessful"))
+
+				Expect(writer.FlushCallCount()).To(Equal(0))
+				Expect(loggedLines()).To(Equal([]string{
+					"attempting to apply changes to the targeted Ops Manager",
+					"==> deploy cf-abc123 (started 2018-06-12 20:32:57 UTC)",
+					"    task 29 | Preparing deployment: Preparing deployment (00:00:04)",
+					"    task 29 | Updating instance router: router/1d2e3f (0) (canary) (00:05:01)",
+					"    FAILED task 29 | Updating instance router: 'router/1d2e3f (0)' is not running after update.",
+					"    FAILED task 29 | Error: 'router/1d2e3f (0)' is not running after update.",
+					"    FAILED task 29 | error",
+					"<== FAILED deploy cf-abc123 in 310s (exit status 1)",
+				}))
+			})
+
+			It("shows the running step and its elapsed time on a status line", func() {
+				liveWriter := &fakes.LiveWriter{}
+				bypass := gbytes.NewBuffer()
+				liveWriter.BypassReturns(bypass)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1).WithLiveWriter(liveWriter)
+
+				err := command.Execute([]string{"--format", "progress"})
+				Expect(err).To(MatchError("installation was unsuccessful"))
+
+				Expect(loggedLines()).To(Equal([]string{
+					"attempting to apply changes to the targeted Ops Manager",
+				}))
+				Expect(bypass).To(gbytes.Say(`==> deploy cf-abc123 \(started 2018-06-12 20:32:57 UTC\)`))
+				Expect(bypass).To(gbytes.Say(`<== FAILED deploy cf-abc123 in 310s \(exit status 1\)`))
+
+				Expect(liveWriter.WriteCallCount()).To(BeNumerically(">", 0))
+				Expect(string(liveWriter.WriteArgsForCall(0))).To(MatchRegexp(`^\x1b\[2K\.\.\. deploy cf-abc123 running for \d+h\d+m\d+s\n$`))
+				Expect(liveWriter.FlushCallCount()).To(Equal(liveWriter.WriteCallCount()))
+			})
+
+			It("prints the last event when the logs do not end with a newline", func() {
+				logsOutputs = []api.InstallationsServiceOutput{
+					{Logs: strings.TrimSuffix(installationLogs, "\n")},
+				}
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
+
+				err := command.Execute([]string{"--format", "progress"})
+				Expect(err).To(MatchError("installation was unsuccessful"))
+
+				lines := loggedLines()
+				Expect(lines[len(lines)-1]).To(Equal("<== FAILED deploy cf-abc123 in 310s (exit status 1)"))
+			})
+
+			It("prints one JSON object per event", func() {
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
+
+				err := command.Execute([]string{"--format", "json"})
+				Expect(err).To(MatchError("installation was unsuccessful"))
+
+				lines := loggedLines()
+				Expect(lines).To(HaveLen(9))
+				Expect(lines[1]).To(MatchJSON(`{"type": "step", "time": "2018-06-12 20:32:57 UTC", "step": "deploy", "product": "cf-abc123", "state": "running"}`))
+				Expect(lines[3]).To(MatchJSON(`{"type": "stage", "time": "20:33:05", "step": "deploy", "product": "cf-abc123", "task": 29, "stage": "Updating instance router", "job": "router", "instance": "1d2e3f", "state": "started", "message": "router/1d2e3f (0) (canary)"}`))
+				Expect(lines[8]).To(MatchJSON(`{"type": "step", "time": "2018-06-12 20:38:07 UTC", "step": "deploy", "product": "cf-abc123", "state": "failed", "duration": "310s", "message": "exit status 1"}`))
+			})
+
+			It("fails on an unknown format", func() {
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
+
+				err := command.Execute([]string{"--format", "xml"})
+				Expect(err).To(MatchError(`unknown format "xml": options are raw, progress or json`))
+				Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+				Expect(service.CreateInstallationCallCount()).To(Equal(0))
+			})
 		})
 
 		Context("failure cases", func() {
@@ -281,7 +429,7 @@ var _ = Describe("ApplyChanges", func() {
 				It("returns an error", func() {
 					service.RunningInstallationReturns(api.InstallationsServiceOutput{}, errors.New("some error"))
 
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("could not check for any already running installation: some error"))
@@ -294,18 +442,39 @@ var _ = Describe("ApplyChanges", func() {
 					for _, version := range versions {
 						service.InfoReturns(api.Info{Version: version}, nil)
 
-						command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+						command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 						err := command.Execute([]string{"--product-name", "p-mysql"})
 						Expect(err).To(MatchError(fmt.Sprintf("--product-name is only available with Ops Manager 2.2 or later: you are running %s", version)))
 					}
 				})
 			})
 
+			Context("when --skip-unchanged-products is used with an old version of ops manager", func() {
+				It("returns an error before checking the pending changes", func() {
+					service.InfoReturns(api.Info{Version: "2.1-build.326"}, nil)
+
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
+					err := command.Execute([]string{"--skip-unchanged-products"})
+					Expect(err).To(MatchError("--skip-unchanged-products is only available with Ops Manager 2.2 or later: you are running 2.1-build.326"))
+					Expect(pendingService.ListStagedPendingChangesCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the version of ops manager cannot be parsed", func() {
+				It("returns an error", func() {
+					service.InfoReturns(api.Info{Version: "unknown"}, nil)
+
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
+					err := command.Execute([]string{"--product-name", "p-mysql"})
+					Expect(err).To(MatchError(`could not determine whether --product-name is supported: invalid Ops Manager version: "unknown"`))
+				})
+			})
+
 			Context("when an installation cannot be triggered", func() {
 				It("returns an error", func() {
 					service.CreateInstallationReturns(api.InstallationsServiceOutput{}, errors.New("some error"))
 
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to trigger: some error"))
@@ -320,7 +489,7 @@ var _ = Describe("ApplyChanges", func() {
 
 					statusErrors = []error{errors.New("another error")}
 
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to get status: another error"))
@@ -341,7 +510,7 @@ var _ = Describe("ApplyChanges", func() {
 
 					logsErrors = []error{errors.New("no")}
 
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to get logs: no"))
@@ -364,7 +533,7 @@ var _ = Describe("ApplyChanges", func() {
 
 					writer.FlushReturns(errors.New("yes"))
 
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, results, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to flush logs: yes"))
@@ -375,7 +544,7 @@ var _ = Describe("ApplyChanges", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewApplyChanges(nil, nil, nil, nil, 1)
+			command := commands.NewApplyChanges(nil, nil, nil, nil, nil, 1)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This authenticated command kicks off an install of any staged changes on the Ops Manager.",
 				ShortDescription: "triggers an install on the Ops Manager targeted",
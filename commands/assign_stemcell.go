@@ -4,17 +4,24 @@ import (
 	"fmt"
 	"strings"
 
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/versions"
 )
 
 type AssignStemcell struct {
-	logger  logger
-	service assignStemcellService
-	Options struct {
-		ConfigFile      string `long:"config"   short:"c"  description:"path to yml file for configuration (keys must match the following command line flags)"`
-		ProductName     string `long:"product"  short:"p"  description:"name of Ops Manager tile to associate a stemcell to" required:"true"`
-		StemcellVersion string `long:"stemcell" short:"s"  description:"associate a particular stemcell version to a tile." default:"latest"`
+	logger            logger
+	service           assignStemcellService
+	metadataExtractor metadataExtractor
+	results           resultWriter
+	varsStore         boshtpl.Variables
+	Options           struct {
+		ConfigFile                string `long:"config"                       short:"c"  description:"path to yml file for configuration (keys must match the following command line flags)"`
+		ProductName               string `long:"product"                      short:"p"  description:"name of Ops Manager tile to associate a stemcell to" required:"true"`
+		StemcellVersion           string `long:"stemcell"                     short:"s"  description:"associate a particular stemcell version to a tile." default:"latest"`
+		ProductFile               string `long:"product-file"                            description:"path to the product file, to check that a compatible stemcell is uploaded and the products the tile requires are staged"`
+		FailOnMissingDependencies bool   `long:"fail-on-missing-dependencies"            description:"fail instead of warning when a product required by the tile is not staged"`
 	}
 }
 
@@ -22,12 +29,16 @@ type AssignStemcell struct {
 type assignStemcellService interface {
 	ListStemcells() (api.ProductStemcells, error)
 	AssignStemcell(input api.ProductStemcells) error
+	GetDiagnosticReport() (api.DiagnosticReport, error)
 }
 
-func NewAssignStemcell(service assignStemcellService, logger logger) AssignStemcell {
+func NewAssignStemcell(service assignStemcellService, metadataExtractor metadataExtractor, logger logger, results resultWriter, varsStore boshtpl.Variables) AssignStemcell {
 	return AssignStemcell{
-		service: service,
-		logger:  logger,
+		service:           service,
+		metadataExtractor: metadataExtractor,
+		logger:            logger,
+		results:           results,
+		varsStore:         varsStore,
 	}
 }
 
@@ -41,9 +52,9 @@ func (as AssignStemcell) Usage() jhanda.Usage {
 }
 
 func (as AssignStemcell) Execute(args []string) error {
-	err := loadConfigFile(args, &as.Options, nil)
+	err := loadConfigFile(args, &as.Options, nil, as.varsStore)
 	if err != nil {
-		return fmt.Errorf("could not parse assign-stemcell flags: %s", err)
+		return configErrorf("could not parse assign-stemcell flags: %w", err)
 	}
 
 	as.logger.Printf("finding available stemcells for product: \"%s\"...", as.Options.ProductName)
@@ -56,8 +67,24 @@ func (as AssignStemcell) Execute(args []string) error {
 		return fmt.Errorf("could not assign stemcell: product \"%s\" is staged for deletion", as.Options.ProductName)
 	}
 
+	if as.Options.ProductFile != "" {
+		metadata, err := as.metadataExtractor.ExtractMetadata(as.Options.ProductFile)
+		if err != nil {
+			return fmt.Errorf("failed to extract product metadata: %w", err)
+		}
+
+		if metadata.Name != as.Options.ProductName {
+			return configErrorf("product file is for %s, not %s", metadata.Name, as.Options.ProductName)
+		}
+
+		err = checkProductDependencies(as.service, as.logger, metadata, as.Options.FailOnMissingDependencies)
+		if err != nil {
+			return err
+		}
+	}
+
 	as.logger.Println("validating that stemcell exists in Ops Manager...")
-	stemcellVersion, err := as.validateStemcellVersion(productStemcell)
+	stemcellVersion, err := as.validateStemcellVersion(productStemcell, as.Options.ProductFile != "")
 	if err != nil {
 		return err
 	}
@@ -76,7 +103,12 @@ func (as AssignStemcell) Execute(args []string) error {
 	}
 
 	as.logger.Println("assigned stemcell successfully")
-	return nil
+
+	return as.results.WriteResult(AssignStemcellResult{
+		ProductName:     as.Options.ProductName,
+		GUID:            productStemcell.GUID,
+		StemcellVersion: stemcellVersion,
+	})
 }
 
 func (as AssignStemcell) getProductStemcell() (api.ProductStemcell, error) {
@@ -93,10 +125,14 @@ func (as AssignStemcell) getProductStemcell() (api.ProductStemcell, error) {
 		}
 	}
 
-	return result, fmt.Errorf("could not list product stemcell: product \"%s\" not found", as.Options.ProductName)
+	return result, NotFoundError{Err: fmt.Errorf("could not list product stemcell: product \"%s\" not found", as.Options.ProductName)}
 }
 
-func (as *AssignStemcell) validateStemcellVersion(productStemcell api.ProductStemcell) (string, error) {
+// validateStemcellVersion checks that the stemcell version is available for
+// the product. When checkCompatibility is set, the version also has to
+// satisfy the stemcell version the product requires, and latest is the
+// latest of those that do.
+func (as *AssignStemcell) validateStemcellVersion(productStemcell api.ProductStemcell, checkCompatibility bool) (string, error) {
 	availableVersions := productStemcell.AvailableVersions
 
 	if len(availableVersions) == 0 {
@@ -107,12 +143,44 @@ func (as *AssignStemcell) validateStemcellVersion(productStemcell api.ProductSte
 			productStemcell.RequiredStemcellVersion)
 	}
 
-	if as.Options.StemcellVersion == "latest" {
+	compatible := func(version string) bool {
+		return !checkCompatibility || stemcellSatisfies(version, productStemcell.RequiredStemcellVersion)
+	}
+
+	if as.Options.StemcellVersion == "latest" && !checkCompatibility {
 		return availableVersions[len(availableVersions)-1], nil
 	}
 
+	if as.Options.StemcellVersion == "latest" {
+		var compatibleVersions []string
+		for _, version := range availableVersions {
+			if compatible(version) {
+				compatibleVersions = append(compatibleVersions, version)
+			}
+		}
+
+		if len(compatibleVersions) == 0 {
+			return "", fmt.Errorf("no stemcells compatible with \"%s\" are available. "+
+				"minimum required stemcell version is: %s. "+
+				"Available Stemcells: %s. "+
+				"upload-stemcell, and try again",
+				as.Options.ProductName,
+				productStemcell.RequiredStemcellVersion,
+				strings.Join(availableVersions, ", "))
+		}
+		latest, found := versions.LatestVersion(compatibleVersions, compatible)
+		if !found {
+			return compatibleVersions[len(compatibleVersions)-1], nil
+		}
+		return latest, nil
+	}
+
 	for _, version := range availableVersions {
 		if as.Options.StemcellVersion == version {
+			if !compatible(version) {
+				return "", fmt.Errorf("stemcell version %s is not compatible with \"%s\": minimum required stemcell version is: %s",
+					version, as.Options.ProductName, productStemcell.RequiredStemcellVersion)
+			}
 			return as.Options.StemcellVersion, nil
 		}
 	}
@@ -120,3 +188,24 @@ func (as *AssignStemcell) validateStemcellVersion(productStemcell api.ProductSte
 	return "", fmt.Errorf(`stemcell version %s not found in Ops Manager. 
 	Available Stemcells for "%s": %s`, as.Options.StemcellVersion, as.Options.ProductName, strings.Join(availableVersions, ", "))
 }
+
+// stemcellSatisfies reports whether a stemcell version is of the same major
+// version as the required one and not older. Versions that cannot be parsed
+// are left for Ops Manager to judge.
+func stemcellSatisfies(version, required string) bool {
+	if required == "" {
+		return true
+	}
+
+	requiredVersion, err := versions.ParseVersion(required)
+	if err != nil {
+		return true
+	}
+
+	v, err := versions.ParseVersion(version)
+	if err != nil {
+		return true
+	}
+
+	return v.Major == requiredVersion.Major && v.Compare(requiredVersion) >= 0
+}
@@ -1,26 +1,33 @@
 package commands_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
-	"io/ioutil"
-	"os"
+	"github.com/pivotal-cf/om/extractor"
 )
 
 var _ = Describe("AssignStemcell", func() {
 	var (
-		fakeService *fakes.AssignStemcellService
-		logger      *fakes.Logger
-		command     commands.AssignStemcell
+		fakeService       *fakes.AssignStemcellService
+		metadataExtractor *fakes.MetadataExtractor
+		logger            *fakes.Logger
+		results           *fakes.ResultWriter
+		command           commands.AssignStemcell
 	)
 
 	BeforeEach(func() {
 		fakeService = &fakes.AssignStemcellService{}
+		metadataExtractor = &fakes.MetadataExtractor{}
 		logger = &fakes.Logger{}
-		command = commands.NewAssignStemcell(fakeService, logger)
+		results = &fakes.ResultWriter{}
+		command = commands.NewAssignStemcell(fakeService, metadataExtractor, logger, results, nil)
 	})
 
 	Context("when --stemcell exists for the specified product", func() {
@@ -55,6 +62,13 @@ var _ = Describe("AssignStemcell", func() {
 					},
 				},
 			}))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.AssignStemcellResult{
+				ProductName:     "cf",
+				GUID:            "cf-guid",
+				StemcellVersion: "1234.6",
+			}))
 		})
 
 		Context("when --stemcell latest is used", func() {
@@ -188,6 +202,92 @@ stemcell: "1234.6"
 		})
 	})
 
+	Context("when some of the available stemcells are older than the required stemcell", func() {
+		BeforeEach(func() {
+			fakeService.ListStemcellsReturns(api.ProductStemcells{
+				Products: []api.ProductStemcell{
+					{
+						GUID:                    "cf-guid",
+						ProductName:             "cf",
+						RequiredStemcellVersion: "1234.9",
+						AvailableVersions: []string{
+							"1234.5", "1234.10", "1234.9", "1235.1",
+						},
+					},
+				},
+			}, nil)
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{Name: "cf", Version: "2.3.0"}, nil)
+		})
+
+		It("assigns the last available stemcell, like Ops Manager accepts it", func() {
+			err := command.Execute([]string{"--product", "cf"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.AssignStemcellArgsForCall(0)).To(Equal(api.ProductStemcells{
+				Products: []api.ProductStemcell{
+					{
+						GUID:                  "cf-guid",
+						StagedStemcellVersion: "1235.1",
+					},
+				},
+			}))
+		})
+
+		It("assigns the latest compatible stemcell when --product-file is given", func() {
+			err := command.Execute([]string{"--product", "cf", "--product-file", "/path/to/cf.pivotal"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.AssignStemcellArgsForCall(0)).To(Equal(api.ProductStemcells{
+				Products: []api.ProductStemcell{
+					{
+						GUID:                  "cf-guid",
+						StagedStemcellVersion: "1234.10",
+					},
+				},
+			}))
+		})
+
+		It("assigns an older stemcell that is given without --product-file", func() {
+			err := command.Execute([]string{"--product", "cf", "--stemcell", "1234.5"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.AssignStemcellArgsForCall(0).Products[0].StagedStemcellVersion).To(Equal("1234.5"))
+		})
+
+		It("returns an error when an incompatible stemcell is given with --product-file", func() {
+			err := command.Execute([]string{"--product", "cf", "--stemcell", "1234.5", "--product-file", "/path/to/cf.pivotal"})
+			Expect(err).To(MatchError("stemcell version 1234.5 is not compatible with \"cf\": minimum required stemcell version is: 1234.9"))
+
+			Expect(fakeService.AssignStemcellCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when none of the available stemcells is compatible", func() {
+		BeforeEach(func() {
+			fakeService.ListStemcellsReturns(api.ProductStemcells{
+				Products: []api.ProductStemcell{
+					{
+						GUID:                    "cf-guid",
+						ProductName:             "cf",
+						RequiredStemcellVersion: "1234.9",
+						AvailableVersions:       []string{"1234.5", "1233.99"},
+					},
+				},
+			}, nil)
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{Name: "cf", Version: "2.3.0"}, nil)
+		})
+
+		It("returns an error when --product-file is given", func() {
+			err := command.Execute([]string{"--product", "cf", "--product-file", "/path/to/cf.pivotal"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no stemcells compatible with \"cf\" are available."))
+			Expect(err.Error()).To(ContainSubstring("minimum required stemcell version is: 1234.9"))
+			Expect(err.Error()).To(ContainSubstring("Available Stemcells: 1234.5, 1233.99"))
+
+			Expect(fakeService.AssignStemcellCallCount()).To(Equal(0))
+		})
+	})
+
 	Context("when the product is not found but the stemcell exists", func() {
 		BeforeEach(func() {
 			fakeService.ListStemcellsReturns(api.ProductStemcells{
@@ -266,6 +366,65 @@ stemcell: "1234.6"
 		})
 	})
 
+	Context("when --product-file is given", func() {
+		BeforeEach(func() {
+			fakeService.ListStemcellsReturns(api.ProductStemcells{
+				Products: []api.ProductStemcell{
+					{
+						GUID:              "some-guid",
+						ProductName:       "p-isolation-segment",
+						AvailableVersions: []string{"97.19"},
+					},
+				},
+			}, nil)
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+				Name:    "p-isolation-segment",
+				Version: "2.3.0",
+				Dependencies: []extractor.ProductDependency{
+					{Name: "cf", Version: "~> 2.3"},
+				},
+			}, nil)
+		})
+
+		It("assigns the stemcell when the products the tile requires are staged", func() {
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				StagedProducts: []api.DiagnosticProduct{
+					{Name: "cf", Version: "2.3.1-build.5"},
+				},
+			}, nil)
+
+			err := command.Execute([]string{"--product", "p-isolation-segment", "--product-file", "/path/to/some-product.tgz", "--fail-on-missing-dependencies"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metadataExtractor.ExtractMetadataArgsForCall(0)).To(Equal("/path/to/some-product.tgz"))
+			Expect(fakeService.AssignStemcellCallCount()).To(Equal(1))
+		})
+
+		It("warns about a missing product and assigns the stemcell", func() {
+			err := command.Execute([]string{"--product", "p-isolation-segment", "--product-file", "/path/to/some-product.tgz"})
+			Expect(err).NotTo(HaveOccurred())
+
+			format, content := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("warning: required product cf ~> 2.3 is not staged"))
+			Expect(fakeService.AssignStemcellCallCount()).To(Equal(1))
+		})
+
+		It("fails before assigning the stemcell when --fail-on-missing-dependencies is given", func() {
+			err := command.Execute([]string{"--product", "p-isolation-segment", "--product-file", "/path/to/some-product.tgz", "--fail-on-missing-dependencies"})
+			Expect(err).To(MatchError("dependencies of p-isolation-segment 2.3.0 are not met: required product cf ~> 2.3 is not staged"))
+			Expect(fakeService.AssignStemcellCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when the product file is for another product", func() {
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{Name: "cf", Version: "2.3.0"}, nil)
+
+			err := command.Execute([]string{"--product", "p-isolation-segment", "--product-file", "/path/to/some-product.tgz"})
+			Expect(err).To(MatchError("product file is for cf, not p-isolation-segment"))
+			Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+			Expect(fakeService.AssignStemcellCallCount()).To(Equal(0))
+		})
+	})
+
 	Context("when an unknown flag is provided", func() {
 		It("returns an error", func() {
 			err := command.Execute([]string{"--badflag"})
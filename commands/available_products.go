@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/models"
@@ -14,7 +12,7 @@ type AvailableProducts struct {
 	presenter presenters.FormattedPresenter
 	logger    logger
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -30,7 +28,7 @@ func NewAvailableProducts(apService availableProductsService, presenter presente
 
 func (ap AvailableProducts) Execute(args []string) error {
 	if _, err := jhanda.Parse(&ap.Options, args); err != nil {
-		return fmt.Errorf("could not parse available-products flags: %s", err)
+		return configErrorf("could not parse available-products flags: %w", err)
 	}
 
 	output, err := ap.service.ListAvailableProducts()
@@ -51,10 +49,12 @@ func (ap AvailableProducts) Execute(args []string) error {
 		})
 	}
 
-	ap.presenter.SetFormat(ap.Options.Format)
-	ap.presenter.PresentAvailableProducts(products)
+	err = ap.presenter.SetFormat(ap.Options.Format)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return ap.presenter.PresentAvailableProducts(products)
 }
 
 func (ap AvailableProducts) Usage() jhanda.Usage {
@@ -100,6 +100,16 @@ var _ = Describe("AvailableProducts", func() {
 			})
 		})
 
+		Context("when the format is not supported", func() {
+			It("returns the error", func() {
+				fakePresenter.SetFormatReturns(errors.New("unknown format \"xml\""))
+
+				err := command.Execute([]string{"--format", "xml"})
+				Expect(err).To(MatchError(`unknown format "xml"`))
+				Expect(fakePresenter.PresentAvailableProductsCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when an unknown flag is passed", func() {
 			It("returns an error", func() {
 				err := command.Execute([]string{"--unknown-flag"})
@@ -0,0 +1,338 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/validator"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	backupInstallationFile   = "installation.zip"
+	backupDirectorConfigFile = "staged-director-config.yml"
+	backupStagedConfigDir    = "staged-config"
+	backupManifestDir        = "deployed-manifests"
+	backupManifestFile       = "manifest.yml"
+)
+
+// backupDirFormat names the backup directories. The microseconds keep two
+// backups started in the same second apart, and the fixed width keeps the
+// names sorted in the order the backups were made.
+const backupDirFormat = "20060102T150405.000000Z"
+
+// backupDirPattern matches the directories that backup creates, so that
+// pruning never removes anything else in --dir. Backups made before the
+// microseconds were added are matched as well.
+var backupDirPattern = regexp.MustCompile(`^backup-\d{8}T\d{6}(?:\.\d{6})?Z$`)
+
+type Backup struct {
+	service backupService
+	logger  logger
+	results resultWriter
+	Options struct {
+		Dir             string `long:"dir"              short:"d"  required:"true" description:"directory to write the backup to, in a new timestamped directory"`
+		Keep            int    `long:"keep"             short:"k"                  description:"number of backups to keep in --dir, removing the oldest ones (default: keep all)"`
+		PollingInterval int    `long:"polling-interval" short:"pi"                 description:"interval (in seconds) at which to print the export status" default:"1"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/backup_service.go --fake-name BackupService . backupService
+type backupService interface {
+	exportInstallationService
+	stagedConfigService
+	stagedDirectorConfigService
+	GetDeployedProductManifest(guid string) (string, error)
+	GetDiagnosticReport() (api.DiagnosticReport, error)
+	Info() (api.Info, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
+}
+
+type backupProduct struct {
+	Name     string `yaml:"name"`
+	Version  string `yaml:"version"`
+	Stemcell string `yaml:"stemcell,omitempty"`
+}
+
+type backupFile struct {
+	Path   string `yaml:"path"`
+	SHA256 string `yaml:"sha256"`
+}
+
+type backupManifest struct {
+	CreatedAt         string          `yaml:"created_at"`
+	OpsManagerVersion string          `yaml:"ops_manager_version"`
+	Stemcells         []string        `yaml:"stemcells"`
+	StagedProducts    []backupProduct `yaml:"staged_products"`
+	DeployedProducts  []backupProduct `yaml:"deployed_products"`
+	Files             []backupFile    `yaml:"files"`
+}
+
+func NewBackup(service backupService, logger logger, results resultWriter) Backup {
+	return Backup{
+		service: service,
+		logger:  logger,
+		results: results,
+	}
+}
+
+func (b Backup) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command exports the installation, and writes the staged director config, the staged config of every product (with placeholders for credentials), the deployed manifests and a manifest with versions and SHA256 checksums to a new directory in --dir",
+		ShortDescription: "backs up the installation and configuration of the target Ops Manager",
+		Flags:            b.Options,
+	}
+}
+
+func (b Backup) Execute(args []string) error {
+	if _, err := jhanda.Parse(&b.Options, args); err != nil {
+		return configErrorf("could not parse backup flags: %w", err)
+	}
+
+	if b.Options.Keep < 0 {
+		return configErrorf("--keep must not be negative")
+	}
+
+	createdAt := time.Now().UTC()
+	dir := filepath.Join(b.Options.Dir, "backup-"+createdAt.Format(backupDirFormat))
+
+	// the backup contains credentials, such as in the deployed manifests and
+	// the installation, so it is only readable by the operator
+	err := os.MkdirAll(b.Options.Dir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create backup directory: %w", err)
+	}
+
+	err = os.Mkdir(dir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create backup directory: %w", err)
+	}
+
+	b.logger.Printf("backing up Ops Manager to %s", dir)
+
+	info, err := b.service.Info()
+	if err != nil {
+		return fmt.Errorf("could not retrieve info from targetted ops manager: %w", err)
+	}
+
+	report, err := b.service.GetDiagnosticReport()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve diagnostic report: %w", err)
+	}
+
+	b.logger.Printf("exporting installation")
+
+	// the export truncates the existing file, which keeps its mode
+	err = createPrivateFile(filepath.Join(dir, backupInstallationFile))
+	if err != nil {
+		return fmt.Errorf("failed to export installation: %w", err)
+	}
+
+	err = b.service.DownloadInstallationAssetCollection(filepath.Join(dir, backupInstallationFile), b.Options.PollingInterval)
+	if err != nil {
+		return fmt.Errorf("failed to export installation: %w", err)
+	}
+
+	files := []string{backupInstallationFile}
+
+	b.logger.Printf("writing staged director config")
+	err = b.writeFile(dir, backupDirectorConfigFile, func(logger logger) error {
+		return NewStagedDirectorConfig(b.service, logger).Execute([]string{"--include-placeholders"})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write staged director config: %w", err)
+	}
+	files = append(files, backupDirectorConfigFile)
+
+	stagedProducts, err := b.service.ListStagedProducts()
+	if err != nil {
+		return fmt.Errorf("could not list staged products: %w", err)
+	}
+
+	for _, product := range stagedProducts.Products {
+		if product.Type == "p-bosh" {
+			continue
+		}
+
+		b.logger.Printf("writing staged config for %s", product.Type)
+		name := filepath.Join(backupStagedConfigDir, product.Type+".yml")
+		err = b.writeFile(dir, name, func(logger logger) error {
+			return NewStagedConfig(b.service, logger).Execute([]string{"--product-name", product.Type, "--include-placeholders"})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write staged config for %s: %w", product.Type, err)
+		}
+		files = append(files, name)
+	}
+
+	deployedProducts, err := b.service.ListDeployedProducts()
+	if err != nil {
+		return fmt.Errorf("could not list deployed products: %w", err)
+	}
+
+	for _, product := range deployedProducts {
+		if product.Type == "p-bosh" {
+			continue
+		}
+
+		b.logger.Printf("writing deployed manifest for %s", product.Type)
+		manifest, err := b.service.GetDeployedProductManifest(product.GUID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve deployed manifest for %s: %w", product.Type, err)
+		}
+
+		name := filepath.Join(backupManifestDir, product.Type+".yml")
+		err = b.writeFile(dir, name, func(logger logger) error {
+			logger.Print(manifest)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write deployed manifest for %s: %w", product.Type, err)
+		}
+		files = append(files, name)
+	}
+
+	manifest := backupManifest{
+		CreatedAt:         createdAt.Format(time.RFC3339),
+		OpsManagerVersion: info.Version,
+		Stemcells:         report.Stemcells,
+		StagedProducts:    backupProducts(report.StagedProducts),
+		DeployedProducts:  backupProducts(report.DeployedProducts),
+	}
+
+	calculator := validator.NewSHA256Calculator()
+	for _, name := range files {
+		sum, err := calculator.Checksum(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("could not calculate checksum of %s: %w", name, err)
+		}
+		manifest.Files = append(manifest.Files, backupFile{Path: filepath.ToSlash(name), SHA256: sum})
+	}
+
+	contents, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal backup manifest: %w", err) // un-tested
+	}
+
+	// the manifest is written last, so that only complete backups have one
+	err = ioutil.WriteFile(filepath.Join(dir, backupManifestFile), contents, 0600)
+	if err != nil {
+		return fmt.Errorf("could not write backup manifest: %w", err)
+	}
+
+	b.logger.Printf("finished backing up Ops Manager to %s", dir)
+
+	removed := []string{}
+	if b.Options.Keep > 0 {
+		removed, err = b.prune()
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.results.WriteResult(BackupResult{
+		Dir:     dir,
+		Removed: removed,
+	})
+}
+
+// writeFile creates the file at name in dir, and writes what the write
+// function logs to it.
+func (b Backup) writeFile(dir, name string, write func(logger) error) error {
+	path := filepath.Join(dir, name)
+
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	err = write(log.New(file, "", 0))
+	if err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// createPrivateFile creates an empty file at path that only the operator can
+// read.
+func createPrivateFile(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// prune removes the oldest complete backups in --dir, keeping --keep of them.
+// Incomplete backups, without a manifest, are left for the operator to
+// inspect.
+// prune removes the oldest backups in --dir until only --keep are left, and
+// returns the paths of the backups it removed.
+func (b Backup) prune() ([]string, error) {
+	entries, err := ioutil.ReadDir(b.Options.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not list backups: %w", err)
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		if !entry.IsDir() || !backupDirPattern.MatchString(entry.Name()) {
+			continue
+		}
+
+		_, err := os.Stat(filepath.Join(b.Options.Dir, entry.Name(), backupManifestFile))
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, entry.Name())
+	}
+
+	// the timestamps in the names sort in the order the backups were made
+	sort.Strings(backups)
+
+	removed := []string{}
+	for len(backups) > b.Options.Keep {
+		path := filepath.Join(b.Options.Dir, backups[0])
+
+		b.logger.Printf("removing old backup %s", path)
+		err := os.RemoveAll(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not remove old backup: %w", err)
+		}
+
+		removed = append(removed, path)
+		backups = backups[1:]
+	}
+
+	return removed, nil
+}
+
+func backupProducts(products []api.DiagnosticProduct) []backupProduct {
+	var result []backupProduct
+	for _, product := range products {
+		result = append(result, backupProduct{
+			Name:     product.Name,
+			Version:  product.Version,
+			Stemcell: product.Stemcell,
+		})
+	}
+
+	return result
+}
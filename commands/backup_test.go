@@ -0,0 +1,264 @@
+package commands_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Backup", func() {
+	var (
+		service *fakes.BackupService
+		logger  *fakes.Logger
+		results *fakes.ResultWriter
+		dir     string
+	)
+
+	backups := func() []string {
+		matches, err := filepath.Glob(filepath.Join(dir, "backup-*"))
+		Expect(err).NotTo(HaveOccurred())
+		return matches
+	}
+
+	writeOldBackup := func(name string, complete bool) {
+		path := filepath.Join(dir, name)
+		Expect(os.MkdirAll(path, 0755)).To(Succeed())
+		if complete {
+			Expect(ioutil.WriteFile(filepath.Join(path, "manifest.yml"), []byte("{}"), 0644)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "backup")
+		Expect(err).NotTo(HaveOccurred())
+
+		service = &fakes.BackupService{}
+		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
+
+		service.InfoReturns(api.Info{Version: "2.4-build.1"}, nil)
+		service.GetDiagnosticReportReturns(api.DiagnosticReport{
+			Stemcells: []string{"light-bosh-stemcell-170.15-google-kvm-ubuntu-xenial-go_agent.tgz"},
+			StagedProducts: []api.DiagnosticProduct{
+				{Name: "p-bosh", Version: "2.4-build.1", Stemcell: "bosh-stemcell-170.15-google-kvm-ubuntu-xenial-go_agent.tgz"},
+				{Name: "cf", Version: "2.4.0", Stemcell: "light-bosh-stemcell-170.15-google-kvm-ubuntu-xenial-go_agent.tgz"},
+			},
+			DeployedProducts: []api.DiagnosticProduct{
+				{Name: "cf", Version: "2.3.5", Stemcell: "light-bosh-stemcell-170.15-google-kvm-ubuntu-xenial-go_agent.tgz"},
+			},
+		}, nil)
+		service.DownloadInstallationAssetCollectionStub = func(outputFile string, pollingInterval int) error {
+			return ioutil.WriteFile(outputFile, []byte("some-installation"), 0644)
+		}
+		service.ListStagedProductsReturns(api.StagedProductsOutput{
+			Products: []api.StagedProduct{
+				{Type: "p-bosh", GUID: "p-bosh-guid"},
+				{Type: "cf", GUID: "cf-guid"},
+			},
+		}, nil)
+		service.GetStagedProductByNameReturns(api.StagedProductsFindOutput{
+			Product: api.StagedProduct{Type: "cf", GUID: "cf-guid"},
+		}, nil)
+		service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+			".properties.some-secret": {
+				Value:        map[string]interface{}{"secret": "***"},
+				Configurable: true,
+				IsCredential: true,
+				Type:         "secret",
+			},
+		}, nil)
+		service.ListDeployedProductsReturns([]api.DeployedProductOutput{
+			{Type: "p-bosh", GUID: "p-bosh-guid"},
+			{Type: "cf", GUID: "cf-guid"},
+		}, nil)
+		service.GetDeployedProductManifestReturns("name: cf\n", nil)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("writes the installation, configs, deployed manifests and a manifest to a new directory", func() {
+		command := commands.NewBackup(service, logger, results)
+
+		err := command.Execute([]string{"--dir", dir})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(backups()).To(HaveLen(1))
+		backup := backups()[0]
+		Expect(filepath.Base(backup)).To(MatchRegexp(`^backup-\d{8}T\d{6}\.\d{6}Z$`))
+
+		Expect(results.WriteResultCallCount()).To(Equal(1))
+		Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.BackupResult{
+			Dir:     backup,
+			Removed: []string{},
+		}))
+
+		outputFile, pollingInterval := service.DownloadInstallationAssetCollectionArgsForCall(0)
+		Expect(outputFile).To(Equal(filepath.Join(backup, "installation.zip")))
+		Expect(pollingInterval).To(Equal(1))
+
+		Expect(filepath.Join(backup, "staged-director-config.yml")).To(BeAnExistingFile())
+
+		stagedConfig, err := ioutil.ReadFile(filepath.Join(backup, "staged-config", "cf.yml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(stagedConfig)).To(ContainSubstring("product-name: cf"))
+		Expect(string(stagedConfig)).To(ContainSubstring("secret: ((properties_some-secret.secret))"))
+		Expect(filepath.Join(backup, "staged-config", "p-bosh.yml")).NotTo(BeAnExistingFile())
+
+		Expect(service.GetDeployedProductManifestCallCount()).To(Equal(1))
+		Expect(service.GetDeployedProductManifestArgsForCall(0)).To(Equal("cf-guid"))
+		deployedManifest, err := ioutil.ReadFile(filepath.Join(backup, "deployed-manifests", "cf.yml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(deployedManifest)).To(Equal("name: cf\n"))
+
+		contents, err := ioutil.ReadFile(filepath.Join(backup, "manifest.yml"))
+		Expect(err).NotTo(HaveOccurred())
+
+		var manifest struct {
+			CreatedAt         string `yaml:"created_at"`
+			OpsManagerVersion string `yaml:"ops_manager_version"`
+			Stemcells         []string
+			StagedProducts    []map[string]string `yaml:"staged_products"`
+			DeployedProducts  []map[string]string `yaml:"deployed_products"`
+			Files             []map[string]string
+		}
+		Expect(yaml.UnmarshalStrict(contents, &manifest)).To(Succeed())
+
+		Expect(manifest.CreatedAt).NotTo(BeEmpty())
+		Expect(manifest.OpsManagerVersion).To(Equal("2.4-build.1"))
+		Expect(manifest.Stemcells).To(Equal([]string{"light-bosh-stemcell-170.15-google-kvm-ubuntu-xenial-go_agent.tgz"}))
+		Expect(manifest.StagedProducts).To(HaveLen(2))
+		Expect(manifest.DeployedProducts).To(Equal([]map[string]string{
+			{"name": "cf", "version": "2.3.5", "stemcell": "light-bosh-stemcell-170.15-google-kvm-ubuntu-xenial-go_agent.tgz"},
+		}))
+
+		var paths []string
+		for _, file := range manifest.Files {
+			paths = append(paths, file["path"])
+		}
+		Expect(paths).To(Equal([]string{
+			"installation.zip",
+			"staged-director-config.yml",
+			"staged-config/cf.yml",
+			"deployed-manifests/cf.yml",
+		}))
+		Expect(manifest.Files[0]["sha256"]).To(Equal(fmt.Sprintf("%x", sha256.Sum256([]byte("some-installation")))))
+	})
+
+	It("makes the backup readable by the operator only", func() {
+		command := commands.NewBackup(service, logger, results)
+
+		err := command.Execute([]string{"--dir", dir})
+		Expect(err).NotTo(HaveOccurred())
+
+		backup := backups()[0]
+		for _, name := range []string{"", "staged-config", "deployed-manifests"} {
+			info, err := os.Stat(filepath.Join(backup, name))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0700)), name)
+		}
+
+		for _, name := range []string{"installation.zip", "staged-director-config.yml", "staged-config/cf.yml", "deployed-manifests/cf.yml", "manifest.yml"} {
+			info, err := os.Stat(filepath.Join(backup, name))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)), name)
+		}
+	})
+
+	It("does not collide with a backup started in the same second", func() {
+		command := commands.NewBackup(service, logger, results)
+
+		Expect(command.Execute([]string{"--dir", dir})).To(Succeed())
+		Expect(command.Execute([]string{"--dir", dir})).To(Succeed())
+
+		Expect(backups()).To(HaveLen(2))
+	})
+
+	Context("when --keep is given", func() {
+		It("removes the oldest complete backups", func() {
+			writeOldBackup("backup-20180101T000000Z", true)
+			writeOldBackup("backup-20180102T000000Z", true)
+			writeOldBackup("backup-20180103T000000Z", false)
+			writeOldBackup("other-directory", true)
+
+			command := commands.NewBackup(service, logger, results)
+
+			err := command.Execute([]string{"--dir", dir, "--keep", "2"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(filepath.Join(dir, "backup-20180101T000000Z")).NotTo(BeADirectory())
+			Expect(filepath.Join(dir, "backup-20180102T000000Z")).To(BeADirectory())
+			Expect(filepath.Join(dir, "backup-20180103T000000Z")).To(BeADirectory())
+			Expect(filepath.Join(dir, "other-directory")).To(BeADirectory())
+			Expect(backups()).To(HaveLen(3))
+
+			Expect(results.WriteResultArgsForCall(0).(commands.BackupResult).Removed).To(Equal([]string{
+				filepath.Join(dir, "backup-20180101T000000Z"),
+			}))
+		})
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the flags cannot be parsed", func() {
+			command := commands.NewBackup(service, logger, results)
+
+			err := command.Execute([]string{"--unknown-flag"})
+			Expect(err).To(MatchError("could not parse backup flags: flag provided but not defined: -unknown-flag"))
+		})
+
+		It("returns an error when --keep is negative", func() {
+			command := commands.NewBackup(service, logger, results)
+
+			err := command.Execute([]string{"--dir", dir, "--keep", "-1"})
+			Expect(err).To(MatchError("--keep must not be negative"))
+			Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+		})
+
+		It("returns an error when the installation cannot be exported", func() {
+			service.DownloadInstallationAssetCollectionStub = nil
+			service.DownloadInstallationAssetCollectionReturns(errors.New("some-error"))
+			command := commands.NewBackup(service, logger, results)
+
+			err := command.Execute([]string{"--dir", dir})
+			Expect(err).To(MatchError("failed to export installation: some-error"))
+		})
+
+		It("returns an error when a staged config cannot be written", func() {
+			service.GetStagedProductPropertiesReturns(nil, errors.New("some-error"))
+			command := commands.NewBackup(service, logger, results)
+
+			err := command.Execute([]string{"--dir", dir})
+			Expect(err).To(MatchError("failed to write staged config for cf: some-error"))
+		})
+
+		It("returns an error when a deployed manifest cannot be retrieved", func() {
+			service.GetDeployedProductManifestReturns("", errors.New("some-error"))
+			command := commands.NewBackup(service, logger, results)
+
+			err := command.Execute([]string{"--dir", dir})
+			Expect(err).To(MatchError("failed to retrieve deployed manifest for cf: some-error"))
+		})
+
+		It("does not write a manifest for an incomplete backup", func() {
+			service.GetDiagnosticReportReturns(api.DiagnosticReport{}, api.DiagnosticReportUnavailable{})
+			command := commands.NewBackup(service, logger, results)
+
+			err := command.Execute([]string{"--dir", dir})
+			Expect(err).To(MatchError("failed to retrieve diagnostic report: diagnostic report is currently unavailable"))
+			Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeUnavailable))
+			Expect(filepath.Join(backups()[0], "manifest.yml")).NotTo(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+// requireCapability returns an error when the command or flag depends on a
+// capability that the version of Ops Manager does not have, or when the
+// version cannot be determined.
+func requireCapability(info api.Info, capability, feature string) error {
+	supported, err := info.Supports(capability)
+	if err != nil {
+		return fmt.Errorf("could not determine whether %s is supported: %w", feature, err)
+	}
+
+	if !supported {
+		c, _ := api.LookupCapability(capability) // Supports already looked it up
+		return UnsupportedError{Err: fmt.Errorf("%s is only available with Ops Manager %s or later: you are running %s", feature, c.MinimumVersion(), info.Version)}
+	}
+
+	return nil
+}
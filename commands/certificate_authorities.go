@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/presenters"
@@ -12,7 +10,7 @@ type CertificateAuthorities struct {
 	service   certificateAuthoritiesService
 	presenter presenters.FormattedPresenter
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -31,7 +29,7 @@ func NewCertificateAuthorities(certificateAuthoritiesService certificateAuthorit
 
 func (c CertificateAuthorities) Execute(args []string) error {
 	if _, err := jhanda.Parse(&c.Options, args); err != nil {
-		return fmt.Errorf("could not parse certificate-authorities flags: %s", err)
+		return configErrorf("could not parse certificate-authorities flags: %w", err)
 	}
 
 	casOutput, err := c.service.ListCertificateAuthorities()
@@ -39,10 +37,12 @@ func (c CertificateAuthorities) Execute(args []string) error {
 		return err
 	}
 
-	c.presenter.SetFormat(c.Options.Format)
-	c.presenter.PresentCertificateAuthorities(casOutput.CAs)
+	err = c.presenter.SetFormat(c.Options.Format)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return c.presenter.PresentCertificateAuthorities(casOutput.CAs)
 }
 
 func (c CertificateAuthorities) Usage() jhanda.Usage {
@@ -14,7 +14,7 @@ type CertificateAuthority struct {
 	Options   struct {
 		ID      string `long:"id" required:"true" description:"ID of certificate to display"`
 		CertPEM bool   `long:"cert-pem" description:"Display the cert pem"`
-		Format  string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format  string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -28,7 +28,7 @@ func NewCertificateAuthority(certificateAuthoritiesService certificateAuthoritie
 
 func (c CertificateAuthority) Execute(args []string) error {
 	if _, err := jhanda.Parse(&c.Options, args); err != nil {
-		return fmt.Errorf("could not parse certificate-authority flags: %s", err)
+		return configErrorf("could not parse certificate-authority flags: %w", err)
 	}
 
 	cas, err := c.service.ListCertificateAuthorities()
@@ -41,14 +41,18 @@ func (c CertificateAuthority) Execute(args []string) error {
 			if c.Options.CertPEM {
 				c.logger.Println(ca.CertPEM)
 			} else {
-				c.presenter.SetFormat(c.Options.Format)
-				c.presenter.PresentCertificateAuthority(ca)
+				err = c.presenter.SetFormat(c.Options.Format)
+				if err != nil {
+					return err
+				}
+
+				return c.presenter.PresentCertificateAuthority(ca)
 			}
 			return nil
 		}
 	}
 
-	return fmt.Errorf("could not find a certificate authority with ID: %q", c.Options.ID)
+	return NotFoundError{Err: fmt.Errorf("could not find a certificate authority with ID: %q", c.Options.ID)}
 }
 
 func (c CertificateAuthority) Usage() jhanda.Usage {
@@ -35,18 +35,18 @@ func NewConfigTemplate(metadataExtractor metadataExtractor, logger logger) Confi
 
 func (ct ConfigTemplate) Execute(args []string) error {
 	if _, err := jhanda.Parse(&ct.Options, args); err != nil {
-		return fmt.Errorf("could not parse config-template flags: %s", err)
+		return configErrorf("could not parse config-template flags: %w", err)
 	}
 
 	extractedMetadata, err := ct.metadataExtractor.ExtractMetadata(ct.Options.Product)
 	if err != nil {
-		return fmt.Errorf("could not extract metadata: %s", err)
+		return fmt.Errorf("could not extract metadata: %w", err)
 	}
 
 	var template proofing.ProductTemplate
 	err = yaml.Unmarshal(extractedMetadata.Raw, &template)
 	if err != nil {
-		return fmt.Errorf("could not parse metadata: %s", err)
+		return fmt.Errorf("could not parse metadata: %w", err)
 	}
 
 	propertyPairs := makePropertyBluePrintPair(&template)
@@ -74,7 +74,7 @@ func (ct ConfigTemplate) Execute(args []string) error {
 
 	output, err := yaml.Marshal(configTemplate)
 	if err != nil {
-		return fmt.Errorf("could not marshal config template: %s", err)
+		return fmt.Errorf("could not marshal config template: %w", err)
 	}
 
 	// post-processing
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 )
@@ -15,9 +16,11 @@ type configureAuthenticationService interface {
 }
 
 type ConfigureAuthentication struct {
-	service configureAuthenticationService
-	logger  logger
-	Options struct {
+	service   configureAuthenticationService
+	logger    logger
+	results   resultWriter
+	varsStore boshtpl.Variables
+	Options   struct {
 		ConfigFile           string `long:"config"                short:"c"                    description:"path to yml file for configuration (keys must match the following command line flags)"`
 		Username             string `long:"username"              short:"u"  env:"OM_USERNAME" description:"admin username" required:"true"`
 		Password             string `long:"password"              short:"p"  env:"OM_PASSWORD" description:"admin password" required:"true"`
@@ -28,22 +31,24 @@ type ConfigureAuthentication struct {
 	}
 }
 
-func NewConfigureAuthentication(service configureAuthenticationService, logger logger) ConfigureAuthentication {
+func NewConfigureAuthentication(service configureAuthenticationService, logger logger, results resultWriter, varsStore boshtpl.Variables) ConfigureAuthentication {
 	return ConfigureAuthentication{
-		service: service,
-		logger:  logger,
+		service:   service,
+		logger:    logger,
+		results:   results,
+		varsStore: varsStore,
 	}
 }
 
 func (ca ConfigureAuthentication) Execute(args []string) error {
-	err := loadConfigFile(args, &ca.Options, nil)
+	err := loadConfigFile(args, &ca.Options, nil, ca.varsStore)
 	if err != nil {
-		return fmt.Errorf("could not parse configure-authentication flags: %s", err)
+		return configErrorf("could not parse configure-authentication flags: %w", err)
 	}
 
 	ensureAvailabilityOutput, err := ca.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 	if err != nil {
-		return fmt.Errorf("could not determine initial configuration status: %s", err)
+		return fmt.Errorf("could not determine initial configuration status: %w", err)
 	}
 
 	if ensureAvailabilityOutput.Status == api.EnsureAvailabilityStatusUnknown {
@@ -52,7 +57,10 @@ func (ca ConfigureAuthentication) Execute(args []string) error {
 
 	if ensureAvailabilityOutput.Status != api.EnsureAvailabilityStatusUnstarted {
 		ca.logger.Printf("configuration previously completed, skipping configuration")
-		return nil
+		return ca.results.WriteResult(ConfigureAuthenticationResult{
+			IdentityProvider:  "internal",
+			AlreadyConfigured: true,
+		})
 	}
 
 	ca.logger.Printf("configuring internal userstore...")
@@ -70,20 +78,22 @@ func (ca ConfigureAuthentication) Execute(args []string) error {
 		EULAAccepted:                     "true",
 	})
 	if err != nil {
-		return fmt.Errorf("could not configure authentication: %s", err)
+		return fmt.Errorf("could not configure authentication: %w", err)
 	}
 
 	ca.logger.Printf("waiting for configuration to complete...")
 	for ensureAvailabilityOutput.Status != api.EnsureAvailabilityStatusComplete {
 		ensureAvailabilityOutput, err = ca.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 		if err != nil {
-			return fmt.Errorf("could not determine final configuration status: %s", err)
+			return fmt.Errorf("could not determine final configuration status: %w", err)
 		}
 	}
 
 	ca.logger.Printf("configuration complete")
 
-	return nil
+	return ca.results.WriteResult(ConfigureAuthenticationResult{
+		IdentityProvider: "internal",
+	})
 }
 
 func (ca ConfigureAuthentication) Usage() jhanda.Usage {
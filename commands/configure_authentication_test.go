@@ -18,12 +18,14 @@ import (
 var _ = Describe("ConfigureAuthentication", func() {
 	var (
 		logger  *fakes.Logger
+		results *fakes.ResultWriter
 		service *fakes.ConfigureAuthenticationService
 	)
 
 	BeforeEach(func() {
 		service = &fakes.ConfigureAuthenticationService{}
 		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
 	})
 
 	Describe("Execute", func() {
@@ -39,7 +41,7 @@ var _ = Describe("ConfigureAuthentication", func() {
 				return eaOutputs[service.EnsureAvailabilityCallCount()-1], nil
 			}
 
-			command := commands.NewConfigureAuthentication(service, logger)
+			command := commands.NewConfigureAuthentication(service, logger, results, nil)
 			err := command.Execute([]string{
 				"--username", "some-username",
 				"--password", "some-password",
@@ -67,6 +69,11 @@ var _ = Describe("ConfigureAuthentication", func() {
 
 			format, content = logger.PrintfArgsForCall(2)
 			Expect(fmt.Sprintf(format, content...)).To(Equal("configuration complete"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ConfigureAuthenticationResult{
+				IdentityProvider: "internal",
+			}))
 		})
 
 		Context("when the authentication setup has already been configured", func() {
@@ -75,7 +82,7 @@ var _ = Describe("ConfigureAuthentication", func() {
 					Status: api.EnsureAvailabilityStatusComplete,
 				}, nil)
 
-				command := commands.NewConfigureAuthentication(service, logger)
+				command := commands.NewConfigureAuthentication(service, logger, results, nil)
 				err := command.Execute([]string{
 					"--username", "some-username",
 					"--password", "some-password",
@@ -88,6 +95,11 @@ var _ = Describe("ConfigureAuthentication", func() {
 
 				format, content := logger.PrintfArgsForCall(0)
 				Expect(fmt.Sprintf(format, content...)).To(Equal("configuration previously completed, skipping configuration"))
+
+				Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ConfigureAuthenticationResult{
+					IdentityProvider:  "internal",
+					AlreadyConfigured: true,
+				}))
 			})
 		})
 
@@ -120,7 +132,7 @@ decryption-passphrase: some-passphrase
 					return eaOutputs[service.EnsureAvailabilityCallCount()-1], nil
 				}
 
-				command := commands.NewConfigureAuthentication(service, logger)
+				command := commands.NewConfigureAuthentication(service, logger, results, nil)
 				err := command.Execute([]string{
 					"--config", configFile.Name(),
 				})
@@ -160,7 +172,7 @@ decryption-passphrase: some-passphrase
 					return eaOutputs[service.EnsureAvailabilityCallCount()-1], nil
 				}
 
-				command := commands.NewConfigureAuthentication(service, logger)
+				command := commands.NewConfigureAuthentication(service, logger, results, nil)
 				err := command.Execute([]string{
 					"--config", configFile.Name(),
 					"--password", "some-password-1",
@@ -193,7 +205,7 @@ decryption-passphrase: some-passphrase
 		Context("failure cases", func() {
 			Context("when an unknown flag is provided", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureAuthentication(service, logger)
+					command := commands.NewConfigureAuthentication(service, logger, results, nil)
 					err := command.Execute([]string{"--banana"})
 					Expect(err).To(MatchError("could not parse configure-authentication flags: flag provided but not defined: -banana"))
 				})
@@ -201,7 +213,7 @@ decryption-passphrase: some-passphrase
 
 			Context("when config file cannot be opened", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureAuthentication(service, logger)
+					command := commands.NewConfigureAuthentication(service, logger, results, nil)
 					err := command.Execute([]string{"--config", "something"})
 					Expect(err).To(MatchError("could not parse configure-authentication flags: could not load the config file: open something: no such file or directory"))
 
@@ -212,7 +224,7 @@ decryption-passphrase: some-passphrase
 				It("returns an error", func() {
 					service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{}, errors.New("failed to fetch status"))
 
-					command := commands.NewConfigureAuthentication(service, logger)
+					command := commands.NewConfigureAuthentication(service, logger, results, nil)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--password", "some-password",
@@ -228,7 +240,7 @@ decryption-passphrase: some-passphrase
 						Status: api.EnsureAvailabilityStatusUnknown,
 					}, nil)
 
-					command := commands.NewConfigureAuthentication(service, logger)
+					command := commands.NewConfigureAuthentication(service, logger, results, nil)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--password", "some-password",
@@ -246,7 +258,7 @@ decryption-passphrase: some-passphrase
 
 					service.SetupReturns(api.SetupOutput{}, errors.New("could not setup"))
 
-					command := commands.NewConfigureAuthentication(service, logger)
+					command := commands.NewConfigureAuthentication(service, logger, results, nil)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--password", "some-password",
@@ -271,7 +283,7 @@ decryption-passphrase: some-passphrase
 						return eaOutputs[service.EnsureAvailabilityCallCount()-1], eaErrors[service.EnsureAvailabilityCallCount()-1]
 					}
 
-					command := commands.NewConfigureAuthentication(service, logger)
+					command := commands.NewConfigureAuthentication(service, logger, results, nil)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--password", "some-password",
@@ -283,7 +295,7 @@ decryption-passphrase: some-passphrase
 
 			Context("when the --username flag is missing", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureAuthentication(nil, nil)
+					command := commands.NewConfigureAuthentication(nil, nil, nil, nil)
 					err := command.Execute([]string{
 						"--password", "some-password",
 						"--decryption-passphrase", "some-passphrase",
@@ -294,7 +306,7 @@ decryption-passphrase: some-passphrase
 
 			Context("when the --password flag is missing", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureAuthentication(nil, nil)
+					command := commands.NewConfigureAuthentication(nil, nil, nil, nil)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--decryption-passphrase", "some-passphrase",
@@ -305,7 +317,7 @@ decryption-passphrase: some-passphrase
 
 			Context("when the --decryption-passphrase flag is missing", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureAuthentication(nil, nil)
+					command := commands.NewConfigureAuthentication(nil, nil, nil, nil)
 					err := command.Execute([]string{
 						"--username", "some-username",
 						"--password", "some-password",
@@ -318,7 +330,7 @@ decryption-passphrase: some-passphrase
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewConfigureAuthentication(nil, nil)
+			command := commands.NewConfigureAuthentication(nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This unauthenticated command helps setup the internal userstore authentication mechanism for your Ops Manager.",
 				ShortDescription: "configures Ops Manager with an internal userstore and admin user account",
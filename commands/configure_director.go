@@ -3,6 +3,7 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"gopkg.in/yaml.v2"
@@ -11,14 +12,18 @@ import (
 )
 
 type ConfigureDirector struct {
-	environFunc func() []string
-	service     configureDirectorService
-	logger      logger
-	Options     struct {
+	environFunc   func() []string
+	varsStore     boshtpl.Variables
+	service       configureDirectorService
+	dryRunService configureDirectorService
+	logger        logger
+	results       resultWriter
+	Options       struct {
 		ConfigFile string   `short:"c" long:"config" description:"path to yml file containing all config fields (see docs/configure-director/README.md for format)" required:"true"`
 		VarsFile   []string `long:"vars-file"  description:"Load variables from a YAML file"`
 		VarsEnv    []string `long:"vars-env"   description:"Load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
 		OpsFile    []string `long:"ops-file"  description:"YAML operations file"`
+		DryRun     bool     `long:"dry-run"   description:"print the API requests that would be made to configure the director without making any changes"`
 	}
 }
 
@@ -51,11 +56,14 @@ type configureDirectorService interface {
 	DeleteVMExtension(name string) error
 }
 
-func NewConfigureDirector(environFunc func() []string, service configureDirectorService, logger logger) ConfigureDirector {
+func NewConfigureDirector(environFunc func() []string, varsStore boshtpl.Variables, service configureDirectorService, dryRunService configureDirectorService, logger logger, results resultWriter) ConfigureDirector {
 	return ConfigureDirector{
-		environFunc: environFunc,
-		service:     service,
-		logger:      logger,
+		environFunc:   environFunc,
+		varsStore:     varsStore,
+		service:       service,
+		dryRunService: dryRunService,
+		logger:        logger,
+		results:       results,
 	}
 }
 
@@ -69,17 +77,22 @@ func (c ConfigureDirector) Usage() jhanda.Usage {
 
 func (c ConfigureDirector) Execute(args []string) error {
 	if _, err := jhanda.Parse(&c.Options, args); err != nil {
-		return fmt.Errorf("could not parse configure-director flags: %s", err)
+		return configErrorf("could not parse configure-director flags: %w", err)
+	}
+
+	if c.Options.DryRun {
+		c.service = c.dryRunService
+		c.logger.Printf("dry run: no changes will be made, printing the API requests that would be sent")
 	}
 
 	config, err := c.interpolateConfig()
 	if err != nil {
-		return err
+		return interpolationError(err)
 	}
 
 	err = c.validateConfig(config)
 	if err != nil {
-		return err
+		return ConfigError{Err: err}
 	}
 
 	err = c.updateStagedDirectorProperties(config)
@@ -112,7 +125,38 @@ func (c ConfigureDirector) Execute(args []string) error {
 		return err
 	}
 
-	return nil
+	return c.results.WriteResult(ConfigureDirectorResult{
+		ConfiguredSections: config.configuredSections(),
+		DryRun:             c.Options.DryRun,
+	})
+}
+
+// configuredSections returns the keys of the config file that were
+// configured, in the order in which they are applied.
+func (config *directorConfig) configuredSections() []string {
+	sections := []struct {
+		key        string
+		configured bool
+	}{
+		{"director-configuration", config.DirectorConfigration != nil},
+		{"iaas-configuration", config.IaasConfiguration != nil},
+		{"security-configuration", config.SecurityConfiguration != nil},
+		{"syslog-configuration", config.SyslogConfiguration != nil},
+		{"az-configuration", config.AZConfiguration != nil},
+		{"networks-configuration", config.NetworksConfiguration != nil},
+		{"network-assignment", config.NetworkAssignment != nil},
+		{"resource-configuration", config.ResourceConfiguration != nil},
+		{"vmextensions-configuration", config.VMExtensions != nil},
+	}
+
+	configured := []string{}
+	for _, section := range sections {
+		if section.configured {
+			configured = append(configured, section.key)
+		}
+	}
+
+	return configured
 }
 
 func (c ConfigureDirector) interpolateConfig() (*directorConfig, error) {
@@ -120,6 +164,7 @@ func (c ConfigureDirector) interpolateConfig() (*directorConfig, error) {
 		templateFile: c.Options.ConfigFile,
 		varsFiles:    c.Options.VarsFile,
 		environFunc:  c.environFunc,
+		varsStore:    c.varsStore,
 		varsEnvs:     c.Options.VarsEnv,
 		opsFiles:     c.Options.OpsFile,
 	}, "")
@@ -130,7 +175,7 @@ func (c ConfigureDirector) interpolateConfig() (*directorConfig, error) {
 	var config directorConfig
 	err = yaml.UnmarshalStrict(configContents, &config)
 	if err != nil {
-		return nil, fmt.Errorf("could not be parsed as valid configuration: %s: %s", c.Options.ConfigFile, err)
+		return nil, fmt.Errorf("could not be parsed as valid configuration: %s: %w", c.Options.ConfigFile, err)
 	}
 	return &config, nil
 }
@@ -187,7 +232,7 @@ func (c ConfigureDirector) updateStagedDirectorProperties(config *directorConfig
 		})
 
 		if err != nil {
-			return fmt.Errorf("properties could not be applied: %s", err)
+			return fmt.Errorf("properties could not be applied: %w", err)
 		}
 
 		c.logger.Printf("finished configuring director options for bosh tile")
@@ -208,7 +253,7 @@ func (c ConfigureDirector) configureAvailabilityZones(config *directorConfig) er
 			AvailabilityZones: json.RawMessage(azs),
 		})
 		if err != nil {
-			return fmt.Errorf("availability zones configuration could not be applied: %s", err)
+			return fmt.Errorf("availability zones configuration could not be applied: %w", err)
 		}
 
 		c.logger.Printf("finished configuring availability zone options for bosh tile")
@@ -229,7 +274,7 @@ func (c ConfigureDirector) configureNetworksConfiguration(config *directorConfig
 			Networks: json.RawMessage(networksConfiguration),
 		})
 		if err != nil {
-			return fmt.Errorf("networks configuration could not be applied: %s", err)
+			return fmt.Errorf("networks configuration could not be applied: %w", err)
 		}
 
 		c.logger.Printf("finished configuring network options for bosh tile")
@@ -249,7 +294,7 @@ func (c ConfigureDirector) configureNetworkAssignment(config *directorConfig) er
 			NetworkAZ: json.RawMessage(networkAssignment),
 		})
 		if err != nil {
-			return fmt.Errorf("network and AZs could not be applied: %s", err)
+			return fmt.Errorf("network and AZs could not be applied: %w", err)
 		}
 
 		c.logger.Printf("finished configuring network assignment options for bosh tile")
@@ -271,7 +316,7 @@ func (c ConfigureDirector) configureResourceConfigurations(config *directorConfi
 
 		jobs, err := c.service.ListStagedProductJobs(productGUID)
 		if err != nil {
-			return fmt.Errorf("failed to fetch jobs: %s", err)
+			return fmt.Errorf("failed to fetch jobs: %w", err)
 		}
 
 		c.logger.Printf("applying resource configuration for the following jobs:")
@@ -297,7 +342,7 @@ func (c ConfigureDirector) addNewExtensions(extensionsToDelete map[string]api.VM
 
 	err = json.Unmarshal([]byte(newExtensionBytes), &newVMExtensions)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshall vmextensions-configuration json: %s. Full Error: %s", newExtensions, err)
+		return nil, fmt.Errorf("could not unmarshall vmextensions-configuration json: %s. Full Error: %w", newExtensions, err)
 	}
 
 	c.logger.Printf("applying vm-extensions configuration for the following:")
@@ -379,7 +424,7 @@ func (c ConfigureDirector) configureVMExtensions(config *directorConfig) error {
 func (c ConfigureDirector) getProductGUID() (string, error) {
 	findOutput, err := c.service.GetStagedProductByName("p-bosh")
 	if err != nil {
-		return "", fmt.Errorf("could not find staged product with name 'p-bosh': %s", err)
+		return "", fmt.Errorf("could not find staged product with name 'p-bosh': %w", err)
 	}
 	return findOutput.Product.GUID, nil
 }
@@ -405,22 +450,22 @@ func (c ConfigureDirector) configureResourceConfiguration(config *directorConfig
 
 	jobProperties, err := c.service.GetStagedProductJobResourceConfig(productGUID, jobGUID)
 	if err != nil {
-		return fmt.Errorf("could not fetch existing job configuration for '%s': %s", jobName, err)
+		return fmt.Errorf("could not fetch existing job configuration for '%s': %w", jobName, err)
 	}
 
 	prop, err := getJSONProperties(config.ResourceConfiguration[jobName])
 	if err != nil {
-		return fmt.Errorf("could not unmarshall resource configuration: %v", err)
+		return fmt.Errorf("could not unmarshall resource configuration: %w", err)
 	}
 
 	err = json.Unmarshal([]byte(prop), &jobProperties)
 	if err != nil {
-		return fmt.Errorf("could not decode resource-configuration json for job '%s': %s", jobName, err)
+		return fmt.Errorf("could not decode resource-configuration json for job '%s': %w", jobName, err)
 	}
 
 	err = c.service.UpdateStagedProductJobResourceConfig(productGUID, jobGUID, jobProperties)
 	if err != nil {
-		return fmt.Errorf("failed to configure resources for '%s': %s", jobName, err)
+		return fmt.Errorf("failed to configure resources for '%s': %w", jobName, err)
 	}
 
 	return nil
@@ -17,6 +17,7 @@ import (
 var _ = Describe("ConfigureDirector", func() {
 	var (
 		logger     *fakes.Logger
+		results    *fakes.ResultWriter
 		service    *fakes.ConfigureDirectorService
 		command    commands.ConfigureDirector
 		err        error
@@ -27,6 +28,7 @@ var _ = Describe("ConfigureDirector", func() {
 	BeforeEach(func() {
 		service = &fakes.ConfigureDirectorService{}
 		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
 		service.GetStagedProductByNameReturns(api.StagedProductsFindOutput{
 			Product: api.StagedProduct{
 				GUID: "p-bosh-guid",
@@ -48,8 +50,11 @@ var _ = Describe("ConfigureDirector", func() {
 
 		command = commands.NewConfigureDirector(
 			func() []string { return []string{} },
+			nil,
 			service,
-			logger)
+			nil,
+			logger,
+			results)
 	})
 
 	JustBeforeEach(func() {
@@ -199,6 +204,62 @@ vmextensions-configuration:
 			Expect(err).NotTo(HaveOccurred())
 
 			ExpectDirectorToBeConfiguredCorrectly()
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ConfigureDirectorResult{
+				ConfiguredSections: []string{
+					"director-configuration",
+					"iaas-configuration",
+					"security-configuration",
+					"syslog-configuration",
+					"az-configuration",
+					"networks-configuration",
+					"network-assignment",
+					"resource-configuration",
+					"vmextensions-configuration",
+				},
+			}))
+		})
+
+		Context("when the --dry-run flag is set", func() {
+			It("configures the director through the dry run service", func() {
+				dryRunService := &fakes.ConfigureDirectorService{}
+				dryRunService.GetStagedProductByNameReturns(api.StagedProductsFindOutput{
+					Product: api.StagedProduct{
+						GUID: "p-bosh-guid",
+					},
+				}, nil)
+				dryRunService.ListStagedProductJobsReturns(map[string]string{
+					"resource": "some-resource-guid",
+				}, nil)
+
+				command = commands.NewConfigureDirector(
+					func() []string { return []string{} },
+					nil,
+					service,
+					dryRunService,
+					logger,
+					results)
+
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--dry-run",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.Invocations()).To(BeEmpty())
+				Expect(dryRunService.UpdateStagedDirectorPropertiesCallCount()).To(Equal(1))
+				Expect(dryRunService.UpdateStagedDirectorAvailabilityZonesCallCount()).To(Equal(1))
+				Expect(dryRunService.UpdateStagedDirectorNetworksCallCount()).To(Equal(1))
+				Expect(dryRunService.UpdateStagedDirectorNetworkAndAZCallCount()).To(Equal(1))
+				Expect(dryRunService.UpdateStagedProductJobResourceConfigCallCount()).To(Equal(1))
+				Expect(dryRunService.CreateStagedVMExtensionCallCount()).To(Equal(2))
+
+				Expect(logger.PrintfArgsForCall(0)).To(Equal("dry run: no changes will be made, printing the API requests that would be sent"))
+
+				Expect(results.WriteResultCallCount()).To(Equal(1))
+				Expect(results.WriteResultArgsForCall(0).(commands.ConfigureDirectorResult).DryRun).To(BeTrue())
+			})
 		})
 
 		Context("when the --config flag is set", func() {
@@ -337,6 +398,31 @@ vmextensions-configuration:
 							})
 							Expect(err).To(HaveOccurred())
 							Expect(err.Error()).To(ContainSubstring("Expected to find variables"))
+							Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+						})
+					})
+
+					Context("when the vars store cannot be read", func() {
+						It("keeps the exit code of the vars store", func() {
+							command = commands.NewConfigureDirector(
+								func() []string { return []string{} },
+								failingVariables{err: netError{errors.New("connection reset")}},
+								service,
+								nil,
+								logger,
+								results)
+
+							configFile, err := ioutil.TempFile("", "config.yaml")
+							Expect(err).ToNot(HaveOccurred())
+							_, err = configFile.Write(templateConfigurationJSON)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(configFile.Close()).ToNot(HaveOccurred())
+
+							err = command.Execute([]string{
+								"--config", configFile.Name(),
+							})
+							Expect(err).To(MatchError(ContainSubstring("connection reset")))
+							Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeUnavailable))
 						})
 					})
 
@@ -369,8 +455,11 @@ vmextensions-configuration:
 
 							command = commands.NewConfigureDirector(
 								func() []string { return []string{"OM_VAR_network_name=network"} },
+								nil,
 								service,
-								logger)
+								nil,
+								logger,
+								results)
 
 							configFile, err := ioutil.TempFile("", "config.yaml")
 							Expect(err).ToNot(HaveOccurred())
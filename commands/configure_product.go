@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/config"
@@ -15,14 +16,19 @@ import (
 )
 
 type ConfigureProduct struct {
-	environFunc func() []string
-	service     configureProductService
-	logger      logger
-	Options     struct {
-		ConfigFile string   `long:"config"    short:"c" description:"path to yml file containing all config fields (see docs/configure-product/README.md for format)" required:"true"`
-		VarsFile   []string `long:"vars-file" short:"l" description:"Load variables from a YAML file"`
-		VarsEnv    []string `long:"vars-env"            description:"Load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
-		OpsFile    []string `long:"ops-file"  short:"o" description:"YAML operations file"`
+	environFunc   func() []string
+	varsStore     boshtpl.Variables
+	service       configureProductService
+	dryRunService configureProductService
+	logger        logger
+	results       resultWriter
+	Options       struct {
+		ConfigFile  []string `long:"config"       short:"c" description:"path to yml file containing all config fields (see docs/configure-product/README.md for format); when given more than once, the files are merged in order" required:"true"`
+		VarsFile    []string `long:"vars-file"    short:"l" description:"Load variables from a YAML file"`
+		VarsEnv     []string `long:"vars-env"               description:"Load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
+		OpsFile     []string `long:"ops-file"     short:"o" description:"YAML operations file"`
+		DryRun      bool     `long:"dry-run"                description:"print the API requests that would be made to configure the product without making any changes"`
+		PrintMerged bool     `long:"print-merged"           description:"print the merged config, with ops-files applied but variables left as placeholders, after validating it, without configuring the product"`
 	}
 }
 
@@ -37,29 +43,41 @@ type configureProductService interface {
 	UpdateStagedProductErrands(productID, errandName string, postDeployState, preDeleteState interface{}) error
 }
 
-func NewConfigureProduct(environFunc func() []string, service configureProductService, logger logger) ConfigureProduct {
+func NewConfigureProduct(environFunc func() []string, varsStore boshtpl.Variables, service configureProductService, dryRunService configureProductService, logger logger, results resultWriter) ConfigureProduct {
 	return ConfigureProduct{
-		environFunc: environFunc,
-		service:     service,
-		logger:      logger,
+		environFunc:   environFunc,
+		varsStore:     varsStore,
+		service:       service,
+		dryRunService: dryRunService,
+		logger:        logger,
+		results:       results,
 	}
 }
 
 func (cp ConfigureProduct) Execute(args []string) error {
 	if _, err := jhanda.Parse(&cp.Options, args); err != nil {
-		return fmt.Errorf("could not parse configure-product flags: %s", err)
+		return configErrorf("could not parse configure-product flags: %w", err)
+	}
+
+	if cp.Options.PrintMerged {
+		return cp.printMergedConfig()
+	}
+
+	if cp.Options.DryRun {
+		cp.service = cp.dryRunService
+		cp.logger.Printf("dry run: no changes will be made, printing the API requests that would be sent")
 	}
 
 	cp.logger.Printf("configuring product...")
 
 	cfg, err := cp.interpolateConfig()
 	if err != nil {
-		return err
+		return interpolationError(err)
 	}
 
 	err = cp.validateConfig(cfg)
 	if err != nil {
-		return err
+		return ConfigError{Err: err}
 	}
 
 	productGUID, err := cp.getProductGUID(cfg)
@@ -89,7 +107,11 @@ func (cp ConfigureProduct) Execute(args []string) error {
 
 	cp.logger.Printf("finished configuring product")
 
-	return nil
+	return cp.results.WriteResult(ConfigureProductResult{
+		ProductName: cfg.ProductName,
+		GUID:        productGUID,
+		DryRun:      cp.Options.DryRun,
+	})
 }
 
 func (cp ConfigureProduct) Usage() jhanda.Usage {
@@ -128,12 +150,12 @@ func (cp *ConfigureProduct) configureResources(cfg config.ProductConfiguration,
 	var userProvidedConfig map[string]json.RawMessage
 	err = json.Unmarshal([]byte(productResources), &userProvidedConfig)
 	if err != nil {
-		return fmt.Errorf("could not decode product-resource json: %s", err)
+		return fmt.Errorf("could not decode product-resource json: %w", err)
 	}
 
 	jobs, err := cp.service.ListStagedProductJobs(productGUID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch jobs: %s", err)
+		return fmt.Errorf("failed to fetch jobs: %w", err)
 	}
 
 	var names []string
@@ -148,7 +170,7 @@ func (cp *ConfigureProduct) configureResources(cfg config.ProductConfiguration,
 		cp.logger.Printf("\t%s", name)
 		jobProperties, err := cp.service.GetStagedProductJobResourceConfig(productGUID, jobs[name])
 		if err != nil {
-			return fmt.Errorf("could not fetch existing job configuration: %s", err)
+			return fmt.Errorf("could not fetch existing job configuration: %w", err)
 		}
 
 		err = json.Unmarshal(userProvidedConfig[name], &jobProperties)
@@ -158,7 +180,7 @@ func (cp *ConfigureProduct) configureResources(cfg config.ProductConfiguration,
 
 		err = cp.service.UpdateStagedProductJobResourceConfig(productGUID, jobs[name], jobProperties)
 		if err != nil {
-			return fmt.Errorf("failed to configure resources: %s", err)
+			return fmt.Errorf("failed to configure resources: %w", err)
 		}
 	}
 	return nil
@@ -181,7 +203,7 @@ func (cp *ConfigureProduct) configureProperties(cfg config.ProductConfiguration,
 		Properties: productProperties,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to configure product: %s", err)
+		return fmt.Errorf("failed to configure product: %w", err)
 	}
 	cp.logger.Printf("finished setting properties")
 
@@ -206,7 +228,7 @@ func (cp *ConfigureProduct) configureNetwork(cfg config.ProductConfiguration, pr
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to configure product: %s", err)
+		return fmt.Errorf("failed to configure product: %w", err)
 	}
 	cp.logger.Printf("finished setting up network")
 
@@ -233,7 +255,7 @@ func (cp *ConfigureProduct) configureErrands(cfg config.ProductConfiguration, pr
 		errandConfig := cfg.ErrandConfigs[name]
 		err := cp.service.UpdateStagedProductErrands(productGUID, name, errandConfig.PostDeployState, errandConfig.PreDeleteState)
 		if err != nil {
-			return fmt.Errorf("failed to set errand state for errand %s: %s", name, err)
+			return fmt.Errorf("failed to set errand state for errand %s: %w", name, err)
 		}
 	}
 
@@ -241,27 +263,79 @@ func (cp *ConfigureProduct) configureErrands(cfg config.ProductConfiguration, pr
 }
 
 func (cp *ConfigureProduct) interpolateConfig() (config.ProductConfiguration, error) {
+	contents, err := mergeProductConfigFiles(cp.Options.ConfigFile)
+	if err != nil {
+		return config.ProductConfiguration{}, err
+	}
+
+	return interpolateProductConfig(cp.interpolateOptions(contents))
+}
+
+func (cp *ConfigureProduct) interpolateOptions(contents []byte) interpolateOptions {
+	return interpolateOptions{
+		templateFile:     strings.Join(cp.Options.ConfigFile, ", "),
+		templateContents: contents,
+		varsFiles:        cp.Options.VarsFile,
+		environFunc:      cp.environFunc,
+		varsStore:        cp.varsStore,
+		varsEnvs:         cp.Options.VarsEnv,
+		opsFiles:         cp.Options.OpsFile,
+	}
+}
+
+// printMergedConfig validates the config like a real configure, and then
+// prints it with the ops-files applied but the ((variables)) left in place,
+// so that no secret is printed.
+func (cp *ConfigureProduct) printMergedConfig() error {
+	contents, err := mergeProductConfigFiles(cp.Options.ConfigFile)
+	if err != nil {
+		return ConfigError{Err: err}
+	}
+
+	options := cp.interpolateOptions(contents)
+
+	cfg, err := interpolateProductConfig(options)
+	if err != nil {
+		return interpolationError(err)
+	}
+
+	err = cp.validateConfig(cfg)
+	if err != nil {
+		return ConfigError{Err: err}
+	}
+
+	options.keepPlaceholders = true
+
+	output, err := interpolate(options, "")
+	if err != nil {
+		return interpolationError(err)
+	}
+
+	cp.logger.Println(string(output))
+
+	return nil
+}
+
+func (cp ConfigureProduct) validateConfig(cfg config.ProductConfiguration) error {
+	return validateProductConfig(cfg)
+}
+
+func interpolateProductConfig(o interpolateOptions) (config.ProductConfiguration, error) {
 	var cfg config.ProductConfiguration
-	configContents, err := interpolate(interpolateOptions{
-		templateFile: cp.Options.ConfigFile,
-		varsFiles:    cp.Options.VarsFile,
-		environFunc:  cp.environFunc,
-		varsEnvs:     cp.Options.VarsEnv,
-		opsFiles:     cp.Options.OpsFile,
-	}, "")
+	configContents, err := interpolate(o, "")
 	if err != nil {
 		return config.ProductConfiguration{}, err
 	}
 
 	err = yaml.UnmarshalStrict(configContents, &cfg)
 	if err != nil {
-		return config.ProductConfiguration{}, fmt.Errorf("%s could not be parsed as valid configuration: %s", cp.Options.ConfigFile, err)
+		return config.ProductConfiguration{}, fmt.Errorf("%s could not be parsed as valid configuration: %w", o.templateFile, err)
 	}
 
 	return cfg, nil
 }
 
-func (cp ConfigureProduct) validateConfig(cfg config.ProductConfiguration) error {
+func validateProductConfig(cfg config.ProductConfiguration) error {
 	if cfg.ProductName == "" {
 		return fmt.Errorf("could not parse configure-product config: \"product-name\" is required")
 	}
@@ -293,7 +367,7 @@ func (cp ConfigureProduct) getProductGUID(cfg config.ProductConfiguration) (stri
 	}
 
 	if productGUID == "" {
-		return "", fmt.Errorf(`could not find product "%s"`, cfg.ProductName)
+		return "", NotFoundError{Err: fmt.Errorf(`could not find product "%s"`, cfg.ProductName)}
 	}
 
 	return productGUID, nil
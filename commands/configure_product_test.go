@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
@@ -16,11 +17,24 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+type failingVariables struct {
+	err error
+}
+
+func (v failingVariables) Get(boshtpl.VariableDefinition) (interface{}, bool, error) {
+	return nil, false, v.err
+}
+
+func (v failingVariables) List() ([]boshtpl.VariableDefinition, error) {
+	return nil, nil
+}
+
 var _ = Describe("ConfigureProduct", func() {
 	Describe("Execute", func() {
 		var (
 			service    *fakes.ConfigureProductService
 			logger     *fakes.Logger
+			results    *fakes.ResultWriter
 			config     string
 			configFile *os.File
 			err        error
@@ -29,6 +43,7 @@ var _ = Describe("ConfigureProduct", func() {
 		BeforeEach(func() {
 			service = &fakes.ConfigureProductService{}
 			logger = &fakes.Logger{}
+			results = &fakes.ResultWriter{}
 		})
 
 		JustBeforeEach(func() {
@@ -46,7 +61,7 @@ var _ = Describe("ConfigureProduct", func() {
 			})
 
 			It("configures a product's properties", func() {
-				client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+				client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 
 				service.ListStagedProductsReturns(api.StagedProductsOutput{
 					Products: []api.StagedProduct{
@@ -76,6 +91,12 @@ var _ = Describe("ConfigureProduct", func() {
 
 				format, content = logger.PrintfArgsForCall(3)
 				Expect(fmt.Sprintf(format, content...)).To(Equal("finished configuring product"))
+
+				Expect(results.WriteResultCallCount()).To(Equal(1))
+				Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ConfigureProductResult{
+					ProductName: "cf",
+					GUID:        "some-product-guid",
+				}))
 			})
 		})
 
@@ -85,7 +106,7 @@ var _ = Describe("ConfigureProduct", func() {
 			})
 
 			It("configures a product's network", func() {
-				client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+				client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 
 				service.ListStagedProductsReturns(api.StagedProductsOutput{
 					Products: []api.StagedProduct{
@@ -118,13 +139,221 @@ var _ = Describe("ConfigureProduct", func() {
 			})
 		})
 
+		Context("when the --dry-run flag is provided", func() {
+			BeforeEach(func() {
+				config = fmt.Sprintf(`{"product-name": "cf", "product-properties": %s, "network-properties": %s}`, productProperties, networkProperties)
+			})
+
+			It("configures the product through the dry run service", func() {
+				dryRunService := &fakes.ConfigureProductService{}
+				dryRunService.ListStagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "some-product-guid", Type: "cf"},
+					},
+				}, nil)
+
+				client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, dryRunService, logger, results)
+
+				err := client.Execute([]string{
+					"--config", configFile.Name(),
+					"--dry-run",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.Invocations()).To(BeEmpty())
+
+				Expect(dryRunService.ListStagedProductsCallCount()).To(Equal(1))
+				actualProperties := dryRunService.UpdateStagedProductPropertiesArgsForCall(0)
+				Expect(actualProperties.GUID).To(Equal("some-product-guid"))
+				Expect(actualProperties.Properties).To(MatchJSON(productProperties))
+				actualNetworks := dryRunService.UpdateStagedProductNetworksAndAZsArgsForCall(0)
+				Expect(actualNetworks.GUID).To(Equal("some-product-guid"))
+				Expect(actualNetworks.NetworksAndAZs).To(MatchJSON(networkProperties))
+
+				format, content := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("dry run: no changes will be made, printing the API requests that would be sent"))
+			})
+		})
+
+		Context("when multiple config files are provided", func() {
+			var (
+				foundationFile  *os.File
+				environmentFile *os.File
+			)
+
+			writeFile := func(contents string) *os.File {
+				file, err := ioutil.TempFile("", "config.yml")
+				Expect(err).NotTo(HaveOccurred())
+				defer file.Close()
+
+				_, err = file.WriteString(contents)
+				Expect(err).NotTo(HaveOccurred())
+
+				return file
+			}
+
+			BeforeEach(func() {
+				config = `---
+product-name: cf
+product-properties:
+  .properties.some-string-property:
+    value: base-value
+  .properties.some-collection:
+    value:
+    - name: first
+    - name: second
+  .properties.some-removed-property:
+    value: removed
+resource-config:
+  some-job:
+    instances: 1
+    instance_type:
+      id: small
+errand-config:
+  some-errand:
+    post-deploy-state: true
+`
+				foundationFile = writeFile(`---
+product-properties:
+  .properties.some-collection:
+    value:
+    - name: third
+  .properties.some-removed-property: ~
+resource-config:
+  some-job:
+    instances: 3
+---
+errand-config:
+  some-errand:
+    pre-delete-state: false
+`)
+				environmentFile = writeFile(`---
+product-name: cf
+product-properties:
+  .properties.some-string-property:
+    value: ((environment))
+`)
+			})
+
+			AfterEach(func() {
+				os.Remove(foundationFile.Name())
+				os.Remove(environmentFile.Name())
+			})
+
+			It("merges them in order, without resolving the variables", func() {
+				client := commands.NewConfigureProduct(func() []string { return []string{"OM_VAR_environment=production"} }, nil, service, nil, logger, results)
+
+				err := client.Execute([]string{
+					"--config", configFile.Name(),
+					"--config", foundationFile.Name(),
+					"--config", environmentFile.Name(),
+					"--vars-env", "OM_VAR",
+					"--print-merged",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.Invocations()).To(BeEmpty())
+				Expect(logger.PrintlnCallCount()).To(Equal(1))
+				Expect(logger.PrintlnArgsForCall(0)[0]).To(MatchYAML(`---
+product-name: cf
+product-properties:
+  .properties.some-string-property:
+    value: ((environment))
+  .properties.some-collection:
+    value:
+    - name: third
+resource-config:
+  some-job:
+    instances: 3
+    instance_type:
+      id: small
+errand-config:
+  some-errand:
+    post-deploy-state: true
+    pre-delete-state: false
+`))
+			})
+
+			It("validates the merged config like a real configure when printing it", func() {
+				client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
+
+				err := client.Execute([]string{
+					"--config", configFile.Name(),
+					"--config", foundationFile.Name(),
+					"--config", environmentFile.Name(),
+					"--print-merged",
+				})
+				Expect(err).To(MatchError(ContainSubstring("Expected to find variables: environment")))
+				Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+
+				unknownKeyFile := writeFile(`some-unknown-key: some-value`)
+				defer os.Remove(unknownKeyFile.Name())
+
+				err = client.Execute([]string{
+					"--config", configFile.Name(),
+					"--config", unknownKeyFile.Name(),
+					"--print-merged",
+				})
+				Expect(err).To(MatchError(ContainSubstring("some-unknown-key")))
+				Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+
+				Expect(logger.PrintlnCallCount()).To(Equal(0))
+			})
+
+			It("configures the product with the merged config", func() {
+				client := commands.NewConfigureProduct(func() []string { return []string{"OM_VAR_environment=production"} }, nil, service, nil, logger, results)
+
+				service.ListStagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{
+						{GUID: "some-product-guid", Type: "cf"},
+					},
+				}, nil)
+				service.ListStagedProductJobsReturns(map[string]string{"some-job": "some-job-guid"}, nil)
+
+				err := client.Execute([]string{
+					"--config", configFile.Name(),
+					"--config", foundationFile.Name(),
+					"--config", environmentFile.Name(),
+					"--vars-env", "OM_VAR",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(service.UpdateStagedProductPropertiesArgsForCall(0).Properties).To(MatchJSON(`{
+					".properties.some-string-property": {"value": "production"},
+					".properties.some-collection": {"value": [{"name": "third"}]}
+				}`))
+
+				_, _, jobProperties := service.UpdateStagedProductJobResourceConfigArgsForCall(0)
+				Expect(jobProperties.Instances).To(BeNumerically("==", 3))
+
+				_, errandName, postDeployState, preDeleteState := service.UpdateStagedProductErrandsArgsForCall(0)
+				Expect(errandName).To(Equal("some-errand"))
+				Expect(postDeployState).To(Equal(true))
+				Expect(preDeleteState).To(Equal(false))
+			})
+
+			Context("when the config files are for different products", func() {
+				It("returns an error", func() {
+					otherProductFile := writeFile(`product-name: p-mysql`)
+					defer os.Remove(otherProductFile.Name())
+
+					client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
+					err := client.Execute([]string{
+						"--config", configFile.Name(),
+						"--config", otherProductFile.Name(),
+					})
+					Expect(err).To(MatchError("config files are for different products: cf and p-mysql"))
+				})
+			})
+		})
+
 		Context("when product resources are provided", func() {
 			BeforeEach(func() {
 				config = fmt.Sprintf(`{"product-name": "cf", "resource-config": %s}`, resourceConfig)
 			})
 
 			It("configures the resource that is provided", func() {
-				client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+				client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 				service.ListStagedProductsReturns(api.StagedProductsOutput{
 					Products: []api.StagedProduct{
 						{GUID: "some-product-guid", Type: "cf"},
@@ -285,7 +514,7 @@ var _ = Describe("ConfigureProduct", func() {
 			Context("when the config file contains variables", func() {
 				Context("passed in a vars-file", func() {
 					It("can interpolate variables into the configuration", func() {
-						client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+						client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 
 						configFile, err = ioutil.TempFile("", "")
 						Expect(err).NotTo(HaveOccurred())
@@ -311,8 +540,11 @@ var _ = Describe("ConfigureProduct", func() {
 					It("can interpolate variables into the configuration", func() {
 						client := commands.NewConfigureProduct(
 							func() []string { return []string{"OM_VAR_password=something-secure"} },
+							nil,
 							service,
-							logger)
+							nil,
+							logger,
+							results)
 
 						configFile, err = ioutil.TempFile("", "")
 						Expect(err).NotTo(HaveOccurred())
@@ -329,7 +561,7 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 
 				It("returns an error if missing variables", func() {
-					client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 
 					configFile, err = ioutil.TempFile("", "")
 					Expect(err).NotTo(HaveOccurred())
@@ -342,12 +574,30 @@ var _ = Describe("ConfigureProduct", func() {
 					})
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("Expected to find variables"))
+					Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+				})
+
+				It("keeps the exit code of the vars store when it cannot be read", func() {
+					varsStore := failingVariables{err: netError{errors.New("connection reset")}}
+					client := commands.NewConfigureProduct(func() []string { return nil }, varsStore, service, nil, logger, results)
+
+					configFile, err = ioutil.TempFile("", "")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = configFile.WriteString(productPropertiesWithVariables)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = client.Execute([]string{
+						"--config", configFile.Name(),
+					})
+					Expect(err).To(MatchError(ContainSubstring("connection reset")))
+					Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeUnavailable))
 				})
 			})
 
 			Context("when an ops-file is provided", func() {
 				It("can interpolate ops-files into the configuration", func() {
-					client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 
 					configFile, err = ioutil.TempFile("", "")
 					Expect(err).NotTo(HaveOccurred())
@@ -374,7 +624,7 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 
 				It("returns an error if the ops file is invalid", func() {
-					client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 
 					configFile, err = ioutil.TempFile("", "")
 					Expect(err).NotTo(HaveOccurred())
@@ -404,7 +654,7 @@ var _ = Describe("ConfigureProduct", func() {
 			})
 
 			It("configures the resource that is provided", func() {
-				client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+				client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 				service.ListStagedProductsReturns(api.StagedProductsOutput{
 					Products: []api.StagedProduct{
 						{GUID: "some-product-guid", Type: "cf"},
@@ -461,7 +711,7 @@ var _ = Describe("ConfigureProduct", func() {
 				config = fmt.Sprintf(`{"product-name": "cf", "resource-config": %s}`, resourceConfig)
 			})
 			It("returns an error", func() {
-				client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+				client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 				service.ListStagedProductsReturns(api.StagedProductsOutput{
 					Products: []api.StagedProduct{
 						{GUID: "some-product-guid", Type: "cf"},
@@ -495,7 +745,7 @@ var _ = Describe("ConfigureProduct", func() {
 			})
 
 			It("logs and then does nothing if network is empty", func() {
-				command := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+				command := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 
 				err := command.Execute([]string{
 					"--config", configFile.Name(),
@@ -524,7 +774,7 @@ var _ = Describe("ConfigureProduct", func() {
 
 			Context("when the product does not exist", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					command := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 
 					service.ListStagedProductsReturns(api.StagedProductsOutput{
 						Products: []api.StagedProduct{
@@ -545,7 +795,7 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					command := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 					service.ListStagedProductsReturns(api.StagedProductsOutput{
 						Products: []api.StagedProduct{
 							{GUID: "some-product-guid", Type: "cf"},
@@ -563,7 +813,7 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					command := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 					service.ListStagedProductsReturns(api.StagedProductsOutput{
 						Products: []api.StagedProduct{
 							{GUID: "some-product-guid", Type: "cf"},
@@ -586,7 +836,7 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					command := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 					service.ListStagedProductsReturns(api.StagedProductsOutput{
 						Products: []api.StagedProduct{
 							{GUID: "some-product-guid", Type: "cf"},
@@ -607,7 +857,7 @@ var _ = Describe("ConfigureProduct", func() {
 
 			Context("when an unknown flag is provided", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					command := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 					err := command.Execute([]string{"--badflag"})
 					Expect(err).To(MatchError("could not parse configure-product flags: flag provided but not defined: -badflag"))
 				})
@@ -619,7 +869,7 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					command := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 					err := command.Execute([]string{"--config", configFile.Name()})
 					Expect(err).To(MatchError("could not parse configure-product config: \"product-name\" is required"))
 				})
@@ -628,7 +878,7 @@ var _ = Describe("ConfigureProduct", func() {
 			Context("when the --config flag is passed", func() {
 				Context("when the provided config path does not exist", func() {
 					It("returns an error", func() {
-						command := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+						command := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 						service.ListStagedProductsReturns(api.StagedProductsOutput{
 							Products: []api.StagedProduct{
 								{GUID: "some-product-guid", Type: "cf"},
@@ -651,7 +901,7 @@ var _ = Describe("ConfigureProduct", func() {
 
 					It("returns an error", func() {
 						invalidConfig := "this is not a valid config"
-						client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+						client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 						service.ListStagedProductsReturns(api.StagedProductsOutput{
 							Products: []api.StagedProduct{
 								{GUID: "some-product-guid", Type: "cf"},
@@ -678,7 +928,7 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					command := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 					service.UpdateStagedProductPropertiesReturns(errors.New("some product error"))
 
 					service.ListStagedProductsReturns(api.StagedProductsOutput{
@@ -698,7 +948,7 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 
 				It("returns an error", func() {
-					command := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					command := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 					service.UpdateStagedProductNetworksAndAZsReturns(errors.New("some product error"))
 
 					service.ListStagedProductsReturns(api.StagedProductsOutput{
@@ -730,7 +980,7 @@ var _ = Describe("ConfigureProduct", func() {
 				})
 				It("errors when calling api", func() {
 					service.UpdateStagedProductErrandsReturns(errors.New("error configuring errand"))
-					client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 
 					configFile, err = ioutil.TempFile("", "")
 					Expect(err).NotTo(HaveOccurred())
@@ -754,7 +1004,7 @@ var _ = Describe("ConfigureProduct", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(configFile.Close()).ToNot(HaveOccurred())
 
-					client := commands.NewConfigureProduct(func() []string { return nil }, service, logger)
+					client := commands.NewConfigureProduct(func() []string { return nil }, nil, service, nil, logger, results)
 					err = client.Execute([]string{
 						"--config", configFile.Name(),
 					})
@@ -767,7 +1017,7 @@ var _ = Describe("ConfigureProduct", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewConfigureProduct(nil, nil, nil)
+			command := commands.NewConfigureProduct(nil, nil, nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This authenticated command configures a staged product",
 				ShortDescription: "configures a staged product",
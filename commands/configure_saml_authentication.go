@@ -4,14 +4,17 @@ import (
 	"errors"
 	"fmt"
 
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 )
 
 type ConfigureSAMLAuthentication struct {
-	service configureAuthenticationService
-	logger  logger
-	Options struct {
+	service   configureAuthenticationService
+	logger    logger
+	results   resultWriter
+	varsStore boshtpl.Variables
+	Options   struct {
 		ConfigFile           string `long:"config"                short:"c"  description:"path to yml file for configuration (keys must match the following command line flags)"`
 		DecryptionPassphrase string `long:"decryption-passphrase" short:"dp" required:"true" description:"passphrase used to encrypt the installation"`
 		HTTPProxyURL         string `long:"http-proxy-url"                                   description:"proxy for outbound HTTP network traffic"`
@@ -24,22 +27,24 @@ type ConfigureSAMLAuthentication struct {
 	}
 }
 
-func NewConfigureSAMLAuthentication(service configureAuthenticationService, logger logger) ConfigureSAMLAuthentication {
+func NewConfigureSAMLAuthentication(service configureAuthenticationService, logger logger, results resultWriter, varsStore boshtpl.Variables) ConfigureSAMLAuthentication {
 	return ConfigureSAMLAuthentication{
-		service: service,
-		logger:  logger,
+		service:   service,
+		logger:    logger,
+		results:   results,
+		varsStore: varsStore,
 	}
 }
 
 func (ca ConfigureSAMLAuthentication) Execute(args []string) error {
-	err := loadConfigFile(args, &ca.Options, nil)
+	err := loadConfigFile(args, &ca.Options, nil, ca.varsStore)
 	if err != nil {
-		return fmt.Errorf("could not parse configure-saml-authentication flags: %s", err)
+		return configErrorf("could not parse configure-saml-authentication flags: %w", err)
 	}
 
 	ensureAvailabilityOutput, err := ca.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 	if err != nil {
-		return fmt.Errorf("could not determine initial configuration status: %s", err)
+		return fmt.Errorf("could not determine initial configuration status: %w", err)
 	}
 
 	if ensureAvailabilityOutput.Status == api.EnsureAvailabilityStatusUnknown {
@@ -48,7 +53,10 @@ func (ca ConfigureSAMLAuthentication) Execute(args []string) error {
 
 	if ensureAvailabilityOutput.Status != api.EnsureAvailabilityStatusUnstarted {
 		ca.logger.Printf("configuration previously completed, skipping configuration")
-		return nil
+		return ca.results.WriteResult(ConfigureAuthenticationResult{
+			IdentityProvider:  "saml",
+			AlreadyConfigured: true,
+		})
 	}
 
 	ca.logger.Printf("configuring SAML authentication...")
@@ -67,20 +75,22 @@ func (ca ConfigureSAMLAuthentication) Execute(args []string) error {
 		RBACGroupsAttribute:              ca.Options.RBACGroupsAttribute,
 	})
 	if err != nil {
-		return fmt.Errorf("could not configure authentication: %s", err)
+		return fmt.Errorf("could not configure authentication: %w", err)
 	}
 
 	ca.logger.Printf("waiting for configuration to complete...")
 	for ensureAvailabilityOutput.Status != api.EnsureAvailabilityStatusComplete {
 		ensureAvailabilityOutput, err = ca.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 		if err != nil {
-			return fmt.Errorf("could not determine final configuration status: %s", err)
+			return fmt.Errorf("could not determine final configuration status: %w", err)
 		}
 	}
 
 	ca.logger.Printf("configuration complete")
 
-	return nil
+	return ca.results.WriteResult(ConfigureAuthenticationResult{
+		IdentityProvider: "saml",
+	})
 }
 
 func (ca ConfigureSAMLAuthentication) Usage() jhanda.Usage {
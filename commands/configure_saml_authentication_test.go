@@ -31,8 +31,9 @@ var _ = Describe("ConfigureSAMLAuthentication", func() {
 			}
 
 			logger := &fakes.Logger{}
+			results := &fakes.ResultWriter{}
 
-			command := commands.NewConfigureSAMLAuthentication(service, logger)
+			command := commands.NewConfigureSAMLAuthentication(service, logger, results, nil)
 			err := command.Execute([]string{
 				"--decryption-passphrase", "some-passphrase",
 				"--saml-idp-metadata", "https://saml.example.com:8080",
@@ -63,6 +64,11 @@ var _ = Describe("ConfigureSAMLAuthentication", func() {
 
 			format, content = logger.PrintfArgsForCall(2)
 			Expect(fmt.Sprintf(format, content...)).To(Equal("configuration complete"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ConfigureAuthenticationResult{
+				IdentityProvider: "saml",
+			}))
 		})
 
 		Context("when the authentication setup has already been configured", func() {
@@ -73,8 +79,9 @@ var _ = Describe("ConfigureSAMLAuthentication", func() {
 				}, nil)
 
 				logger := &fakes.Logger{}
+				results := &fakes.ResultWriter{}
 
-				command := commands.NewConfigureSAMLAuthentication(service, logger)
+				command := commands.NewConfigureSAMLAuthentication(service, logger, results, nil)
 				err := command.Execute([]string{
 					"--decryption-passphrase", "some-passphrase",
 					"--saml-idp-metadata", "https://saml.example.com:8080",
@@ -89,6 +96,11 @@ var _ = Describe("ConfigureSAMLAuthentication", func() {
 
 				format, content := logger.PrintfArgsForCall(0)
 				Expect(fmt.Sprintf(format, content...)).To(Equal("configuration previously completed, skipping configuration"))
+
+				Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ConfigureAuthenticationResult{
+					IdentityProvider:  "saml",
+					AlreadyConfigured: true,
+				}))
 			})
 		})
 
@@ -126,7 +138,7 @@ decryption-passphrase: some-passphrase
 
 				logger := &fakes.Logger{}
 
-				command := commands.NewConfigureSAMLAuthentication(service, logger)
+				command := commands.NewConfigureSAMLAuthentication(service, logger, &fakes.ResultWriter{}, nil)
 				err := command.Execute([]string{
 					"--config", configFile.Name(),
 				})
@@ -170,7 +182,7 @@ decryption-passphrase: some-passphrase
 
 				logger := &fakes.Logger{}
 
-				command := commands.NewConfigureSAMLAuthentication(service, logger)
+				command := commands.NewConfigureSAMLAuthentication(service, logger, &fakes.ResultWriter{}, nil)
 				err := command.Execute([]string{
 					"--config", configFile.Name(),
 					"--saml-idp-metadata", "https://super.example.com:6543",
@@ -204,7 +216,7 @@ decryption-passphrase: some-passphrase
 		Context("failure cases", func() {
 			Context("when an unknown flag is provided", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureSAMLAuthentication(&fakes.ConfigureAuthenticationService{}, &fakes.Logger{})
+					command := commands.NewConfigureSAMLAuthentication(&fakes.ConfigureAuthenticationService{}, &fakes.Logger{}, &fakes.ResultWriter{}, nil)
 					err := command.Execute([]string{"--banana"})
 					Expect(err).To(MatchError("could not parse configure-saml-authentication flags: flag provided but not defined: -banana"))
 				})
@@ -212,7 +224,7 @@ decryption-passphrase: some-passphrase
 
 			Context("when config file cannot be opened", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureSAMLAuthentication(&fakes.ConfigureAuthenticationService{}, &fakes.Logger{})
+					command := commands.NewConfigureSAMLAuthentication(&fakes.ConfigureAuthenticationService{}, &fakes.Logger{}, &fakes.ResultWriter{}, nil)
 					err := command.Execute([]string{"--config", "something"})
 					Expect(err).To(MatchError("could not parse configure-saml-authentication flags: could not load the config file: open something: no such file or directory"))
 
@@ -224,7 +236,7 @@ decryption-passphrase: some-passphrase
 					service := &fakes.ConfigureAuthenticationService{}
 					service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{}, errors.New("failed to fetch status"))
 
-					command := commands.NewConfigureSAMLAuthentication(service, &fakes.Logger{})
+					command := commands.NewConfigureSAMLAuthentication(service, &fakes.Logger{}, &fakes.ResultWriter{}, nil)
 					err := command.Execute([]string{
 						"--decryption-passphrase", "some-passphrase",
 						"--saml-idp-metadata", "https://saml.example.com:8080",
@@ -243,7 +255,7 @@ decryption-passphrase: some-passphrase
 						Status: api.EnsureAvailabilityStatusUnknown,
 					}, nil)
 
-					command := commands.NewConfigureSAMLAuthentication(service, &fakes.Logger{})
+					command := commands.NewConfigureSAMLAuthentication(service, &fakes.Logger{}, &fakes.ResultWriter{}, nil)
 					err := command.Execute([]string{
 						"--decryption-passphrase", "some-passphrase",
 						"--saml-idp-metadata", "https://saml.example.com:8080",
@@ -264,7 +276,7 @@ decryption-passphrase: some-passphrase
 
 					service.SetupReturns(api.SetupOutput{}, errors.New("could not setup"))
 
-					command := commands.NewConfigureSAMLAuthentication(service, &fakes.Logger{})
+					command := commands.NewConfigureSAMLAuthentication(service, &fakes.Logger{}, &fakes.ResultWriter{}, nil)
 					err := command.Execute([]string{
 						"--decryption-passphrase", "some-passphrase",
 						"--saml-idp-metadata", "https://saml.example.com:8080",
@@ -293,7 +305,7 @@ decryption-passphrase: some-passphrase
 						return eaOutputs[service.EnsureAvailabilityCallCount()-1], eaErrors[service.EnsureAvailabilityCallCount()-1]
 					}
 
-					command := commands.NewConfigureSAMLAuthentication(service, &fakes.Logger{})
+					command := commands.NewConfigureSAMLAuthentication(service, &fakes.Logger{}, &fakes.ResultWriter{}, nil)
 					err := command.Execute([]string{
 						"--decryption-passphrase", "some-passphrase",
 						"--saml-idp-metadata", "https://saml.example.com:8080",
@@ -307,7 +319,7 @@ decryption-passphrase: some-passphrase
 
 			Context("when the --saml-idp-metadata field is not configured with others", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureSAMLAuthentication(nil, nil)
+					command := commands.NewConfigureSAMLAuthentication(nil, nil, nil, nil)
 					err := command.Execute([]string{
 						"--decryption-passphrase", "some-passphrase",
 						"--saml-bosh-idp-metadata", "https://bosh-saml.example.com:8080",
@@ -322,7 +334,7 @@ decryption-passphrase: some-passphrase
 
 			Context("when the --saml-bosh-idp-metadata field is not configured with others", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureSAMLAuthentication(nil, nil)
+					command := commands.NewConfigureSAMLAuthentication(nil, nil, nil, nil)
 					err := command.Execute([]string{
 						"--decryption-passphrase", "some-passphrase",
 						"--saml-idp-metadata", "https://saml.example.com:8080",
@@ -337,7 +349,7 @@ decryption-passphrase: some-passphrase
 
 			Context("when the --saml-rbac-admin-group field is not configured with others", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureSAMLAuthentication(nil, nil)
+					command := commands.NewConfigureSAMLAuthentication(nil, nil, nil, nil)
 					err := command.Execute([]string{
 						"--decryption-passphrase", "some-passphrase",
 						"--saml-idp-metadata", "https://saml.example.com:8080",
@@ -352,7 +364,7 @@ decryption-passphrase: some-passphrase
 
 			Context("when the --saml-rbac-groups-attribute field is not configured with others", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureSAMLAuthentication(nil, nil)
+					command := commands.NewConfigureSAMLAuthentication(nil, nil, nil, nil)
 					err := command.Execute([]string{
 						"--decryption-passphrase", "some-passphrase",
 						"--saml-idp-metadata", "https://saml.example.com:8080",
@@ -367,7 +379,7 @@ decryption-passphrase: some-passphrase
 
 			Context("when the --decryption-passphrase flag is missing", func() {
 				It("returns an error", func() {
-					command := commands.NewConfigureSAMLAuthentication(nil, nil)
+					command := commands.NewConfigureSAMLAuthentication(nil, nil, nil, nil)
 					err := command.Execute([]string{
 						"--saml-idp-metadata", "https://saml.example.com:8080",
 						"--saml-bosh-idp-metadata", "https://bosh-saml.example.com:8080",
@@ -381,7 +393,7 @@ decryption-passphrase: some-passphrase
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewConfigureSAMLAuthentication(nil, nil)
+			command := commands.NewConfigureSAMLAuthentication(nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This unauthenticated command helps setup the authentication mechanism for your Ops Manager with SAML.",
 				ShortDescription: "configures Ops Manager with SAML authentication",
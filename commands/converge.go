@@ -0,0 +1,587 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/config"
+	"gopkg.in/yaml.v2"
+)
+
+type Converge struct {
+	environFunc func() []string
+	varsStore   boshtpl.Variables
+	service     convergeService
+	commands    jhanda.CommandSet
+	logger      logger
+	results     resultWriter
+	Options     struct {
+		Foundation        string   `long:"foundation"         short:"f" required:"true" description:"path to yml file describing the desired director and products (see docs/converge/README.md for format)"`
+		VarsFile          []string `long:"vars-file"          short:"l"                 description:"Load variables from a YAML file"`
+		VarsEnv           []string `long:"vars-env"                                     description:"Load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
+		DownloadDirectory string   `long:"download-directory" short:"d"                 description:"directory to download products and stemcells from Pivotal Network into"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/converge_service.go --fake-name ConvergeService . convergeService
+type convergeService interface {
+	diffProductConfigService
+	stagedDirectorConfigService
+	CheckProductAvailability(productName string, productVersion string) (bool, error)
+	GetDiagnosticReport() (api.DiagnosticReport, error)
+	Info() (api.Info, error)
+	ListStagedPendingChanges() (api.PendingChangesOutput, error)
+	ListStemcells() (api.ProductStemcells, error)
+}
+
+func NewConverge(environFunc func() []string, varsStore boshtpl.Variables, service convergeService, commands jhanda.CommandSet, logger logger, results resultWriter) Converge {
+	return Converge{
+		environFunc: environFunc,
+		varsStore:   varsStore,
+		service:     service,
+		commands:    commands,
+		logger:      logger,
+		results:     results,
+	}
+}
+
+func (c Converge) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command uploads, stages, configures and deploys the director and products described in a foundation file, running only the steps needed to reach the desired state",
+		ShortDescription: "**EXPERIMENTAL** converges the Ops Manager to the state described in a foundation file",
+		Flags:            c.Options,
+	}
+}
+
+func (c Converge) Execute(args []string) error {
+	if _, err := jhanda.Parse(&c.Options, args); err != nil {
+		return configErrorf("could not parse converge flags: %w", err)
+	}
+
+	foundation, err := c.loadFoundation()
+	if err != nil {
+		return err
+	}
+
+	err = c.validateFoundation(foundation)
+	if err != nil {
+		return err
+	}
+
+	// the pending changes decide whether to apply changes, so their
+	// capability is checked before anything is changed
+	info, err := c.service.Info()
+	if err != nil {
+		return fmt.Errorf("could not retrieve info from targetted ops manager: %w", err)
+	}
+
+	err = requireCapability(info, api.CapabilityPendingChanges, "om converge")
+	if err != nil {
+		return err
+	}
+
+	var result ConvergeResult
+
+	result.DirectorConfigured, err = c.convergeDirector(foundation.Director)
+	if err != nil {
+		return err
+	}
+
+	report, err := c.service.GetDiagnosticReport()
+	if err != nil {
+		return fmt.Errorf("failed to get diagnostic report: %w", err)
+	}
+
+	result.Products = []ConvergeProductResult{}
+	for _, product := range foundation.Products {
+		productResult, err := c.convergeProduct(product, report)
+		if err != nil {
+			return err
+		}
+		result.Products = append(result.Products, productResult)
+	}
+
+	result.ChangesApplied, err = c.applyChanges()
+	if err != nil {
+		return err
+	}
+
+	return c.results.WriteResult(result)
+}
+
+func (c Converge) loadFoundation() (config.FoundationConfiguration, error) {
+	contents, err := interpolate(interpolateOptions{
+		templateFile: c.Options.Foundation,
+		varsFiles:    c.Options.VarsFile,
+		environFunc:  c.environFunc,
+		varsStore:    c.varsStore,
+		varsEnvs:     c.Options.VarsEnv,
+	}, "")
+	if err != nil {
+		return config.FoundationConfiguration{}, interpolationError(err)
+	}
+
+	var foundation config.FoundationConfiguration
+	err = yaml.UnmarshalStrict(contents, &foundation)
+	if err != nil {
+		return config.FoundationConfiguration{}, configErrorf("%s could not be parsed as valid configuration: %w", c.Options.Foundation, err)
+	}
+
+	if foundation.Director != nil {
+		foundation.Director.Config = c.resolvePath(foundation.Director.Config)
+		foundation.Director.VarsFiles = c.resolvePaths(foundation.Director.VarsFiles)
+		foundation.Director.OpsFiles = c.resolvePaths(foundation.Director.OpsFiles)
+	}
+
+	for i := range foundation.Products {
+		product := &foundation.Products[i]
+		product.File = c.resolvePath(product.File)
+		product.Config = c.resolvePath(product.Config)
+		product.VarsFiles = c.resolvePaths(product.VarsFiles)
+		product.OpsFiles = c.resolvePaths(product.OpsFiles)
+		if product.Stemcell != nil {
+			product.Stemcell.File = c.resolvePath(product.Stemcell.File)
+		}
+	}
+
+	return foundation, nil
+}
+
+// resolvePath treats relative paths in the foundation file as relative to the
+// directory containing the foundation file.
+func (c Converge) resolvePath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(filepath.Dir(c.Options.Foundation), path)
+}
+
+func (c Converge) resolvePaths(paths []string) []string {
+	var resolved []string
+	for _, path := range paths {
+		resolved = append(resolved, c.resolvePath(path))
+	}
+
+	return resolved
+}
+
+func (c Converge) validateFoundation(foundation config.FoundationConfiguration) error {
+	if len(foundation.Field) > 0 {
+		var unrecognizedKeys []string
+		for key := range foundation.Field {
+			unrecognizedKeys = append(unrecognizedKeys, key)
+		}
+		sort.Strings(unrecognizedKeys)
+
+		return configErrorf("the foundation file contains unrecognized keys: %s", strings.Join(unrecognizedKeys, ", "))
+	}
+
+	if foundation.Director != nil && foundation.Director.Config == "" {
+		return configErrorf("could not parse foundation file: \"director.config\" is required")
+	}
+
+	for i, product := range foundation.Products {
+		if product.Name == "" || product.Version == "" {
+			return configErrorf("could not parse foundation file: \"name\" and \"version\" are required for product %d", i)
+		}
+
+		if (product.File == "") == (product.Pivnet == nil) {
+			return configErrorf("could not parse foundation file: exactly one of \"file\" or \"pivnet\" is required for product %q", product.Name)
+		}
+
+		if product.Pivnet != nil && c.Options.DownloadDirectory == "" {
+			return configErrorf("--download-directory is required to download product %q from Pivotal Network", product.Name)
+		}
+	}
+
+	return nil
+}
+
+func (c Converge) convergeDirector(director *config.FoundationDirector) (bool, error) {
+	if director == nil {
+		c.logger.Printf("director is not provided, nothing to do here")
+		return false, nil
+	}
+
+	changed, err := c.directorConfigChanged(director)
+	if err != nil {
+		return false, err
+	}
+
+	if !changed {
+		c.logger.Printf("director is already configured")
+		return false, nil
+	}
+
+	c.logger.Printf("configuring director")
+
+	args := []string{"--config", director.Config}
+	args = append(args, c.varsArgs(director.VarsFiles)...)
+	for _, opsFile := range director.OpsFiles {
+		args = append(args, "--ops-file", opsFile)
+	}
+
+	err = Execute(c.commands, "configure-director", args)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// directorConfigChanged compares the desired director config with the one
+// exported from the staged director. Only the keys of the desired config are
+// compared, and credentials are ignored, as they are exported as placeholders.
+func (c Converge) directorConfigChanged(director *config.FoundationDirector) (bool, error) {
+	contents, err := interpolate(interpolateOptions{
+		templateFile: director.Config,
+		varsFiles:    append(append([]string{}, c.Options.VarsFile...), director.VarsFiles...),
+		environFunc:  c.environFunc,
+		varsStore:    c.varsStore,
+		varsEnvs:     c.Options.VarsEnv,
+		opsFiles:     director.OpsFiles,
+	}, "")
+	if err != nil {
+		return false, interpolationError(err)
+	}
+
+	var desired interface{}
+	err = yaml.Unmarshal(contents, &desired)
+	if err != nil {
+		return false, configErrorf("could not be parsed as valid configuration: %s: %w", director.Config, err)
+	}
+
+	exporter := NewStagedDirectorConfig(c.service, c.logger)
+	exporter.Options.IncludePlaceholders = true
+
+	stagedConfig, err := exporter.stagedConfig()
+	if err != nil {
+		return false, fmt.Errorf("failed to export the staged director config: %w", err)
+	}
+
+	// the round trip gives the staged config the same types as the desired one
+	stagedContents, err := yaml.Marshal(stagedConfig)
+	if err != nil {
+		return false, err // un-tested
+	}
+
+	var staged interface{}
+	err = yaml.Unmarshal(stagedContents, &staged)
+	if err != nil {
+		return false, err // un-tested
+	}
+
+	return !configContains(staged, desired), nil
+}
+
+// configContains reports whether every value in desired is the same in
+// staged. Placeholders in staged stand for credentials and match any value.
+func configContains(staged, desired interface{}) bool {
+	if placeholder, ok := staged.(string); ok && strings.HasPrefix(placeholder, "((") && strings.HasSuffix(placeholder, "))") {
+		return true
+	}
+
+	switch desiredValue := desired.(type) {
+	case map[interface{}]interface{}:
+		stagedValue, ok := staged.(map[interface{}]interface{})
+		if !ok {
+			return false
+		}
+
+		for key, value := range desiredValue {
+			if !configContains(stagedValue[key], value) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		stagedValue, ok := staged.([]interface{})
+		if !ok || len(stagedValue) != len(desiredValue) {
+			return false
+		}
+
+		for i := range desiredValue {
+			if !configContains(stagedValue[i], desiredValue[i]) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(staged, desired)
+	}
+}
+
+func (c Converge) convergeProduct(product config.FoundationProduct, report api.DiagnosticReport) (ConvergeProductResult, error) {
+	result := ConvergeProductResult{
+		ProductName:    product.Name,
+		ProductVersion: product.Version,
+	}
+
+	staged := false
+	for _, stagedProduct := range report.StagedProducts {
+		if stagedProduct.Name == product.Name && stagedProduct.Version == product.Version {
+			staged = true
+			break
+		}
+	}
+
+	stemcellFile := ""
+	if product.Stemcell != nil {
+		stemcellFile = product.Stemcell.File
+	}
+
+	if staged {
+		c.logger.Printf("%s %s is already staged", product.Name, product.Version)
+	} else {
+		available, err := c.service.CheckProductAvailability(product.Name, product.Version)
+		if err != nil {
+			return result, fmt.Errorf("failed to check availability of product %s %s: %w", product.Name, product.Version, err)
+		}
+
+		if available {
+			c.logger.Printf("%s %s is already uploaded", product.Name, product.Version)
+		} else {
+			productFile := product.File
+			if product.Pivnet != nil {
+				var downloadedStemcell string
+				productFile, downloadedStemcell, err = c.download(product)
+				if err != nil {
+					return result, err
+				}
+
+				if stemcellFile == "" {
+					stemcellFile = downloadedStemcell
+				}
+			}
+
+			err = Execute(c.commands, "upload-product", []string{
+				"--product", productFile,
+				"--product-version", product.Version,
+			})
+			if err != nil {
+				return result, err
+			}
+			result.Uploaded = true
+		}
+	}
+
+	if stemcellFile != "" {
+		var err error
+		result.StemcellUploaded, err = c.uploadStemcell(product, stemcellFile, report)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if !staged {
+		err := Execute(c.commands, "stage-product", []string{
+			"--product-name", product.Name,
+			"--product-version", product.Version,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Staged = true
+	}
+
+	if product.Stemcell != nil && product.Stemcell.Version != "" {
+		var err error
+		result.StemcellAssigned, err = c.assignStemcell(product)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	var err error
+	result.Configured, err = c.configureProduct(product, !staged)
+	return result, err
+}
+
+func (c Converge) download(product config.FoundationProduct) (string, string, error) {
+	args := []string{
+		"--pivnet-api-token", product.Pivnet.Token,
+		"--pivnet-file-glob", product.Pivnet.FileGlob,
+		"--pivnet-product-slug", product.Pivnet.Slug,
+		"--product-version", product.Version,
+		"--output-directory", c.Options.DownloadDirectory,
+	}
+	if product.Pivnet.StemcellIaas != "" {
+		args = append(args, "--download-stemcell", "--stemcell-iaas", product.Pivnet.StemcellIaas)
+	}
+
+	err := Execute(c.commands, "download-product", args)
+	if err != nil {
+		return "", "", err
+	}
+
+	downloadListFile, err := os.Open(filepath.Join(c.Options.DownloadDirectory, DownloadListFilename))
+	if err != nil {
+		return "", "", fmt.Errorf("could not read %s: %w", DownloadListFilename, err)
+	}
+	defer downloadListFile.Close()
+
+	var downloaded downloadList
+	err = json.NewDecoder(downloadListFile).Decode(&downloaded)
+	if err != nil {
+		return "", "", fmt.Errorf("could not parse %s: %w", DownloadListFilename, err)
+	}
+
+	return downloaded.Product, downloaded.Stemcell, nil
+}
+
+func (c Converge) uploadStemcell(product config.FoundationProduct, stemcellFile string, report api.DiagnosticReport) (bool, error) {
+	for _, stemcell := range report.Stemcells {
+		if stemcell == filepath.Base(stemcellFile) {
+			c.logger.Printf("stemcell %s is already uploaded", stemcell)
+			return false, nil
+		}
+	}
+
+	floating := product.Stemcell == nil || product.Stemcell.Version == ""
+
+	err := Execute(c.commands, "upload-stemcell", []string{
+		"--stemcell", stemcellFile,
+		fmt.Sprintf("--floating=%t", floating),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c Converge) assignStemcell(product config.FoundationProduct) (bool, error) {
+	productStemcells, err := c.service.ListStemcells()
+	if err != nil {
+		return false, fmt.Errorf("failed to list stemcell assignments: %w", err)
+	}
+
+	for _, productStemcell := range productStemcells.Products {
+		if productStemcell.ProductName == product.Name && productStemcell.StagedStemcellVersion == product.Stemcell.Version {
+			c.logger.Printf("stemcell %s is already assigned to %s", product.Stemcell.Version, product.Name)
+			return false, nil
+		}
+	}
+
+	err = Execute(c.commands, "assign-stemcell", []string{
+		"--product", product.Name,
+		"--stemcell", product.Stemcell.Version,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c Converge) configureProduct(product config.FoundationProduct, newlyStaged bool) (bool, error) {
+	if product.Config == "" {
+		c.logger.Printf("config for %s is not provided, nothing to do here", product.Name)
+		return false, nil
+	}
+
+	if !newlyStaged {
+		changed, err := c.productConfigChanged(product)
+		if err != nil {
+			return false, err
+		}
+
+		if !changed {
+			c.logger.Printf("%s is already configured", product.Name)
+			return false, nil
+		}
+	}
+
+	args := []string{"--config", product.Config}
+	args = append(args, c.varsArgs(product.VarsFiles)...)
+	for _, opsFile := range product.OpsFiles {
+		args = append(args, "--ops-file", opsFile)
+	}
+
+	err := Execute(c.commands, "configure-product", args)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// productConfigChanged ignores credentials, because their staged values
+// cannot be read back and would otherwise always count as a change.
+func (c Converge) productConfigChanged(product config.FoundationProduct) (bool, error) {
+	cfg, err := interpolateProductConfig(interpolateOptions{
+		templateFile: product.Config,
+		varsFiles:    append(append([]string{}, c.Options.VarsFile...), product.VarsFiles...),
+		environFunc:  c.environFunc,
+		varsStore:    c.varsStore,
+		varsEnvs:     c.Options.VarsEnv,
+		opsFiles:     product.OpsFiles,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	err = validateProductConfig(cfg)
+	if err != nil {
+		return false, err
+	}
+
+	sections, err := NewDiffProductConfig(c.environFunc, c.varsStore, c.service, nil, c.logger).findDifferences(cfg)
+	if err != nil {
+		return false, err
+	}
+
+	for _, section := range sections {
+		for _, difference := range section.differences {
+			if !difference.credential {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (c Converge) applyChanges() (bool, error) {
+	pendingChanges, err := c.service.ListStagedPendingChanges()
+	if err != nil {
+		return false, fmt.Errorf("failed to list pending changes: %w", err)
+	}
+
+	for _, change := range pendingChanges.ChangeList {
+		if change.Action != "unchanged" {
+			err = Execute(c.commands, "apply-changes", nil)
+			if err != nil {
+				return false, err
+			}
+
+			return true, nil
+		}
+	}
+
+	c.logger.Printf("there are no pending changes, nothing to apply")
+	return false, nil
+}
+
+func (c Converge) varsArgs(varsFiles []string) []string {
+	var args []string
+	for _, varsFile := range append(append([]string{}, c.Options.VarsFile...), varsFiles...) {
+		args = append(args, "--vars-file", varsFile)
+	}
+	for _, varsEnv := range c.Options.VarsEnv {
+		args = append(args, "--vars-env", varsEnv)
+	}
+
+	return args
+}
@@ -0,0 +1,455 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordedExecution struct {
+	name string
+	args []string
+}
+
+type recordingCommand struct {
+	name       string
+	executions *[]recordedExecution
+	err        error
+}
+
+func (r recordingCommand) Execute(args []string) error {
+	*r.executions = append(*r.executions, recordedExecution{name: r.name, args: args})
+	return r.err
+}
+
+func (r recordingCommand) Usage() jhanda.Usage {
+	return jhanda.Usage{}
+}
+
+var _ = Describe("Converge", func() {
+	var (
+		logger       *fakes.Logger
+		fakeService  *fakes.ConvergeService
+		commandSet   jhanda.CommandSet
+		executions   []recordedExecution
+		tempDir      string
+		foundation   string
+		command      commands.Converge
+		results      *fakes.ResultWriter
+		executedArgs func(string) [][]string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "converge")
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = &fakes.Logger{}
+		fakeService = &fakes.ConvergeService{}
+		fakeService.InfoReturns(api.Info{Version: "2.3-build.167"}, nil)
+		fakeService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+			ChangeList: []api.ProductChange{{Product: "cf-guid", Action: "install"}},
+		}, nil)
+
+		executions = nil
+		commandSet = jhanda.CommandSet{}
+		for _, name := range []string{
+			"configure-director",
+			"download-product",
+			"upload-product",
+			"upload-stemcell",
+			"stage-product",
+			"assign-stemcell",
+			"configure-product",
+			"apply-changes",
+		} {
+			commandSet[name] = recordingCommand{name: name, executions: &executions}
+		}
+
+		executedArgs = func(name string) [][]string {
+			var args [][]string
+			for _, execution := range executions {
+				if execution.name == name {
+					args = append(args, execution.args)
+				}
+			}
+			return args
+		}
+
+		results = &fakes.ResultWriter{}
+		foundation = filepath.Join(tempDir, "foundation.yml")
+		command = commands.NewConverge(func() []string { return nil }, nil, fakeService, commandSet, logger, results)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	writeFoundation := func(contents string) {
+		err := ioutil.WriteFile(foundation, []byte(contents), 0600)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	writeFile := func(name, contents string) {
+		err := ioutil.WriteFile(filepath.Join(tempDir, name), []byte(contents), 0600)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	executedNames := func() []string {
+		var names []string
+		for _, execution := range executions {
+			names = append(names, execution.name)
+		}
+		return names
+	}
+
+	Describe("Execute", func() {
+		Context("when nothing has been uploaded yet", func() {
+			BeforeEach(func() {
+				writeFoundation(`---
+director:
+  config: director.yml
+  vars-files: [director-vars.yml]
+products:
+- name: cf
+  version: 2.3.0
+  file: /products/cf-2.3.0.pivotal
+  stemcell:
+    file: stemcells/light-bosh-stemcell-97.19.tgz
+    version: "97.19"
+  config: cf.yml
+  ops-files: [cf-ops.yml]
+`)
+				writeFile("director.yml", `{"director-configuration": {"ntp_servers_string": "ntp.example.com"}}`)
+				writeFile("director-vars.yml", `{}`)
+			})
+
+			It("runs every step in order", func() {
+				varsFile := filepath.Join(tempDir, "common-vars.yml")
+				Expect(ioutil.WriteFile(varsFile, []byte("{}"), 0600)).To(Succeed())
+
+				err := command.Execute([]string{"--foundation", foundation, "--vars-file", varsFile})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executedNames()).To(Equal([]string{
+					"configure-director",
+					"upload-product",
+					"upload-stemcell",
+					"stage-product",
+					"assign-stemcell",
+					"configure-product",
+					"apply-changes",
+				}))
+
+				Expect(executedArgs("configure-director")).To(Equal([][]string{{
+					"--config", filepath.Join(tempDir, "director.yml"),
+					"--vars-file", varsFile,
+					"--vars-file", filepath.Join(tempDir, "director-vars.yml"),
+				}}))
+				Expect(executedArgs("upload-product")).To(Equal([][]string{{
+					"--product", "/products/cf-2.3.0.pivotal",
+					"--product-version", "2.3.0",
+				}}))
+				Expect(executedArgs("upload-stemcell")).To(Equal([][]string{{
+					"--stemcell", filepath.Join(tempDir, "stemcells", "light-bosh-stemcell-97.19.tgz"),
+					"--floating=false",
+				}}))
+				Expect(executedArgs("stage-product")).To(Equal([][]string{{
+					"--product-name", "cf",
+					"--product-version", "2.3.0",
+				}}))
+				Expect(executedArgs("assign-stemcell")).To(Equal([][]string{{
+					"--product", "cf",
+					"--stemcell", "97.19",
+				}}))
+				Expect(executedArgs("configure-product")).To(Equal([][]string{{
+					"--config", filepath.Join(tempDir, "cf.yml"),
+					"--vars-file", varsFile,
+					"--ops-file", filepath.Join(tempDir, "cf-ops.yml"),
+				}}))
+
+				name, version := fakeService.CheckProductAvailabilityArgsForCall(0)
+				Expect(name).To(Equal("cf"))
+				Expect(version).To(Equal("2.3.0"))
+
+				Expect(results.WriteResultCallCount()).To(Equal(1))
+				Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ConvergeResult{
+					DirectorConfigured: true,
+					Products: []commands.ConvergeProductResult{{
+						ProductName:      "cf",
+						ProductVersion:   "2.3.0",
+						Uploaded:         true,
+						StemcellUploaded: true,
+						Staged:           true,
+						StemcellAssigned: true,
+						Configured:       true,
+					}},
+					ChangesApplied: true,
+				}))
+			})
+		})
+
+		Context("when the foundation has already converged", func() {
+			BeforeEach(func() {
+				writeFoundation(`---
+products:
+- name: cf
+  version: 2.3.0
+  file: /products/cf-2.3.0.pivotal
+  stemcell:
+    file: /stemcells/light-bosh-stemcell-97.19.tgz
+    version: "97.19"
+  config: cf.yml
+`)
+				err := ioutil.WriteFile(filepath.Join(tempDir, "cf.yml"), []byte(`{"product-name": "cf", "product-properties": {".properties.some-property": {"value": "some-value"}}}`), 0600)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+					Stemcells:      []string{"light-bosh-stemcell-97.19.tgz"},
+					StagedProducts: []api.DiagnosticProduct{{Name: "cf", Version: "2.3.0"}},
+				}, nil)
+				fakeService.ListStemcellsReturns(api.ProductStemcells{
+					Products: []api.ProductStemcell{{ProductName: "cf", StagedStemcellVersion: "97.19"}},
+				}, nil)
+				fakeService.GetStagedProductByNameReturns(api.StagedProductsFindOutput{
+					Product: api.StagedProduct{GUID: "cf-guid", Type: "cf"},
+				}, nil)
+				fakeService.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+					".properties.some-property": {Type: "string", Value: "some-value", Configurable: true},
+				}, nil)
+				fakeService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+					ChangeList: []api.ProductChange{{Product: "cf-guid", Action: "unchanged"}},
+				}, nil)
+			})
+
+			It("does not run any commands", func() {
+				err := command.Execute([]string{"--foundation", foundation})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executions).To(BeEmpty())
+				Expect(fakeService.CheckProductAvailabilityCallCount()).To(Equal(0))
+				Expect(fakeService.GetStagedProductByNameArgsForCall(0)).To(Equal("cf"))
+
+				Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ConvergeResult{
+					Products: []commands.ConvergeProductResult{{
+						ProductName:    "cf",
+						ProductVersion: "2.3.0",
+					}},
+				}))
+			})
+
+			Context("when the product configuration has drifted", func() {
+				BeforeEach(func() {
+					fakeService.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+						".properties.some-property": {Type: "string", Value: "other-value", Configurable: true},
+					}, nil)
+					fakeService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+						ChangeList: []api.ProductChange{{Product: "cf-guid", Action: "update"}},
+					}, nil)
+				})
+
+				It("only configures the product and applies changes", func() {
+					err := command.Execute([]string{"--foundation", foundation})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(executedNames()).To(Equal([]string{"configure-product", "apply-changes"}))
+				})
+			})
+		})
+
+		Context("when the director has already converged", func() {
+			BeforeEach(func() {
+				writeFoundation(`{"director": {"config": "director.yml"}, "products": []}`)
+				writeFile("director.yml", `---
+director-configuration:
+  ntp_servers_string: ntp.example.com
+  resurrector_enabled: true
+iaas-configuration:
+  project: some-project
+  auth_json: some-secret
+`)
+
+				fakeService.GetStagedDirectorPropertiesReturns(map[string]map[string]interface{}{
+					"director_configuration": {
+						"ntp_servers_string":  "ntp.example.com",
+						"resurrector_enabled": true,
+						"max_threads":         5,
+					},
+					"iaas_configuration": {
+						"project":   "some-project",
+						"auth_json": "***",
+					},
+				}, nil)
+				fakeService.ListStagedPendingChangesReturns(api.PendingChangesOutput{}, nil)
+			})
+
+			It("does not configure the director", func() {
+				err := command.Execute([]string{"--foundation", foundation})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executions).To(BeEmpty())
+				Expect(fakeService.GetStagedProductByNameArgsForCall(0)).To(Equal("p-bosh"))
+			})
+
+			It("configures the director when its config has drifted", func() {
+				fakeService.GetStagedDirectorPropertiesReturns(map[string]map[string]interface{}{
+					"director_configuration": {
+						"ntp_servers_string":  "ntp.example.com",
+						"resurrector_enabled": false,
+					},
+				}, nil)
+
+				err := command.Execute([]string{"--foundation", foundation})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executedNames()).To(Equal([]string{"configure-director"}))
+			})
+		})
+
+		Context("when the product is downloaded from Pivotal Network", func() {
+			var downloadDir string
+
+			BeforeEach(func() {
+				downloadDir = filepath.Join(tempDir, "downloads")
+				Expect(os.Mkdir(downloadDir, 0700)).To(Succeed())
+
+				contents, err := json.Marshal(map[string]string{
+					"product":  filepath.Join(downloadDir, "cf-2.3.0.pivotal"),
+					"stemcell": filepath.Join(downloadDir, "light-bosh-stemcell-97.19.tgz"),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.WriteFile(filepath.Join(downloadDir, commands.DownloadListFilename), contents, 0600)).To(Succeed())
+
+				writeFoundation(`---
+products:
+- name: cf
+  version: 2.3.0
+  pivnet:
+    slug: elastic-runtime
+    file-glob: "cf-*.pivotal"
+    api-token: some-token
+    stemcell-iaas: google
+`)
+			})
+
+			It("downloads the product and its stemcell before uploading them", func() {
+				err := command.Execute([]string{"--foundation", foundation, "--download-directory", downloadDir})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executedNames()).To(Equal([]string{
+					"download-product",
+					"upload-product",
+					"upload-stemcell",
+					"stage-product",
+					"apply-changes",
+				}))
+				Expect(executedArgs("download-product")).To(Equal([][]string{{
+					"--pivnet-api-token", "some-token",
+					"--pivnet-file-glob", "cf-*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.3.0",
+					"--output-directory", downloadDir,
+					"--download-stemcell", "--stemcell-iaas", "google",
+				}}))
+				Expect(executedArgs("upload-product")[0][1]).To(Equal(filepath.Join(downloadDir, "cf-2.3.0.pivotal")))
+				Expect(executedArgs("upload-stemcell")).To(Equal([][]string{{
+					"--stemcell", filepath.Join(downloadDir, "light-bosh-stemcell-97.19.tgz"),
+					"--floating=true",
+				}}))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when an unknown flag is provided", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse converge flags: flag provided but not defined: -badflag"))
+			})
+
+			It("returns an unsupported error before changing anything when Ops Manager cannot list pending changes", func() {
+				writeFoundation(`{"products": [{"name": "cf", "version": "2.3.0", "file": "cf.pivotal"}]}`)
+				fakeService.InfoReturns(api.Info{Version: "2.1-build.326"}, nil)
+
+				err := command.Execute([]string{"--foundation", foundation})
+				Expect(err).To(MatchError("om converge is only available with Ops Manager 2.2 or later: you are running 2.1-build.326"))
+				Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeUnsupported))
+				Expect(executions).To(BeEmpty())
+			})
+
+			It("returns an error when the foundation file contains unrecognized keys", func() {
+				writeFoundation(`{"products": [], "unknown-key": true}`)
+
+				err := command.Execute([]string{"--foundation", foundation})
+				Expect(err).To(MatchError("the foundation file contains unrecognized keys: unknown-key"))
+				Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+			})
+
+			It("returns an error when a product has neither a file nor a pivnet source", func() {
+				writeFoundation(`{"products": [{"name": "cf", "version": "2.3.0"}]}`)
+
+				err := command.Execute([]string{"--foundation", foundation})
+				Expect(err).To(MatchError(`could not parse foundation file: exactly one of "file" or "pivnet" is required for product "cf"`))
+				Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+			})
+
+			It("returns an error when a pivnet product is given without a download directory", func() {
+				writeFoundation(`{"products": [{"name": "cf", "version": "2.3.0", "pivnet": {"slug": "cf"}}]}`)
+
+				err := command.Execute([]string{"--foundation", foundation})
+				Expect(err).To(MatchError(`--download-directory is required to download product "cf" from Pivotal Network`))
+				Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+			})
+
+			It("stops when a step fails", func() {
+				writeFoundation(`{"director": {"config": "director.yml"}, "products": [{"name": "cf", "version": "2.3.0", "file": "cf.pivotal"}]}`)
+				writeFile("director.yml", `{"director-configuration": {"ntp_servers_string": "ntp.example.com"}}`)
+				commandSet["configure-director"] = recordingCommand{name: "configure-director", executions: &executions, err: errors.New("director failed")}
+
+				err := command.Execute([]string{"--foundation", foundation})
+				Expect(err).To(MatchError(`could not execute "configure-director": director failed`))
+				Expect(executedNames()).To(Equal([]string{"configure-director"}))
+			})
+
+			It("keeps the error of the step for the exit code", func() {
+				writeFoundation(`{"products": [{"name": "cf", "version": "2.3.0", "file": "cf.pivotal"}]}`)
+				commandSet["apply-changes"] = recordingCommand{
+					name:       "apply-changes",
+					executions: &executions,
+					err:        commands.InstallationFailedError{InstallationID: 42, Err: errors.New("installation failed")},
+				}
+
+				err := command.Execute([]string{"--foundation", foundation})
+				Expect(err).To(MatchError(`could not execute "apply-changes": installation failed`))
+				Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeInstallationFailed))
+			})
+
+			It("returns an error when the diagnostic report cannot be fetched", func() {
+				writeFoundation(`{"products": []}`)
+				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("some-error"))
+
+				err := command.Execute([]string{"--foundation", foundation})
+				Expect(err).To(MatchError("failed to get diagnostic report: some-error"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewConverge(nil, nil, nil, nil, nil, nil)
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command uploads, stages, configures and deploys the director and products described in a foundation file, running only the steps needed to reach the desired state",
+				ShortDescription: "**EXPERIMENTAL** converges the Ops Manager to the state described in a foundation file",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/presenters"
@@ -14,7 +12,7 @@ type CreateCertificateAuthority struct {
 	Options   struct {
 		CertPem    string `long:"certificate-pem" required:"true" description:"certificate"`
 		PrivateKey string `long:"private-key-pem" required:"true" description:"private key"`
-		Format     string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format     string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -29,7 +27,7 @@ func NewCreateCertificateAuthority(service createCertificateAuthorityService, pr
 
 func (c CreateCertificateAuthority) Execute(args []string) error {
 	if _, err := jhanda.Parse(&c.Options, args); err != nil {
-		return fmt.Errorf("could not parse create-certificate-authority flags: %s", err)
+		return configErrorf("could not parse create-certificate-authority flags: %w", err)
 	}
 
 	ca, err := c.service.CreateCertificateAuthority(api.CertificateAuthorityInput{
@@ -40,10 +38,12 @@ func (c CreateCertificateAuthority) Execute(args []string) error {
 		return err
 	}
 
-	c.presenter.SetFormat(c.Options.Format)
-	c.presenter.PresentCertificateAuthority(ca)
+	err = c.presenter.SetFormat(c.Options.Format)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return c.presenter.PresentCertificateAuthority(ca)
 }
 
 func (c CreateCertificateAuthority) Usage() jhanda.Usage {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/config"
@@ -17,8 +18,10 @@ type createVMExtensionService interface {
 
 type CreateVMExtension struct {
 	environFunc func() []string
+	varsStore   boshtpl.Variables
 	service     createVMExtensionService
 	logger      logger
+	results     resultWriter
 	Options     struct {
 		Name            string   `long:"name"               short:"n"   description:"VM extension name"`
 		ConfigFile      string   `long:"config"             short:"c"   description:"path to yml file containing all config fields (see docs/create-vm-extension/README.md for format)"`
@@ -29,17 +32,19 @@ type CreateVMExtension struct {
 	}
 }
 
-func NewCreateVMExtension(environFunc func() []string, service createVMExtensionService, logger logger) CreateVMExtension {
+func NewCreateVMExtension(environFunc func() []string, varsStore boshtpl.Variables, service createVMExtensionService, logger logger, results resultWriter) CreateVMExtension {
 	return CreateVMExtension{
 		environFunc: environFunc,
+		varsStore:   varsStore,
 		service:     service,
 		logger:      logger,
+		results:     results,
 	}
 }
 
 func (c CreateVMExtension) Execute(args []string) error {
 	if _, err := jhanda.Parse(&c.Options, args); err != nil {
-		return fmt.Errorf("could not parse create-vm-extension flags: %s", err)
+		return configErrorf("could not parse create-vm-extension flags: %w", err)
 	}
 
 	var (
@@ -52,6 +57,7 @@ func (c CreateVMExtension) Execute(args []string) error {
 			templateFile: c.Options.ConfigFile,
 			varsFiles:    c.Options.VarsFile,
 			environFunc:  c.environFunc,
+			varsStore:    c.varsStore,
 			varsEnvs:     c.Options.VarsEnv,
 			opsFiles:     c.Options.OpsFile,
 		}, "")
@@ -61,7 +67,7 @@ func (c CreateVMExtension) Execute(args []string) error {
 
 		err = yaml.Unmarshal(configContents, &cfg)
 		if err != nil {
-			return fmt.Errorf("%s could not be parsed as valid configuration: %s", c.Options.ConfigFile, err)
+			return fmt.Errorf("%s could not be parsed as valid configuration: %w", c.Options.ConfigFile, err)
 		}
 
 		if cfg.VMExtension.Name == "" {
@@ -94,7 +100,7 @@ func (c CreateVMExtension) Execute(args []string) error {
 
 	c.logger.Printf("VM Extension '%s' created/updated\n", name)
 
-	return nil
+	return c.results.WriteResult(CreateVMExtensionResult{Name: name})
 }
 
 func (c CreateVMExtension) Usage() jhanda.Usage {
@@ -34,6 +34,7 @@ var _ = Describe("CreateVMExtension", func() {
 	var (
 		fakeService *fakes.CreateVMExtensionService
 		fakeLogger  *fakes.Logger
+		results     *fakes.ResultWriter
 		command     commands.CreateVMExtension
 		configFile  *os.File
 		err         error
@@ -43,7 +44,8 @@ var _ = Describe("CreateVMExtension", func() {
 	BeforeEach(func() {
 		fakeService = &fakes.CreateVMExtensionService{}
 		fakeLogger = &fakes.Logger{}
-		command = commands.NewCreateVMExtension(func() []string { return nil }, fakeService, fakeLogger)
+		results = &fakes.ResultWriter{}
+		command = commands.NewCreateVMExtension(func() []string { return nil }, nil, fakeService, fakeLogger, results)
 	})
 
 	AfterEach(func() {
@@ -71,6 +73,11 @@ var _ = Describe("CreateVMExtension", func() {
 			Expect(fakeLogger.PrintfCallCount()).To(Equal(1))
 			format, content := fakeLogger.PrintfArgsForCall(0)
 			Expect(fmt.Sprintf(format, content...)).To(Equal("VM Extension 'some-vm-extension' created/updated\n"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.CreateVMExtensionResult{
+				Name: "some-vm-extension",
+			}))
 		})
 
 		Context("when using a config file", func() {
@@ -113,8 +120,10 @@ var _ = Describe("CreateVMExtension", func() {
 				It("makes a request to the OpsMan to create a VM extension", func() {
 					command = commands.NewCreateVMExtension(
 						func() []string { return []string{"OM_VAR_vm_extension_name=some-vm-extension"} },
+						nil,
 						fakeService,
-						fakeLogger)
+						fakeLogger,
+						results)
 					configFile, err = ioutil.TempFile("", "")
 					Expect(err).NotTo(HaveOccurred())
 
@@ -224,7 +233,7 @@ var _ = Describe("CreateVMExtension", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewCreateVMExtension(nil, nil, nil)
+			command := commands.NewCreateVMExtension(nil, nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This creates/updates a VM extension",
 				ShortDescription: "creates/updates a VM extension",
@@ -15,7 +15,7 @@ type CredentialReferences struct {
 	logger    logger
 	Options   struct {
 		Product string `long:"product-name" short:"p" required:"true" description:"name of deployed product"`
-		Format  string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format  string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -31,13 +31,13 @@ func NewCredentialReferences(crService credentialReferencesService, presenter pr
 
 func (cr CredentialReferences) Execute(args []string) error {
 	if _, err := jhanda.Parse(&cr.Options, args); err != nil {
-		return fmt.Errorf("could not parse credential-references flags: %s", err)
+		return configErrorf("could not parse credential-references flags: %w", err)
 	}
 
 	deployedProductGUID := ""
 	deployedProducts, err := cr.service.ListDeployedProducts()
 	if err != nil {
-		return fmt.Errorf("failed to list credential references: %s", err)
+		return fmt.Errorf("failed to list credential references: %w", err)
 	}
 	for _, deployedProduct := range deployedProducts {
 		if deployedProduct.Type == cr.Options.Product {
@@ -53,7 +53,7 @@ func (cr CredentialReferences) Execute(args []string) error {
 	output, err := cr.service.ListDeployedProductCredentials(deployedProductGUID)
 	sort.Strings(output.Credentials)
 	if err != nil {
-		return fmt.Errorf("failed to list credential references: %s", err)
+		return fmt.Errorf("failed to list credential references: %w", err)
 	}
 
 	if len(output.Credentials) == 0 {
@@ -61,10 +61,12 @@ func (cr CredentialReferences) Execute(args []string) error {
 		return nil
 	}
 
-	cr.presenter.SetFormat(cr.Options.Format)
-	cr.presenter.PresentCredentialReferences(output.Credentials)
+	err = cr.presenter.SetFormat(cr.Options.Format)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return cr.presenter.PresentCredentialReferences(output.Credentials)
 }
 
 func (cr CredentialReferences) Usage() jhanda.Usage {
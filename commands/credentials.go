@@ -16,7 +16,7 @@ type Credentials struct {
 		Product             string `long:"product-name"         short:"p" required:"true" description:"name of deployed product"`
 		CredentialReference string `long:"credential-reference" short:"c" required:"true" description:"name of credential reference"`
 		CredentialField     string `long:"credential-field"     short:"f"                 description:"single credential field to output"`
-		Format              string `long:"format"               short:"t" default:"table" description:"Format to print as (options: table,json)"`
+		Format              string `long:"format"               short:"t" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -32,13 +32,13 @@ func NewCredentials(csService credentialsService, presenter presenters.Formatted
 
 func (cs Credentials) Execute(args []string) error {
 	if _, err := jhanda.Parse(&cs.Options, args); err != nil {
-		return fmt.Errorf("could not parse credential-references flags: %s", err)
+		return configErrorf("could not parse credential-references flags: %w", err)
 	}
 
 	deployedProductGUID := ""
 	deployedProducts, err := cs.service.ListDeployedProducts()
 	if err != nil {
-		return fmt.Errorf("failed to fetch credential: %s", err)
+		return fmt.Errorf("failed to fetch credential: %w", err)
 	}
 	for _, deployedProduct := range deployedProducts {
 		if deployedProduct.Type == cs.Options.Product {
@@ -56,7 +56,7 @@ func (cs Credentials) Execute(args []string) error {
 		CredentialReference: cs.Options.CredentialReference,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to fetch credential for %q: %s", cs.Options.CredentialReference, err)
+		return fmt.Errorf("failed to fetch credential for %q: %w", cs.Options.CredentialReference, err)
 	}
 
 	if len(output.Credential.Value) == 0 {
@@ -64,13 +64,17 @@ func (cs Credentials) Execute(args []string) error {
 	}
 
 	if cs.Options.CredentialField == "" {
-		cs.presenter.SetFormat(cs.Options.Format)
-		cs.presenter.PresentCredentials(output.Credential.Value)
+		err = cs.presenter.SetFormat(cs.Options.Format)
+		if err != nil {
+			return err
+		}
+
+		return cs.presenter.PresentCredentials(output.Credential.Value)
 	} else {
 		if value, ok := output.Credential.Value[cs.Options.CredentialField]; ok {
 			cs.logger.Println(value)
 		} else {
-			return fmt.Errorf("credential field %q not found", cs.Options.CredentialField)
+			return NotFoundError{Err: fmt.Errorf("credential field %q not found", cs.Options.CredentialField)}
 		}
 	}
 
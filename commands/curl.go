@@ -37,7 +37,7 @@ func NewCurl(service curlService, stdout logger, stderr logger) Curl {
 
 func (c Curl) Execute(args []string) error {
 	if _, err := jhanda.Parse(&c.Options, args); err != nil {
-		return fmt.Errorf("could not parse curl flags: %s", err)
+		return configErrorf("could not parse curl flags: %w", err)
 	}
 
 	requestHeaders := make(http.Header)
@@ -55,7 +55,7 @@ func (c Curl) Execute(args []string) error {
 
 	output, err := c.service.Curl(input)
 	if err != nil {
-		return fmt.Errorf("failed to make api request: %s", err)
+		return fmt.Errorf("failed to make api request: %w", err)
 	}
 
 	writeHeadersToStderr := !c.Options.Silent || output.StatusCode >= 400
@@ -67,7 +67,7 @@ func (c Curl) Execute(args []string) error {
 	headers := bytes.NewBuffer([]byte{})
 	err = output.Headers.Write(headers)
 	if err != nil {
-		return fmt.Errorf("failed to write api response headers: %s", err)
+		return fmt.Errorf("failed to write api response headers: %w", err)
 	}
 
 	if writeHeadersToStderr {
@@ -76,7 +76,7 @@ func (c Curl) Execute(args []string) error {
 
 	body, err := ioutil.ReadAll(output.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read api response body: %s", err)
+		return fmt.Errorf("failed to read api response body: %w", err)
 	}
 	defer output.Body.Close()
 
@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 )
@@ -10,6 +8,7 @@ import (
 type DeleteCertificateAuthority struct {
 	service deleteCertificateAuthorityService
 	logger  logger
+	results resultWriter
 	Options struct {
 		Id string `long:"id" required:"true" description:"certificate authority id"`
 	}
@@ -20,13 +19,13 @@ type deleteCertificateAuthorityService interface {
 	DeleteCertificateAuthority(api.DeleteCertificateAuthorityInput) error
 }
 
-func NewDeleteCertificateAuthority(service deleteCertificateAuthorityService, logger logger) DeleteCertificateAuthority {
-	return DeleteCertificateAuthority{service: service, logger: logger}
+func NewDeleteCertificateAuthority(service deleteCertificateAuthorityService, logger logger, results resultWriter) DeleteCertificateAuthority {
+	return DeleteCertificateAuthority{service: service, logger: logger, results: results}
 }
 
 func (a DeleteCertificateAuthority) Execute(args []string) error {
 	if _, err := jhanda.Parse(&a.Options, args); err != nil {
-		return fmt.Errorf("could not parse delete-certificate-authority flags: %s", err)
+		return configErrorf("could not parse delete-certificate-authority flags: %w", err)
 	}
 
 	err := a.service.DeleteCertificateAuthority(api.DeleteCertificateAuthorityInput{
@@ -39,7 +38,7 @@ func (a DeleteCertificateAuthority) Execute(args []string) error {
 
 	a.logger.Printf("Certificate authority '%s' deleted\n", a.Options.Id)
 
-	return nil
+	return a.results.WriteResult(CertificateAuthorityResult{GUID: a.Options.Id})
 }
 
 func (a DeleteCertificateAuthority) Usage() jhanda.Usage {
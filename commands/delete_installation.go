@@ -13,6 +13,7 @@ type DeleteInstallation struct {
 	service      deleteInstallationService
 	logger       logger
 	logWriter    logWriter
+	results      resultWriter
 	waitDuration time.Duration
 }
 
@@ -24,11 +25,12 @@ type deleteInstallationService interface {
 	GetInstallationLogs(id int) (api.InstallationsServiceOutput, error)
 }
 
-func NewDeleteInstallation(service deleteInstallationService, logWriter logWriter, logger logger, waitDuration time.Duration) DeleteInstallation {
+func NewDeleteInstallation(service deleteInstallationService, logWriter logWriter, logger logger, results resultWriter, waitDuration time.Duration) DeleteInstallation {
 	return DeleteInstallation{
 		service:      service,
 		logger:       logger,
 		logWriter:    logWriter,
+		results:      results,
 		waitDuration: waitDuration,
 	}
 }
@@ -41,12 +43,12 @@ func (ac DeleteInstallation) Execute(args []string) error {
 
 		installation, err = ac.service.DeleteInstallationAssetCollection()
 		if err != nil {
-			return fmt.Errorf("failed to delete installation: %s", err)
+			return fmt.Errorf("failed to delete installation: %w", err)
 		}
 
 		if installation == (api.InstallationsServiceOutput{}) {
 			ac.logger.Printf("no installation to delete")
-			return nil
+			return ac.results.WriteResult(DeleteInstallationResult{NothingToDelete: true})
 		}
 	} else {
 		ac.logger.Printf("found already running deletion...attempting to re-attach")
@@ -55,23 +57,33 @@ func (ac DeleteInstallation) Execute(args []string) error {
 	for {
 		current, err := ac.service.GetInstallation(installation.ID)
 		if err != nil {
-			return fmt.Errorf("installation failed to get status: %s", err)
+			return fmt.Errorf("installation failed to get status: %w", err)
 		}
 
 		install, err := ac.service.GetInstallationLogs(installation.ID)
 		if err != nil {
-			return fmt.Errorf("installation failed to get logs: %s", err)
+			return fmt.Errorf("installation failed to get logs: %w", err)
 		}
 
 		err = ac.logWriter.Flush(install.Logs)
 		if err != nil {
-			return fmt.Errorf("installation failed to flush logs: %s", err)
+			return fmt.Errorf("installation failed to flush logs: %w", err)
+		}
+
+		if current.Status == api.StatusSucceeded || current.Status == api.StatusFailed {
+			err = ac.results.WriteResult(DeleteInstallationResult{
+				InstallationID: installation.ID,
+				Status:         current.Status,
+			})
+			if err != nil {
+				return err
+			}
 		}
 
 		if current.Status == api.StatusSucceeded {
 			return nil
 		} else if current.Status == api.StatusFailed {
-			return errors.New("deleting the installation was unsuccessful")
+			return InstallationFailedError{InstallationID: installation.ID, Err: errors.New("deleting the installation was unsuccessful")}
 		}
 
 		time.Sleep(ac.waitDuration)
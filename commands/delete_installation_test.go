@@ -18,6 +18,7 @@ var _ = Describe("DeleteInstallation", func() {
 		fakeService   *fakes.DeleteInstallationService
 		logger        *fakes.Logger
 		writer        *fakes.LogWriter
+		results       *fakes.ResultWriter
 		statusOutputs []api.InstallationsServiceOutput
 		statusErrors  []error
 		logsOutputs   []api.InstallationsServiceOutput
@@ -30,6 +31,7 @@ var _ = Describe("DeleteInstallation", func() {
 		fakeService = &fakes.DeleteInstallationService{}
 		logger = &fakes.Logger{}
 		writer = &fakes.LogWriter{}
+		results = &fakes.ResultWriter{}
 
 		statusCount = 0
 		logsCount = 0
@@ -73,7 +75,7 @@ var _ = Describe("DeleteInstallation", func() {
 
 			logsErrors = []error{nil, nil, nil}
 
-			command := commands.NewDeleteInstallation(fakeService, writer, logger, 1)
+			command := commands.NewDeleteInstallation(fakeService, writer, logger, results, 1)
 
 			err := command.Execute([]string{})
 			Expect(err).NotTo(HaveOccurred())
@@ -92,6 +94,12 @@ var _ = Describe("DeleteInstallation", func() {
 			Expect(writer.FlushArgsForCall(0)).To(Equal("start of logs"))
 			Expect(writer.FlushArgsForCall(1)).To(Equal("these logs"))
 			Expect(writer.FlushArgsForCall(2)).To(Equal("some other logs"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.DeleteInstallationResult{
+				InstallationID: 311,
+				Status:         "succeeded",
+			}))
 		})
 
 		It("handles a failed installation", func() {
@@ -112,10 +120,16 @@ var _ = Describe("DeleteInstallation", func() {
 
 			logsErrors = []error{nil}
 
-			command := commands.NewDeleteInstallation(fakeService, writer, logger, 1)
+			command := commands.NewDeleteInstallation(fakeService, writer, logger, results, 1)
 
 			err := command.Execute([]string{})
 			Expect(err).To(MatchError("deleting the installation was unsuccessful"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.DeleteInstallationResult{
+				InstallationID: 311,
+				Status:         "failed",
+			}))
 		})
 
 		It("handles the case when there is no installation to delete", func() {
@@ -124,7 +138,7 @@ var _ = Describe("DeleteInstallation", func() {
 				return output, nil
 			}
 
-			command := commands.NewDeleteInstallation(fakeService, writer, logger, 1)
+			command := commands.NewDeleteInstallation(fakeService, writer, logger, results, 1)
 
 			err := command.Execute([]string{})
 			Expect(err).NotTo(HaveOccurred())
@@ -133,6 +147,10 @@ var _ = Describe("DeleteInstallation", func() {
 			Expect(fmt.Sprintf(format, content...)).To(Equal("attempting to delete the installation on the targeted Ops Manager"))
 			format, content = logger.PrintfArgsForCall(1)
 			Expect(fmt.Sprintf(format, content...)).To(Equal("no installation to delete"))
+
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.DeleteInstallationResult{
+				NothingToDelete: true,
+			}))
 		})
 
 		Context("when an installation is already running", func() {
@@ -155,7 +173,7 @@ var _ = Describe("DeleteInstallation", func() {
 
 				logsErrors = []error{nil, nil, nil}
 
-				command := commands.NewDeleteInstallation(fakeService, writer, logger, 1)
+				command := commands.NewDeleteInstallation(fakeService, writer, logger, results, 1)
 
 				err := command.Execute([]string{})
 				Expect(err).NotTo(HaveOccurred())
@@ -175,7 +193,7 @@ var _ = Describe("DeleteInstallation", func() {
 				It("returns an error", func() {
 					fakeService.DeleteInstallationAssetCollectionReturns(api.InstallationsServiceOutput{}, errors.New("some error"))
 
-					command := commands.NewDeleteInstallation(fakeService, writer, logger, 1)
+					command := commands.NewDeleteInstallation(fakeService, writer, logger, results, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("failed to delete installation: some error"))
@@ -190,7 +208,7 @@ var _ = Describe("DeleteInstallation", func() {
 
 					statusErrors = []error{errors.New("another error")}
 
-					command := commands.NewDeleteInstallation(fakeService, writer, logger, 1)
+					command := commands.NewDeleteInstallation(fakeService, writer, logger, results, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to get status: another error"))
@@ -211,7 +229,7 @@ var _ = Describe("DeleteInstallation", func() {
 
 					logsErrors = []error{errors.New("no")}
 
-					command := commands.NewDeleteInstallation(fakeService, writer, logger, 1)
+					command := commands.NewDeleteInstallation(fakeService, writer, logger, results, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to get logs: no"))
@@ -234,7 +252,7 @@ var _ = Describe("DeleteInstallation", func() {
 
 					writer.FlushReturns(errors.New("yes"))
 
-					command := commands.NewDeleteInstallation(fakeService, writer, logger, 1)
+					command := commands.NewDeleteInstallation(fakeService, writer, logger, results, 1)
 
 					err := command.Execute([]string{})
 					Expect(err).To(MatchError("installation failed to flush logs: yes"))
@@ -245,7 +263,7 @@ var _ = Describe("DeleteInstallation", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewDeleteInstallation(nil, nil, nil, 1)
+			command := commands.NewDeleteInstallation(nil, nil, nil, nil, 1)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This authenticated command deletes all the products installed on the targeted Ops Manager.",
 				ShortDescription: "deletes all the products on the Ops Manager targeted",
@@ -1,14 +1,13 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 )
 
 type DeleteProduct struct {
 	service deleteProductService
+	results resultWriter
 	Options struct {
 		Product string `long:"product-name"    short:"p" required:"true" description:"name of product"`
 		Version string `long:"product-version" short:"v" required:"true" description:"version of product"`
@@ -20,15 +19,16 @@ type deleteProductService interface {
 	DeleteAvailableProducts(input api.DeleteAvailableProductsInput) error
 }
 
-func NewDeleteProduct(service deleteProductService) DeleteProduct {
+func NewDeleteProduct(service deleteProductService, results resultWriter) DeleteProduct {
 	return DeleteProduct{
 		service: service,
+		results: results,
 	}
 }
 
 func (dp DeleteProduct) Execute(args []string) error {
 	if _, err := jhanda.Parse(&dp.Options, args); err != nil {
-		return fmt.Errorf("could not parse delete-product flags: %s", err)
+		return configErrorf("could not parse delete-product flags: %w", err)
 	}
 
 	err := dp.service.DeleteAvailableProducts(api.DeleteAvailableProductsInput{
@@ -40,7 +40,10 @@ func (dp DeleteProduct) Execute(args []string) error {
 		return err
 	}
 
-	return nil
+	return dp.results.WriteResult(DeleteProductResult{
+		ProductName:    dp.Options.Product,
+		ProductVersion: dp.Options.Version,
+	})
 }
 
 func (dp DeleteProduct) Usage() jhanda.Usage {
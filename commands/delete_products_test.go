@@ -16,11 +16,13 @@ var _ = Describe("DeleteProduct", func() {
 	var (
 		command     commands.DeleteProduct
 		fakeService *fakes.DeleteProductService
+		results     *fakes.ResultWriter
 	)
 
 	BeforeEach(func() {
 		fakeService = &fakes.DeleteProductService{}
-		command = commands.NewDeleteProduct(fakeService)
+		results = &fakes.ResultWriter{}
+		command = commands.NewDeleteProduct(fakeService, results)
 	})
 
 	Describe("Execute", func() {
@@ -36,6 +38,12 @@ var _ = Describe("DeleteProduct", func() {
 				ProductVersion:          "1.2.3-build.4",
 				ShouldDeleteAllProducts: false,
 			}))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.DeleteProductResult{
+				ProductName:    "some-product-name",
+				ProductVersion: "1.2.3-build.4",
+			}))
 		})
 
 		Context("failure cases", func() {
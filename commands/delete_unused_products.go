@@ -8,6 +8,7 @@ import (
 type DeleteUnusedProducts struct {
 	service deleteUnusedProductsService
 	logger  logger
+	results resultWriter
 }
 
 //go:generate counterfeiter -o ./fakes/delete_unused_products_service.go --fake-name DeleteUnusedProductsService . deleteUnusedProductsService
@@ -15,10 +16,11 @@ type deleteUnusedProductsService interface {
 	DeleteAvailableProducts(input api.DeleteAvailableProductsInput) error
 }
 
-func NewDeleteUnusedProducts(service deleteUnusedProductsService, logger logger) DeleteUnusedProducts {
+func NewDeleteUnusedProducts(service deleteUnusedProductsService, logger logger, results resultWriter) DeleteUnusedProducts {
 	return DeleteUnusedProducts{
 		service: service,
 		logger:  logger,
+		results: results,
 	}
 }
 
@@ -34,7 +36,7 @@ func (dup DeleteUnusedProducts) Execute(args []string) error {
 
 	dup.logger.Printf("done")
 
-	return nil
+	return dup.results.WriteResult(DeleteUnusedProductsResult{Deleted: true})
 }
 
 func (dup DeleteUnusedProducts) Usage() jhanda.Usage {
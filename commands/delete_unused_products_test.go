@@ -18,12 +18,14 @@ var _ = Describe("DeleteProduct", func() {
 		command     commands.DeleteUnusedProducts
 		fakeService *fakes.DeleteUnusedProductsService
 		logger      *fakes.Logger
+		results     *fakes.ResultWriter
 	)
 
 	BeforeEach(func() {
 		fakeService = &fakes.DeleteUnusedProductsService{}
 		logger = &fakes.Logger{}
-		command = commands.NewDeleteUnusedProducts(fakeService, logger)
+		results = &fakes.ResultWriter{}
+		command = commands.NewDeleteUnusedProducts(fakeService, logger, results)
 	})
 
 	Describe("Execute", func() {
@@ -43,6 +45,11 @@ var _ = Describe("DeleteProduct", func() {
 
 			format, content = logger.PrintfArgsForCall(1)
 			Expect(fmt.Sprintf(format, content...)).To(Equal("done"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.DeleteUnusedProductsResult{
+				Deleted: true,
+			}))
 		})
 	})
 
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
@@ -31,7 +30,7 @@ func NewDeployedManifest(service deployedManifestService, logger logger) Deploye
 
 func (dm DeployedManifest) Execute(args []string) error {
 	if _, err := jhanda.Parse(&dm.Options, args); err != nil {
-		return fmt.Errorf("could not parse staged-manifest flags: %s", err)
+		return configErrorf("could not parse staged-manifest flags: %w", err)
 	}
 
 	output, err := dm.service.ListDeployedProducts()
@@ -12,7 +12,7 @@ type DeployedProducts struct {
 	presenter presenters.FormattedPresenter
 	service   deployedProductsService
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -30,20 +30,22 @@ func NewDeployedProducts(presenter presenters.FormattedPresenter, service deploy
 
 func (dp DeployedProducts) Execute(args []string) error {
 	if _, err := jhanda.Parse(&dp.Options, args); err != nil {
-		return fmt.Errorf("could not parse deployed-products flags: %s", err)
+		return configErrorf("could not parse deployed-products flags: %w", err)
 	}
 
 	diagnosticReport, err := dp.service.GetDiagnosticReport()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve deployed products %s", err)
+		return fmt.Errorf("failed to retrieve deployed products %w", err)
 	}
 
 	deployedProducts := diagnosticReport.DeployedProducts
 
-	dp.presenter.SetFormat(dp.Options.Format)
-	dp.presenter.PresentDeployedProducts(deployedProducts)
+	err = dp.presenter.SetFormat(dp.Options.Format)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return dp.presenter.PresentDeployedProducts(deployedProducts)
 }
 
 func (dp DeployedProducts) Usage() jhanda.Usage {
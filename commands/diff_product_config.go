@@ -0,0 +1,514 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/kiln/proofing"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/config"
+	"github.com/pivotal-cf/om/configparser"
+	"gopkg.in/yaml.v2"
+)
+
+const maskedValue = "***"
+
+// credentialPropertyTypes are the property blueprint types whose values are
+// credentials.
+var credentialPropertyTypes = map[string]bool{
+	"secret":               true,
+	"simple_credentials":   true,
+	"rsa_cert_credentials": true,
+	"rsa_pkey_credentials": true,
+	"salted_credentials":   true,
+}
+
+type DiffProductConfig struct {
+	environFunc       func() []string
+	varsStore         boshtpl.Variables
+	service           diffProductConfigService
+	metadataExtractor metadataExtractor
+	logger            logger
+	// blueprints are the property blueprints of --product-file, by property
+	// name
+	blueprints map[string]lintProperty
+	Options    struct {
+		ConfigFile  string   `long:"config"       short:"c" description:"path to yml file containing all config fields (see docs/configure-product/README.md for format)" required:"true"`
+		VarsFile    []string `long:"vars-file"    short:"l" description:"Load variables from a YAML file"`
+		VarsEnv     []string `long:"vars-env"               description:"Load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
+		OpsFile     []string `long:"ops-file"     short:"o" description:"YAML operations file"`
+		ProductFile string   `long:"product-file"           description:"path to the product file (.pivotal), to find the credential fields of collections from its property blueprints"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/diff_product_config_service.go --fake-name DiffProductConfigService . diffProductConfigService
+type diffProductConfigService interface {
+	GetStagedProductByName(product string) (api.StagedProductsFindOutput, error)
+	GetStagedProductJobResourceConfig(productGUID, jobGUID string) (api.JobProperties, error)
+	GetStagedProductNetworksAndAZs(product string) (map[string]interface{}, error)
+	GetStagedProductProperties(product string) (map[string]api.ResponseProperty, error)
+	ListStagedProductJobs(productGUID string) (map[string]string, error)
+	ListStagedProductErrands(productID string) (api.ErrandsListOutput, error)
+}
+
+type configSectionDifferences struct {
+	name        string
+	differences []configDifference
+}
+
+type configDifference struct {
+	path       string
+	current    interface{}
+	desired    interface{}
+	added      bool
+	credential bool
+}
+
+func NewDiffProductConfig(environFunc func() []string, varsStore boshtpl.Variables, service diffProductConfigService, metadataExtractor metadataExtractor, logger logger) DiffProductConfig {
+	return DiffProductConfig{
+		environFunc:       environFunc,
+		varsStore:         varsStore,
+		service:           service,
+		metadataExtractor: metadataExtractor,
+		logger:            logger,
+	}
+}
+
+func (dpc DiffProductConfig) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command compares a configure-product config file against the staged product and prints the settings that would change (Note: credentials are never compared and will appear as '***')",
+		ShortDescription: "**EXPERIMENTAL** compares a product config file against the staged product",
+		Flags:            dpc.Options,
+	}
+}
+
+func (dpc DiffProductConfig) Execute(args []string) error {
+	if _, err := jhanda.Parse(&dpc.Options, args); err != nil {
+		return configErrorf("could not parse diff-product-config flags: %w", err)
+	}
+
+	cfg, err := interpolateProductConfig(interpolateOptions{
+		templateFile: dpc.Options.ConfigFile,
+		varsFiles:    dpc.Options.VarsFile,
+		environFunc:  dpc.environFunc,
+		varsStore:    dpc.varsStore,
+		varsEnvs:     dpc.Options.VarsEnv,
+		opsFiles:     dpc.Options.OpsFile,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = validateProductConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	if dpc.Options.ProductFile != "" {
+		metadata, err := dpc.metadataExtractor.ExtractMetadata(dpc.Options.ProductFile)
+		if err != nil {
+			return fmt.Errorf("could not extract metadata: %w", err)
+		}
+
+		var template proofing.ProductTemplate
+		err = yaml.Unmarshal(metadata.Raw, &template)
+		if err != nil {
+			return fmt.Errorf("could not parse metadata: %w", err)
+		}
+
+		dpc.blueprints = lintProperties(template)
+	}
+
+	sections, err := dpc.findDifferences(cfg)
+	if err != nil {
+		return err
+	}
+
+	var changed bool
+	for _, section := range sections {
+		if len(section.differences) == 0 {
+			continue
+		}
+		changed = true
+
+		dpc.logger.Printf("%s:", section.name)
+		for _, difference := range section.differences {
+			dpc.logger.Printf("  %s", difference)
+		}
+	}
+
+	if !changed {
+		dpc.logger.Printf("no differences found between %s and the staged product %q", dpc.Options.ConfigFile, cfg.ProductName)
+	}
+
+	return nil
+}
+
+func (dpc DiffProductConfig) findDifferences(cfg config.ProductConfiguration) ([]configSectionDifferences, error) {
+	findOutput, err := dpc.service.GetStagedProductByName(cfg.ProductName)
+	if err != nil {
+		return nil, err
+	}
+	productGUID := findOutput.Product.GUID
+
+	propertyDifferences, err := dpc.diffProperties(cfg, productGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	networkDifferences, err := dpc.diffNetwork(cfg, productGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceDifferences, err := dpc.diffResources(cfg, productGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	errandDifferences, err := dpc.diffErrands(cfg, productGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return []configSectionDifferences{
+		{"product-properties", propertyDifferences},
+		{"network-properties", networkDifferences},
+		{"resource-config", resourceDifferences},
+		{"errand-config", errandDifferences},
+	}, nil
+}
+
+func (dpc DiffProductConfig) diffProperties(cfg config.ProductConfiguration, productGUID string) ([]configDifference, error) {
+	if cfg.ProductProperties == nil {
+		return nil, nil
+	}
+
+	properties, err := dpc.service.GetStagedProductProperties(productGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var differences []configDifference
+	for _, name := range sortedKeys(cfg.ProductProperties) {
+		desired, err := normalizeConfigValue(cfg.ProductProperties[name])
+		if err != nil {
+			return nil, err
+		}
+
+		property, ok := properties[name]
+		if !ok {
+			return nil, fmt.Errorf("product %q does not contain a property named %q", cfg.ProductName, name)
+		}
+
+		if property.IsCredential {
+			differences = append(differences, configDifference{path: name, credential: true})
+			continue
+		}
+
+		var current interface{} = map[string]interface{}{"value": property.Value}
+		if property.Type == "collection" && property.Value != nil {
+			current, err = configparser.NewConfigParser().ParseProperties(configparser.NewPropertyName(name), property, configparser.NilHandler())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		current, err = normalizeConfigValue(current)
+		if err != nil {
+			return nil, err
+		}
+
+		blueprint, hasBlueprint := dpc.blueprints[name]
+		credentialKeys := collectionCredentialKeys(property, blueprint, hasBlueprint)
+		for _, difference := range diffConfigValues(name, current, desired) {
+			difference.current = maskCollectionCredentials(difference.current, credentialKeys)
+			difference.desired = maskCollectionCredentials(difference.desired, credentialKeys)
+			differences = append(differences, difference)
+		}
+	}
+
+	return differences, nil
+}
+
+func (dpc DiffProductConfig) diffNetwork(cfg config.ProductConfiguration, productGUID string) ([]configDifference, error) {
+	if cfg.NetworkProperties == nil {
+		return nil, nil
+	}
+
+	networks, err := dpc.service.GetStagedProductNetworksAndAZs(productGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := normalizeConfigValue(networks)
+	if err != nil {
+		return nil, err
+	}
+
+	desired, err := normalizeConfigValue(cfg.NetworkProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffConfigValues("", current, desired), nil
+}
+
+func (dpc DiffProductConfig) diffResources(cfg config.ProductConfiguration, productGUID string) ([]configDifference, error) {
+	if cfg.ResourceConfigProperties == nil {
+		return nil, nil
+	}
+
+	jobs, err := dpc.service.ListStagedProductJobs(productGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jobs: %w", err)
+	}
+
+	var differences []configDifference
+	for _, name := range sortedKeys(cfg.ResourceConfigProperties) {
+		jobGUID, ok := jobs[name]
+		if !ok {
+			return nil, fmt.Errorf("product %q does not contain a job named %q", cfg.ProductName, name)
+		}
+
+		jobProperties, err := dpc.service.GetStagedProductJobResourceConfig(productGUID, jobGUID)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch existing job configuration for %q: %w", name, err)
+		}
+
+		current, err := normalizeConfigValue(jobProperties)
+		if err != nil {
+			return nil, err
+		}
+
+		desired, err := normalizeConfigValue(cfg.ResourceConfigProperties[name])
+		if err != nil {
+			return nil, err
+		}
+
+		differences = append(differences, diffConfigValues(name, current, desired)...)
+	}
+
+	return differences, nil
+}
+
+func (dpc DiffProductConfig) diffErrands(cfg config.ProductConfiguration, productGUID string) ([]configDifference, error) {
+	if len(cfg.ErrandConfigs) == 0 {
+		return nil, nil
+	}
+
+	errandsListOutput, err := dpc.service.ListStagedProductErrands(productGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	stagedErrands := map[string]api.Errand{}
+	for _, errand := range errandsListOutput.Errands {
+		stagedErrands[errand.Name] = errand
+	}
+
+	var names []string
+	for name := range cfg.ErrandConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var differences []configDifference
+	for _, name := range names {
+		errand, ok := stagedErrands[name]
+		if !ok {
+			return nil, fmt.Errorf("product %q does not contain an errand named %q", cfg.ProductName, name)
+		}
+
+		current, err := normalizeConfigValue(config.ErrandConfig{
+			PostDeployState: errand.PostDeploy,
+			PreDeleteState:  errand.PreDelete,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		desired, err := normalizeConfigValue(cfg.ErrandConfigs[name])
+		if err != nil {
+			return nil, err
+		}
+
+		differences = append(differences, diffConfigValues(name, current, desired)...)
+	}
+
+	return differences, nil
+}
+
+// diffConfigValues only walks the keys present in desired, because
+// configure-product leaves any setting it is not given untouched.
+func diffConfigValues(path string, current, desired interface{}) []configDifference {
+	desiredMap, desiredIsMap := desired.(map[string]interface{})
+	currentMap, currentIsMap := current.(map[string]interface{})
+
+	if desiredIsMap && (currentIsMap || current == nil) {
+		var differences []configDifference
+		for _, key := range sortedKeys(desiredMap) {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+
+			currentValue, ok := currentMap[key]
+			if !ok {
+				differences = append(differences, configDifference{path: childPath, desired: desiredMap[key], added: true})
+				continue
+			}
+
+			differences = append(differences, diffConfigValues(childPath, currentValue, desiredMap[key])...)
+		}
+		return differences
+	}
+
+	if reflect.DeepEqual(current, desired) {
+		return nil
+	}
+
+	return []configDifference{{path: path, current: current, desired: desired}}
+}
+
+func (d configDifference) String() string {
+	if d.credential {
+		return fmt.Sprintf("* %s: %s (credentials are not compared)", d.path, maskedValue)
+	}
+
+	if d.added {
+		return fmt.Sprintf("+ %s: %s", d.path, renderConfigValue(d.desired))
+	}
+
+	return fmt.Sprintf("~ %s: %s -> %s", d.path, renderConfigValue(d.current), renderConfigValue(d.desired))
+}
+
+// normalizeConfigValue round-trips a value through JSON so values decoded
+// from YAML and from the API compare equal.
+func normalizeConfigValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	contents, err := getJSONProperties(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized interface{}
+	err = json.Unmarshal([]byte(contents), &normalized)
+	if err != nil {
+		return nil, fmt.Errorf("could not normalize config value: %w", err)
+	}
+
+	return normalized, nil
+}
+
+func renderConfigValue(value interface{}) string {
+	contents, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value) // un-tested
+	}
+
+	return string(contents)
+}
+
+// collectionCredentials are the fields of the items of a collection property
+// that are credentials.
+type collectionCredentials struct {
+	keys map[string]bool
+	// all is set when the fields of the collection are unknown, so that
+	// every field is treated as a credential
+	all bool
+}
+
+func (c collectionCredentials) contains(key string) bool {
+	return c.all || c.keys[key]
+}
+
+// collectionCredentialKeys reads the credential fields of a collection from
+// its property blueprint in --product-file. Without one, they are read from
+// the staged items, and when there are none, every field is treated as a
+// credential so that no secret of the desired items is printed.
+func collectionCredentialKeys(property api.ResponseProperty, blueprint lintProperty, hasBlueprint bool) collectionCredentials {
+	credentials := collectionCredentials{keys: map[string]bool{}}
+	if property.Type != "collection" {
+		return credentials
+	}
+
+	if hasBlueprint {
+		for _, field := range blueprint.collection {
+			if credentialPropertyTypes[field.Type] {
+				credentials.keys[field.Name] = true
+			}
+		}
+		return credentials
+	}
+
+	items, ok := property.Value.([]interface{})
+	if !ok || len(items) == 0 {
+		credentials.all = true
+		return credentials
+	}
+
+	for _, item := range items {
+		fields, ok := item.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		for key, field := range fields {
+			attributes, ok := field.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+
+			if isCredential, _ := attributes["credential"].(bool); isCredential {
+				credentials.keys[fmt.Sprintf("%v", key)] = true
+			}
+		}
+	}
+
+	return credentials
+}
+
+func maskCollectionCredentials(value interface{}, credentials collectionCredentials) interface{} {
+	if !credentials.all && len(credentials.keys) == 0 {
+		return value
+	}
+
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		masked := map[string]interface{}{}
+		for key, innerValue := range typedValue {
+			if credentials.contains(key) {
+				masked[key] = maskedValue
+				continue
+			}
+			masked[key] = maskCollectionCredentials(innerValue, credentials)
+		}
+		return masked
+	case []interface{}:
+		var masked []interface{}
+		for _, innerValue := range typedValue {
+			masked = append(masked, maskCollectionCredentials(innerValue, credentials))
+		}
+		return masked
+	}
+
+	return value
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
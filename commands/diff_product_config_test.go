@@ -0,0 +1,388 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffProductConfig", func() {
+	var (
+		logger      *fakes.Logger
+		fakeService *fakes.DiffProductConfigService
+		configFile  *os.File
+		config      string
+	)
+
+	loggedLines := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+
+		fakeService = &fakes.DiffProductConfigService{}
+		fakeService.GetStagedProductByNameReturns(api.StagedProductsFindOutput{
+			Product: api.StagedProduct{GUID: "some-product-guid", Type: "cf"},
+		}, nil)
+		fakeService.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+			".properties.unchanged": {
+				Type:         "string",
+				Value:        "same",
+				Configurable: true,
+			},
+			".properties.changed": {
+				Type:         "integer",
+				Value:        1,
+				Configurable: true,
+			},
+			".properties.secret": {
+				Type:         "secret",
+				Value:        map[interface{}]interface{}{"secret": "***"},
+				IsCredential: true,
+				Configurable: true,
+			},
+			".properties.collection": {
+				Type: "collection",
+				Value: []interface{}{
+					map[interface{}]interface{}{
+						"name": map[interface{}]interface{}{
+							"type":         "string",
+							"configurable": true,
+							"credential":   false,
+							"value":        "first",
+						},
+						"password": map[interface{}]interface{}{
+							"type":         "secret",
+							"configurable": true,
+							"credential":   true,
+							"value":        map[interface{}]interface{}{"secret": "***"},
+						},
+					},
+				},
+				Configurable: true,
+			},
+		}, nil)
+		fakeService.GetStagedProductNetworksAndAZsReturns(map[string]interface{}{
+			"network": map[string]interface{}{"name": "old-network"},
+			"singleton_availability_zone": map[string]interface{}{
+				"name": "az-one",
+			},
+		}, nil)
+		fakeService.ListStagedProductJobsReturns(map[string]string{
+			"some-job": "some-job-guid",
+		}, nil)
+		fakeService.GetStagedProductJobResourceConfigReturns(api.JobProperties{
+			Instances:    1,
+			InstanceType: api.InstanceType{ID: "automatic"},
+		}, nil)
+		fakeService.ListStagedProductErrandsReturns(api.ErrandsListOutput{
+			Errands: []api.Errand{
+				{Name: "smoke-tests", PostDeploy: false},
+			},
+		}, nil)
+
+		config = `---
+product-name: cf
+product-properties:
+  .properties.unchanged:
+    value: same
+  .properties.changed:
+    value: 2
+  .properties.secret:
+    value:
+      secret: super-secret
+  .properties.collection:
+    value:
+    - name: second
+      password:
+        secret: another-secret
+network-properties:
+  network:
+    name: new-network
+  singleton_availability_zone:
+    name: az-one
+resource-config:
+  some-job:
+    instances: 3
+    persistent_disk:
+      size_mb: "20480"
+errand-config:
+  smoke-tests:
+    post-deploy-state: true
+`
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		configFile, err = ioutil.TempFile("", "config.yml")
+		Expect(err).NotTo(HaveOccurred())
+		defer configFile.Close()
+
+		_, err = configFile.WriteString(config)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Remove(configFile.Name())
+	})
+
+	Describe("Execute", func() {
+		It("prints the differences between the config file and the staged product", func() {
+			command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+			err := command.Execute([]string{
+				"--config", configFile.Name(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.GetStagedProductByNameArgsForCall(0)).To(Equal("cf"))
+			Expect(fakeService.GetStagedProductPropertiesArgsForCall(0)).To(Equal("some-product-guid"))
+			Expect(fakeService.GetStagedProductNetworksAndAZsArgsForCall(0)).To(Equal("some-product-guid"))
+			productGUID, jobGUID := fakeService.GetStagedProductJobResourceConfigArgsForCall(0)
+			Expect(productGUID).To(Equal("some-product-guid"))
+			Expect(jobGUID).To(Equal("some-job-guid"))
+			Expect(fakeService.ListStagedProductErrandsArgsForCall(0)).To(Equal("some-product-guid"))
+
+			Expect(loggedLines()).To(Equal([]string{
+				"product-properties:",
+				`  ~ .properties.changed.value: 1 -> 2`,
+				`  ~ .properties.collection.value: [{"name":"first"}] -> [{"name":"second","password":"***"}]`,
+				"  * .properties.secret: *** (credentials are not compared)",
+				"network-properties:",
+				`  ~ network.name: "old-network" -> "new-network"`,
+				"resource-config:",
+				"  ~ some-job.instances: 1 -> 3",
+				`  + some-job.persistent_disk: {"size_mb":"20480"}`,
+				"errand-config:",
+				"  ~ smoke-tests.post-deploy-state: false -> true",
+			}))
+		})
+
+		Context("when the staged collection is empty", func() {
+			BeforeEach(func() {
+				fakeService.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+					".properties.collection": {
+						Type:         "collection",
+						Value:        nil,
+						Configurable: true,
+					},
+				}, nil)
+
+				config = `---
+product-name: cf
+product-properties:
+  .properties.collection:
+    value:
+    - name: second
+      password:
+        secret: another-secret
+`
+			})
+
+			It("masks every field of the collection", func() {
+				command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(loggedLines()).To(ContainElement(`  ~ .properties.collection.value: null -> [{"name":"***","password":"***"}]`))
+			})
+
+			It("masks the credential fields of the property blueprint in --product-file", func() {
+				metadataExtractor := &fakes.MetadataExtractor{}
+				metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+					Name:    "cf",
+					Version: "2.3.0",
+					Raw: []byte(`---
+name: cf
+product_version: 2.3.0
+property_blueprints:
+- name: collection
+  type: collection
+  configurable: true
+  property_blueprints:
+  - name: name
+    type: string
+  - name: password
+    type: secret
+`),
+				}, nil)
+
+				command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, metadataExtractor, logger)
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--product-file", "/path/to/cf.pivotal",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(metadataExtractor.ExtractMetadataArgsForCall(0)).To(Equal("/path/to/cf.pivotal"))
+				Expect(loggedLines()).To(ContainElement(`  ~ .properties.collection.value: null -> [{"name":"second","password":"***"}]`))
+			})
+		})
+
+		Context("when the config matches the staged product", func() {
+			BeforeEach(func() {
+				config = `---
+product-name: cf
+product-properties:
+  .properties.unchanged:
+    value: same
+errand-config:
+  smoke-tests:
+    post-deploy-state: false
+`
+			})
+
+			It("reports that nothing would change", func() {
+				command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeService.GetStagedProductNetworksAndAZsCallCount()).To(Equal(0))
+				Expect(fakeService.ListStagedProductJobsCallCount()).To(Equal(0))
+				Expect(loggedLines()).To(Equal([]string{
+					fmt.Sprintf(`no differences found between %s and the staged product "cf"`, configFile.Name()),
+				}))
+			})
+		})
+
+		Context("when the config file contains variables", func() {
+			BeforeEach(func() {
+				config = `---
+product-name: cf
+product-properties:
+  .properties.changed:
+    value: ((count))
+`
+			})
+
+			It("interpolates them before comparing", func() {
+				command := commands.NewDiffProductConfig(func() []string { return []string{"OM_VAR_count=1"} }, nil, fakeService, nil, logger)
+				err := command.Execute([]string{
+					"--config", configFile.Name(),
+					"--vars-env", "OM_VAR",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(loggedLines()).To(HaveLen(1))
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is provided", func() {
+				It("returns an error", func() {
+					command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+					err := command.Execute([]string{"--badflag"})
+					Expect(err).To(MatchError("could not parse diff-product-config flags: flag provided but not defined: -badflag"))
+				})
+			})
+
+			Context("when the product name is missing from the config", func() {
+				BeforeEach(func() {
+					config = `product-properties: {}`
+				})
+
+				It("returns an error", func() {
+					command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(`could not parse configure-product config: "product-name" is required`))
+				})
+			})
+
+			Context("when the product is not staged", func() {
+				It("returns an error", func() {
+					fakeService.GetStagedProductByNameReturns(api.StagedProductsFindOutput{}, errors.New("could not find product"))
+
+					command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("could not find product"))
+				})
+			})
+
+			Context("when the config contains an unknown property", func() {
+				BeforeEach(func() {
+					config = `{"product-name": "cf", "product-properties": {".properties.missing": {"value": 1}}}`
+				})
+
+				It("returns an error", func() {
+					command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(`product "cf" does not contain a property named ".properties.missing"`))
+				})
+			})
+
+			Context("when the config contains an unknown job", func() {
+				BeforeEach(func() {
+					config = `{"product-name": "cf", "resource-config": {"missing-job": {"instances": 1}}}`
+				})
+
+				It("returns an error", func() {
+					command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(`product "cf" does not contain a job named "missing-job"`))
+				})
+			})
+
+			Context("when the config contains an unknown errand", func() {
+				BeforeEach(func() {
+					config = `{"product-name": "cf", "errand-config": {"missing-errand": {"post-deploy-state": true}}}`
+				})
+
+				It("returns an error", func() {
+					command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(`product "cf" does not contain an errand named "missing-errand"`))
+				})
+			})
+
+			Context("when fetching the staged properties fails", func() {
+				It("returns an error", func() {
+					fakeService.GetStagedProductPropertiesReturns(nil, errors.New("some-error"))
+
+					command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when listing jobs fails", func() {
+				It("returns an error", func() {
+					fakeService.ListStagedProductJobsReturns(nil, errors.New("some-error"))
+
+					command := commands.NewDiffProductConfig(func() []string { return nil }, nil, fakeService, nil, logger)
+					err := command.Execute([]string{"--config", configFile.Name()})
+					Expect(err).To(MatchError("failed to fetch jobs: some-error"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			command := commands.NewDiffProductConfig(nil, nil, nil, nil, nil)
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command compares a configure-product config file against the staged product and prints the settings that would change (Note: credentials are never compared and will appear as '***')",
+				ShortDescription: "**EXPERIMENTAL** compares a product config file against the staged product",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -2,16 +2,20 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/go-pivnet"
 	pivnetlog "github.com/pivotal-cf/go-pivnet/logger"
 	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/download"
 	"github.com/pivotal-cf/om/validator"
+	"github.com/pivotal-cf/om/versions"
 	"github.com/pivotal-cf/pivnet-cli/filter"
-	"github.com/pivotal-cf/pivnet-cli/gp"
 	"io"
 	"os"
 	"path"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -19,12 +23,14 @@ import (
 const DownloadListFilename = "download-file.json"
 
 type downloadList struct {
-	Product  string `json:"product,omitempty"`
-	Stemcell string `json:"stemcell,omitempty"`
+	Product        string `json:"product,omitempty"`
+	ProductVersion string `json:"product_version,omitempty"`
+	Stemcell       string `json:"stemcell,omitempty"`
 }
 
 //go:generate counterfeiter -o ./fakes/pivnet_downloader_service.go --fake-name PivnetDownloader . PivnetDownloader
 type PivnetDownloader interface {
+	ReleasesForProductSlug(productSlug string) ([]pivnet.Release, error)
 	ReleaseForVersion(productSlug string, releaseVersion string) (pivnet.Release, error)
 	ProductFilesForRelease(productSlug string, releaseID int) ([]pivnet.ProductFile, error)
 	DownloadProductFile(location *os.File, productSlug string, releaseID int, productFileID int, progressWriter io.Writer) error
@@ -34,35 +40,52 @@ type PivnetDownloader interface {
 type PivnetFactory func(config pivnet.ClientConfig, logger pivnetlog.Logger) PivnetDownloader
 
 func DefaultPivnetFactory(config pivnet.ClientConfig, logger pivnetlog.Logger) PivnetDownloader {
-	return gp.NewClient(config, logger)
+	return download.NewPivnetClient(config, logger)
 }
 
 type DownloadProduct struct {
 	environFunc    func() []string
+	varsStore      boshtpl.Variables
 	logger         pivnetlog.Logger
 	progressWriter io.Writer
+	results        resultWriter
 	pivnetFactory  PivnetFactory
 	client         PivnetDownloader
 	filter         *filter.Filter
+	cache          *download.Cache
+	s3Client       *download.S3Client
 	Options        struct {
 		ConfigFile     string   `long:"config"               short:"c"   description:"path to yml file for configuration (keys must match the following command line flags)"`
 		VarsFile       []string `long:"vars-file"            short:"l"   description:"Load variables from a YAML file"`
 		VarsEnv        []string `long:"vars-env"                         description:"Load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
-		Token          string   `long:"pivnet-api-token"                  required:"true"`
+		Source         string   `long:"source"                           description:"where to download the product file from (pivnet, s3)" default:"pivnet"`
+		Token          string   `long:"pivnet-api-token"                 description:"API token for Pivotal Network, required for the pivnet source"`
 		FileGlob       string   `long:"pivnet-file-glob"     short:"f"   description:"Glob to match files within Pivotal Network product to be downloaded." required:"true"`
 		ProductSlug    string   `long:"pivnet-product-slug"  short:"p"   description:"Path to product" required:"true"`
-		ProductVersion string   `long:"product-version"                  description:"version of the provided product file to be used for validation" required:"true"`
+		ProductVersion string   `long:"product-version"                  description:"version of the product to download, either exact or a constraint such as '~2.3' or '>=2.2.5 <2.3' that selects the highest matching release"`
+		VersionRegex   string   `long:"product-version-regex"            description:"regular expression that selects the highest matching release of the product to download"`
 		OutputDir      string   `long:"output-directory"     short:"o"   description:"Directory path to which the file will be outputted. File name will be preserved from Pivotal Network" required:"true"`
 		Stemcell       bool     `long:"download-stemcell"                description:"If set, the latest available stemcell for the product will also be downloaded"`
 		StemcellIaas   string   `long:"stemcell-iaas"                    description:"The stemcell for the specified iaas. for example 'vsphere' or 'vcloud' or 'openstack' or 'google' or 'azure' or 'aws'"`
+		CacheDir       string   `long:"cache-dir"                        description:"Directory of previously downloaded files. Files found in it are linked into the output directory instead of downloaded, and downloaded files are added to it"`
+		S3Bucket       string   `long:"s3-bucket"                        description:"bucket name where the product resides in the s3 compatible blobstore, required for the s3 source"`
+		S3Endpoint     string   `long:"s3-endpoint"                      description:"the endpoint to access the s3 compatible blobstore. If not using AWS, this is required"`
+		S3RegionName   string   `long:"s3-region-name"                   description:"bucket region in the s3 compatible blobstore" default:"us-east-1"`
+		S3AccessKeyID  string   `long:"s3-access-key-id"                 description:"access key for the s3 compatible blobstore. If not set, requests are anonymous"`
+		S3SecretKey    string   `long:"s3-secret-access-key"             description:"secret key for the s3 compatible blobstore"`
+		S3Path         string   `long:"s3-path"                          description:"folder in the bucket where files are stored as '[product-slug,product-version]file-name'"`
+		S3CACert       string   `long:"s3-ca-cert"                       description:"path to or contents of a CA certificate to trust for the s3 compatible blobstore, e.g. for an on-premise MinIO"`
+		S3SkipSSL      bool     `long:"s3-skip-ssl-validation"           description:"skip ssl certificate validation of the s3 compatible blobstore"`
 	}
 }
 
-func NewDownloadProduct(environFunc func() []string, logger pivnetlog.Logger, progressWriter io.Writer, factory PivnetFactory) DownloadProduct {
+func NewDownloadProduct(environFunc func() []string, varsStore boshtpl.Variables, logger pivnetlog.Logger, progressWriter io.Writer, results resultWriter, factory PivnetFactory) DownloadProduct {
 	return DownloadProduct{
 		environFunc:    environFunc,
+		varsStore:      varsStore,
 		logger:         logger,
 		progressWriter: progressWriter,
+		results:        results,
 		pivnetFactory:  factory,
 		filter:         filter.NewFilter(logger),
 	}
@@ -70,30 +93,43 @@ func NewDownloadProduct(environFunc func() []string, logger pivnetlog.Logger, pr
 
 func (c DownloadProduct) Usage() jhanda.Usage {
 	return jhanda.Usage{
-		Description:      "This command attempts to download a single product file from Pivotal Network. The API token used must be associated with a user account that has already accepted the EULA for the specified product",
+		Description:      "This command attempts to download a single product file from Pivotal Network. The API token used must be associated with a user account that has already accepted the EULA for the specified product. With --source s3, the file is downloaded from an s3 compatible blobstore instead, where it is stored as '[product-slug,product-version]file-name'",
 		ShortDescription: "downloads a specified product file from Pivotal Network",
 		Flags:            c.Options,
 	}
 }
 
 func (c DownloadProduct) Execute(args []string) error {
-	err := loadConfigFile(args, &c.Options, c.environFunc)
+	err := loadConfigFile(args, &c.Options, c.environFunc, c.varsStore)
 	if err != nil {
-		return fmt.Errorf("could not parse download-product flags: %s", err)
+		return configErrorf("could not parse download-product flags: %w", err)
+	}
+
+	err = c.validate()
+	if err != nil {
+		return configErrorf("could not parse download-product flags: %w", err)
 	}
 
 	var productFileName, stemcellFileName string
 	var releaseID int
 
-	c.init()
+	err = c.init()
+	if err != nil {
+		return configErrorf("could not parse download-product flags: %w", err)
+	}
 
-	releaseID, productFileName, err = c.downloadProductFile(c.Options.ProductSlug, c.Options.ProductVersion, c.Options.FileGlob)
+	productVersion, err := c.resolveVersion(c.Options.ProductSlug)
 	if err != nil {
-		return fmt.Errorf("could not download product: %s", err)
+		return fmt.Errorf("could not resolve the product version: %w", err)
+	}
+
+	releaseID, productFileName, err = c.downloadProductFile(c.Options.ProductSlug, productVersion, c.Options.FileGlob)
+	if err != nil {
+		return fmt.Errorf("could not download product: %w", err)
 	}
 
 	if !c.Options.Stemcell {
-		return c.writerDownloadedFileList(productFileName, stemcellFileName)
+		return c.writerDownloadedFileList(productFileName, productVersion, stemcellFileName)
 	}
 
 	c.logger.Info("Downloading stemcell")
@@ -101,44 +137,86 @@ func (c DownloadProduct) Execute(args []string) error {
 	nameParts := strings.Split(productFileName, ".")
 	if nameParts[len(nameParts)-1] != "pivotal" {
 		c.logger.Info("the downloaded file is not a .pivotal file. Not determining and fetching required stemcell.")
-		return nil
+		return c.results.WriteResult(DownloadProductResult{
+			ProductPath:    productFileName,
+			ProductVersion: productVersion,
+		})
 	}
 
 	dependencies, err := c.client.ReleaseDependencies(c.Options.ProductSlug, releaseID)
 	if err != nil {
-		return fmt.Errorf("could not fetch stemcell dependency for %s %s: %s", c.Options.ProductSlug, c.Options.ProductVersion, err)
+		return fmt.Errorf("could not fetch stemcell dependency for %s %s: %w", c.Options.ProductSlug, productVersion, err)
 	}
 
 	stemcellSlug, stemcellVersion, err := getLatestStemcell(dependencies)
 	if err != nil {
-		return fmt.Errorf("could not sort stemcell dependency: %s", err)
+		return fmt.Errorf("could not sort stemcell dependency: %w", err)
 	}
 
 	_, stemcellFileName, err = c.downloadProductFile(stemcellSlug, stemcellVersion, fmt.Sprintf("*%s*", c.Options.StemcellIaas))
 	if err != nil {
-		return fmt.Errorf("could not download stemcell: %s", err)
+		return fmt.Errorf("could not download stemcell: %w", err)
 	}
 
-	return c.writerDownloadedFileList(productFileName, stemcellFileName)
+	return c.writerDownloadedFileList(productFileName, productVersion, stemcellFileName)
 }
 
-func (c DownloadProduct) writerDownloadedFileList(productFileName string, stemcellFileName string) error {
+func (c DownloadProduct) validate() error {
+	if c.Options.ProductVersion == "" && c.Options.VersionRegex == "" {
+		return errors.New(`missing required flag "--product-version"`)
+	}
+
+	if c.Options.ProductVersion != "" && c.Options.VersionRegex != "" {
+		return errors.New("cannot use both --product-version and --product-version-regex")
+	}
+
+	switch c.Options.Source {
+	case "pivnet":
+		if c.Options.Token == "" {
+			return errors.New(`missing required flag "--pivnet-api-token"`)
+		}
+	case "s3":
+		if c.Options.S3Bucket == "" {
+			return errors.New(`missing required flag "--s3-bucket"`)
+		}
+
+		if c.Options.Stemcell {
+			return errors.New("--download-stemcell is only supported with the pivnet source")
+		}
+	default:
+		return fmt.Errorf("unknown source %q: options are pivnet or s3", c.Options.Source)
+	}
+
+	return nil
+}
+
+func (c DownloadProduct) writerDownloadedFileList(productFileName string, productVersion string, stemcellFileName string) error {
 	c.logger.Info(fmt.Sprintf("Writing a list of downloaded artifact to %s", DownloadListFilename))
 	downloadList := downloadList{
-		Product:  productFileName,
-		Stemcell: stemcellFileName,
+		Product:        productFileName,
+		ProductVersion: productVersion,
+		Stemcell:       stemcellFileName,
 	}
 
 	downloadListFile, err := os.Create(path.Join(c.Options.OutputDir, DownloadListFilename))
 	if err != nil {
-		return fmt.Errorf("could not create %s: %s", DownloadListFilename, err)
+		return fmt.Errorf("could not create %s: %w", DownloadListFilename, err)
 	}
 	defer downloadListFile.Close()
 
-	return json.NewEncoder(downloadListFile).Encode(downloadList)
+	err = json.NewEncoder(downloadListFile).Encode(downloadList)
+	if err != nil {
+		return fmt.Errorf("could not write %s: %w", DownloadListFilename, err) // un-tested
+	}
+
+	return c.results.WriteResult(DownloadProductResult{
+		ProductPath:    productFileName,
+		ProductVersion: productVersion,
+		StemcellPath:   stemcellFileName,
+	})
 }
 
-func (c *DownloadProduct) init() {
+func (c *DownloadProduct) init() error {
 	c.client = c.pivnetFactory(
 		pivnet.ClientConfig{
 			Host:              pivnet.DefaultHost,
@@ -148,22 +226,153 @@ func (c *DownloadProduct) init() {
 		},
 		c.logger,
 	)
+
+	if c.Options.CacheDir != "" {
+		cache := download.NewCache(c.Options.CacheDir)
+		c.cache = &cache
+	}
+
+	if c.Options.Source == "s3" {
+		config := download.S3Config{
+			Endpoint:          c.Options.S3Endpoint,
+			Bucket:            c.Options.S3Bucket,
+			Region:            c.Options.S3RegionName,
+			AccessKeyID:       c.Options.S3AccessKeyID,
+			SecretAccessKey:   c.Options.S3SecretKey,
+			CACert:            c.Options.S3CACert,
+			SkipSSLValidation: c.Options.S3SkipSSL,
+		}
+
+		httpClient, err := download.NewS3HTTPClient(config)
+		if err != nil {
+			return err
+		}
+
+		s3Client := download.NewS3Client(config, httpClient, c.logger)
+		c.s3Client = &s3Client
+	}
+
+	return nil
+}
+
+// resolveVersion returns the highest release of slug that matches the
+// version constraint or regex. Exact versions are returned as is.
+func (c *DownloadProduct) resolveVersion(slug string) (string, error) {
+	var match func(string) bool
+	var filter string
+
+	switch {
+	case c.Options.VersionRegex != "":
+		regex, err := regexp.Compile(c.Options.VersionRegex)
+		if err != nil {
+			return "", fmt.Errorf("could not compile regex %q: %w", c.Options.VersionRegex, err)
+		}
+		match = regex.MatchString
+		filter = fmt.Sprintf("regex %q", c.Options.VersionRegex)
+	case versions.IsVersionConstraint(c.Options.ProductVersion):
+		constraint, err := versions.NewVersionConstraint(c.Options.ProductVersion)
+		if err != nil {
+			return "", err
+		}
+		match = constraint.Check
+		filter = fmt.Sprintf("constraint %q", c.Options.ProductVersion)
+	default:
+		return c.Options.ProductVersion, nil
+	}
+
+	available, err := c.availableVersions(slug)
+	if err != nil {
+		return "", err
+	}
+
+	version, found := versions.LatestVersion(available, match)
+	if !found {
+		return "", fmt.Errorf("no version of %s matches the %s", slug, filter)
+	}
+
+	c.logger.Info(fmt.Sprintf("Resolved the %s to version %s of %s", filter, version, slug))
+
+	return version, nil
+}
+
+func (c *DownloadProduct) availableVersions(slug string) ([]string, error) {
+	var versions []string
+
+	if c.s3Client != nil {
+		prefix := fmt.Sprintf("[%s,", slug)
+		if c.Options.S3Path != "" {
+			prefix = path.Join(c.Options.S3Path, prefix)
+		}
+
+		keys, err := c.s3Client.ListObjects(prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			end := strings.Index(key[len(prefix):], "]")
+			if end > 0 {
+				versions = append(versions, key[len(prefix):len(prefix)+end])
+			}
+		}
+
+		return versions, nil
+	}
+
+	releases, err := c.client.ReleasesForProductSlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the releases for %s: %w", slug, err)
+	}
+
+	for _, release := range releases {
+		versions = append(versions, release.Version)
+	}
+
+	return versions, nil
 }
 
 func (c *DownloadProduct) downloadProductFile(slug, version, glob string) (int, string, error) {
+	if c.cache != nil {
+		entry, found, err := c.cache.Lookup(slug, version, glob)
+		if err != nil {
+			return 0, "", err
+		}
+
+		if found {
+			productFilePath := path.Join(c.Options.OutputDir, entry.FileName)
+			c.logger.Info(fmt.Sprintf("%s found in cache %s, skip downloading", entry.FileName, c.Options.CacheDir))
+
+			err = c.cache.Link(entry, productFilePath)
+			if err != nil {
+				return entry.ReleaseID, "", fmt.Errorf("could not copy %s from cache: %w", entry.FileName, err)
+			}
+
+			return entry.ReleaseID, productFilePath, nil
+		}
+	}
+
+	if c.s3Client != nil {
+		productFilePath, err := c.downloadS3File(slug, version, glob)
+		if err != nil {
+			return 0, "", err
+		}
+
+		return 0, productFilePath, c.addToCache(slug, version, glob, 0, pivnet.ProductFile{}, productFilePath)
+	}
+
 	release, err := c.client.ReleaseForVersion(slug, version)
 	if err != nil {
-		return release.ID, "", fmt.Errorf("could not fetch the release for %s %s: %s", slug, version, err)
+		return release.ID, "", fmt.Errorf("could not fetch the release for %s %s: %w", slug, version, err)
 	}
 
 	productFileNames, err := c.client.ProductFilesForRelease(slug, release.ID)
 	if err != nil {
-		return release.ID, "", fmt.Errorf("could not fetch the product files for %s %s: %s", slug, version, err)
+		return release.ID, "", fmt.Errorf("could not fetch the product files for %s %s: %w", slug, version, err)
 	}
 
 	productFileNames, err = c.filter.ProductFileKeysByGlobs(productFileNames, []string{glob})
 	if err != nil {
-		return release.ID, "", fmt.Errorf("could not glob product files: %s", err)
+		return release.ID, "", fmt.Errorf("could not glob product files: %w", err)
 	}
 
 	if err := checkSingleProductFile(glob, productFileNames); err != nil {
@@ -181,21 +390,140 @@ func (c *DownloadProduct) downloadProductFile(slug, version, glob string) (int,
 
 	if exist {
 		c.logger.Info(fmt.Sprintf("%s already exists, skip downloading", productFilePath))
-		return release.ID, productFilePath, nil
+		return release.ID, productFilePath, c.addToCache(slug, version, glob, release.ID, productFileName, productFilePath)
+	}
+
+	err = downloadToFile(productFilePath, productFileName.SHA256, func(productFile *os.File) error {
+		return c.client.DownloadProductFile(productFile, slug, release.ID, productFileName.ID, c.progressWriter)
+	})
+	if err != nil {
+		return release.ID, "", fmt.Errorf("could not download product file %s %s: %w", slug, version, err)
+	}
+
+	return release.ID, productFilePath, c.addToCache(slug, version, glob, release.ID, productFileName, productFilePath)
+}
+
+// downloadS3File finds the single file matching glob among the files stored
+// for slug and version, following the '[slug,version]file-name' convention.
+func (c *DownloadProduct) downloadS3File(slug, version, glob string) (string, error) {
+	prefix := fmt.Sprintf("[%s,%s]", slug, version)
+	if c.Options.S3Path != "" {
+		prefix = path.Join(c.Options.S3Path, prefix)
+	}
+
+	keys, err := c.s3Client.ListObjects(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []string
+	for _, key := range keys {
+		fileName := strings.TrimPrefix(key, prefix)
+		if strings.Contains(fileName, "/") {
+			continue
+		}
+
+		matched, err := path.Match(glob, fileName)
+		if err != nil {
+			return "", fmt.Errorf("could not glob files in bucket %s: %w", c.Options.S3Bucket, err)
+		}
+
+		if matched {
+			matches = append(matches, fileName)
+		}
+	}
+
+	if len(matches) > 1 {
+		return "", fmt.Errorf("the glob '%s' matches multiple files. Write your glob to match exactly one of the following:\n  %s", glob, strings.Join(matches, "\n  "))
+	} else if len(matches) == 0 {
+		return "", fmt.Errorf("the glob '%s' matches no file in bucket %s under %s", glob, c.Options.S3Bucket, prefix)
+	}
+
+	productFilePath := path.Join(c.Options.OutputDir, matches[0])
+	err = downloadToFile(productFilePath, "", func(productFile *os.File) error {
+		return c.s3Client.DownloadObject(productFile, prefix+matches[0], c.progressWriter)
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not download %s from bucket %s: %w", prefix+matches[0], c.Options.S3Bucket, err)
 	}
 
-	productFile, err := os.Create(productFilePath)
+	return productFilePath, nil
+}
+
+// downloadToFile downloads into a partial file next to productFilePath, which
+// is kept when the download fails so the next attempt can resume it, and only
+// moves it into place once it matches the expected checksum.
+func downloadToFile(productFilePath, expectedSum string, download func(*os.File) error) error {
+	partialFilePath := productFilePath + ".partial"
+	productFile, err := os.OpenFile(partialFilePath, os.O_RDWR|os.O_CREATE, 0644)
 	if err != nil {
-		return release.ID, "", fmt.Errorf("could not create file %s: %s", productFilePath, err)
+		return fmt.Errorf("could not create file %s: %w", partialFilePath, err)
 	}
 	defer productFile.Close()
 
-	err = c.client.DownloadProductFile(productFile, slug, release.ID, productFileName.ID, c.progressWriter)
+	err = download(productFile)
+	if err != nil {
+		return err
+	}
+
+	err = verifyChecksum(partialFilePath, expectedSum)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(partialFilePath, productFilePath)
+	if err != nil {
+		return fmt.Errorf("could not move %s to %s: %w", partialFilePath, productFilePath, err)
+	}
+
+	return nil
+}
+
+func (c *DownloadProduct) addToCache(slug, version, glob string, releaseID int, productFile pivnet.ProductFile, productFilePath string) error {
+	if c.cache == nil {
+		return nil
+	}
+
+	sum := productFile.SHA256
+	if sum == "" {
+		var err error
+		sum, err = validator.NewSHA256Calculator().Checksum(productFilePath)
+		if err != nil {
+			return fmt.Errorf("failed to calculate the checksum: %w", err)
+		}
+	}
+
+	return c.cache.Store(download.CacheEntry{
+		Slug:          slug,
+		Version:       version,
+		Glob:          glob,
+		ReleaseID:     releaseID,
+		ProductFileID: productFile.ID,
+		SHA256:        sum,
+		FileName:      path.Base(productFilePath),
+	}, productFilePath)
+}
+
+// verifyChecksum removes a downloaded file that does not match the checksum
+// published on Pivotal Network, so that the next attempt starts over.
+func verifyChecksum(path, expectedSum string) error {
+	if expectedSum == "" {
+		return nil
+	}
+
+	validate := validator.NewSHA256Calculator()
+	sum, err := validate.Checksum(path)
 	if err != nil {
-		return release.ID, "", fmt.Errorf("could not download product file %s %s: %s", slug, version, err)
+		return fmt.Errorf("failed to calculate the checksum: %w", err)
+	}
+
+	if sum != expectedSum {
+		os.Remove(path)
+		os.Remove(path + download.StateFileSuffix)
+		return fmt.Errorf("the checksum of %s is %s, expected %s", path, sum, expectedSum)
 	}
 
-	return release.ID, productFilePath, nil
+	return nil
 }
 
 func checkFileExists(path, expectedSum string) (bool, error) {
@@ -204,14 +532,14 @@ func checkFileExists(path, expectedSum string) (bool, error) {
 		if os.IsNotExist(err) {
 			return false, nil
 		} else {
-			return false, fmt.Errorf("failed to get file information: %s", err)
+			return false, fmt.Errorf("failed to get file information: %w", err)
 		}
 	}
 
 	validate := validator.NewSHA256Calculator()
 	sum, err := validate.Checksum(path)
 	if err != nil {
-		return false, fmt.Errorf("failed to calculate the checksum: %s", err)
+		return false, fmt.Errorf("failed to calculate the checksum: %w", err)
 	}
 
 	return sum == expectedSum, nil
@@ -1,6 +1,9 @@
 package commands_test
 
 import (
+	"crypto/md5"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -12,9 +15,14 @@ import (
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
 	"github.com/pivotal-cf/om/validator"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
+	"strings"
+	"time"
 )
 
 var _ = Describe("DownloadProduct", func() {
@@ -23,6 +31,7 @@ var _ = Describe("DownloadProduct", func() {
 		logger               *loggerfakes.FakeLogger
 		fakePivnetDownloader *fakes.PivnetDownloader
 		fakeWriter           *gbytes.Buffer
+		results              *fakes.ResultWriter
 		environFunc          func() []string
 		tempDir              string
 		err                  error
@@ -37,10 +46,11 @@ var _ = Describe("DownloadProduct", func() {
 		fakePivnetDownloader = &fakes.PivnetDownloader{}
 		environFunc = func() []string { return nil }
 		fakeWriter = gbytes.NewBuffer()
+		results = &fakes.ResultWriter{}
 	})
 
 	JustBeforeEach(func() {
-		command = commands.NewDownloadProduct(environFunc, logger, fakeWriter, fakePivnetFactory)
+		command = commands.NewDownloadProduct(environFunc, nil, logger, fakeWriter, results, fakePivnetFactory)
 	})
 
 	Context("given the flags are set correctly", func() {
@@ -89,16 +99,89 @@ var _ = Describe("DownloadProduct", func() {
 			Expect(releaseID).To(Equal(12345))
 
 			file, slug, releaseID, productFileID, _ := fakePivnetDownloader.DownloadProductFileArgsForCall(0)
-			Expect(file.Name()).To(Equal(path.Join(tempDir, "cf-2.0-build.1.pivotal")))
+			Expect(file.Name()).To(Equal(path.Join(tempDir, "cf-2.0-build.1.pivotal.partial")))
 			Expect(slug).To(Equal("elastic-runtime"))
 			Expect(releaseID).To(Equal(12345))
 			Expect(productFileID).To(Equal(54321))
 
+			productFilePath := path.Join(tempDir, "cf-2.0-build.1.pivotal")
+			Expect(productFilePath).To(BeAnExistingFile())
+			Expect(file.Name()).NotTo(BeAnExistingFile())
+
 			fileName := path.Join(tempDir, commands.DownloadListFilename)
 			fileContent, err := ioutil.ReadFile(fileName)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fileName).To(BeAnExistingFile())
-			Expect(string(fileContent)).To(MatchJSON(fmt.Sprintf(`{"product": "%s" }`, file.Name())))
+			Expect(string(fileContent)).To(MatchJSON(fmt.Sprintf(`{"product": "%s", "product_version": "2.0.0"}`, productFilePath)))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.DownloadProductResult{
+				ProductPath:    productFilePath,
+				ProductVersion: "2.0.0",
+			}))
+		})
+
+		Context("when Pivotal Network publishes a checksum for the file", func() {
+			BeforeEach(func() {
+				fakePivnetDownloader.ProductFilesForReleaseReturnsOnCall(0, []pivnet.ProductFile{
+					{
+						ID:           54321,
+						AWSObjectKey: "/some-account/some-bucket/cf-2.0-build.1.pivotal",
+						SHA256:       "a8cab8e8d8d0df6a3d3e8a7fab4e1d5b8e1c5d1ad4d2ac9fcc63f4bd8c5a4566",
+						Name:         "Example Cloud Foundry",
+					},
+				}, nil)
+			})
+
+			It("verifies the downloaded file against the checksum", func() {
+				fakePivnetDownloader.DownloadProductFileStub = func(location *os.File, _ string, _ int, _ int, _ io.Writer) error {
+					_, err := location.WriteString("some-tile")
+					return err
+				}
+
+				fakePivnetDownloader.ProductFilesForReleaseReturnsOnCall(0, []pivnet.ProductFile{
+					{
+						ID:           54321,
+						AWSObjectKey: "/some-account/some-bucket/cf-2.0-build.1.pivotal",
+						SHA256:       fmt.Sprintf("%x", sha256.Sum256([]byte("some-tile"))),
+						Name:         "Example Cloud Foundry",
+					},
+				}, nil)
+
+				err := command.Execute([]string{
+					"--pivnet-api-token", "token",
+					"--pivnet-file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				contents, err := ioutil.ReadFile(path.Join(tempDir, "cf-2.0-build.1.pivotal"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("some-tile"))
+			})
+
+			Context("when the downloaded file does not match the checksum", func() {
+				It("removes the file and returns an error", func() {
+					fakePivnetDownloader.DownloadProductFileStub = func(location *os.File, _ string, _ int, _ int, _ io.Writer) error {
+						_, err := location.WriteString("some-corrupted-tile")
+						return err
+					}
+
+					err := command.Execute([]string{
+						"--pivnet-api-token", "token",
+						"--pivnet-file-glob", "*.pivotal",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", "2.0.0",
+						"--output-directory", tempDir,
+					})
+					Expect(err).To(MatchError(ContainSubstring("expected a8cab8e8d8d0df6a3d3e8a7fab4e1d5b8e1c5d1ad4d2ac9fcc63f4bd8c5a4566")))
+
+					Expect(path.Join(tempDir, "cf-2.0-build.1.pivotal")).NotTo(BeAnExistingFile())
+					Expect(path.Join(tempDir, "cf-2.0-build.1.pivotal.partial")).NotTo(BeAnExistingFile())
+				})
+			})
 		})
 
 		Context("when the globs returns multiple files", func() {
@@ -207,7 +290,7 @@ var _ = Describe("DownloadProduct", func() {
 				Expect(str).To(Equal("stemcells-ubuntu-xenial"))
 
 				file, slug, releaseID, fileID, _ := fakePivnetDownloader.DownloadProductFileArgsForCall(1)
-				Expect(file.Name()).To(Equal(path.Join(tempDir, "light-bosh-stemcell-97.19-google-kvm-ubuntu-xenial-go_agent.tgz")))
+				Expect(file.Name()).To(Equal(path.Join(tempDir, "light-bosh-stemcell-97.19-google-kvm-ubuntu-xenial-go_agent.tgz.partial")))
 				Expect(slug).To(Equal("stemcells-ubuntu-xenial"))
 				Expect(releaseID).To(Equal(9999))
 				Expect(fileID).To(Equal(5678))
@@ -293,6 +376,373 @@ var _ = Describe("DownloadProduct", func() {
 			})
 		})
 
+		Context("when the cache-dir flag is passed", func() {
+			var cacheDir string
+
+			BeforeEach(func() {
+				cacheDir, err = ioutil.TempDir("", "om-tests-cache-")
+				Expect(err).NotTo(HaveOccurred())
+
+				fakePivnetDownloader.DownloadProductFileStub = func(location *os.File, _ string, _ int, _ int, _ io.Writer) error {
+					_, err := location.WriteString("some-tile")
+					return err
+				}
+			})
+
+			AfterEach(func() {
+				err = os.RemoveAll(cacheDir)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("adds the downloaded file to the cache and uses it on the next download", func() {
+				args := []string{
+					"--pivnet-api-token", "token",
+					"--pivnet-file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+					"--cache-dir", cacheDir,
+				}
+
+				err := command.Execute(args)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakePivnetDownloader.DownloadProductFileCallCount()).To(Equal(1))
+
+				otherOutputDir, err := ioutil.TempDir("", "om-tests-")
+				Expect(err).NotTo(HaveOccurred())
+				defer os.RemoveAll(otherOutputDir)
+
+				args[9] = otherOutputDir
+				err = commands.NewDownloadProduct(environFunc, nil, logger, fakeWriter, results, fakePivnetFactory).Execute(args)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePivnetDownloader.ReleaseForVersionCallCount()).To(Equal(1))
+				Expect(fakePivnetDownloader.DownloadProductFileCallCount()).To(Equal(1))
+
+				productFilePath := path.Join(otherOutputDir, "cf-2.0-build.1.pivotal")
+				contents, err := ioutil.ReadFile(productFilePath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("some-tile"))
+
+				fileContent, err := ioutil.ReadFile(path.Join(otherOutputDir, commands.DownloadListFilename))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(fileContent)).To(MatchJSON(fmt.Sprintf(`{"product": "%s", "product_version": "2.0.0"}`, productFilePath)))
+			})
+
+			It("downloads again when another version is requested", func() {
+				err := command.Execute([]string{
+					"--pivnet-api-token", "token",
+					"--pivnet-file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakePivnetDownloader.ReleaseForVersionReturnsOnCall(1, pivnet.Release{ID: 12346}, nil)
+				fakePivnetDownloader.ProductFilesForReleaseReturnsOnCall(1, []pivnet.ProductFile{
+					{
+						ID:           54322,
+						AWSObjectKey: "/some-account/some-bucket/cf-2.0-build.2.pivotal",
+						Name:         "Example Cloud Foundry",
+					},
+				}, nil)
+
+				err = command.Execute([]string{
+					"--pivnet-api-token", "token",
+					"--pivnet-file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.1",
+					"--output-directory", tempDir,
+					"--cache-dir", cacheDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePivnetDownloader.DownloadProductFileCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when the product version is a constraint", func() {
+			BeforeEach(func() {
+				fakePivnetDownloader.ReleasesForProductSlugReturns([]pivnet.Release{
+					{ID: 1, Version: "2.0.0"},
+					{ID: 2, Version: "2.0.3"},
+					{ID: 3, Version: "2.0.4-build.1"},
+					{ID: 4, Version: "2.1.0"},
+				}, nil)
+			})
+
+			It("downloads the highest matching release", func() {
+				err := command.Execute([]string{
+					"--pivnet-api-token", "token",
+					"--pivnet-file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "~2.0",
+					"--output-directory", tempDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePivnetDownloader.ReleasesForProductSlugArgsForCall(0)).To(Equal("elastic-runtime"))
+
+				slug, version := fakePivnetDownloader.ReleaseForVersionArgsForCall(0)
+				Expect(slug).To(Equal("elastic-runtime"))
+				Expect(version).To(Equal("2.0.3"))
+
+				fileContent, err := ioutil.ReadFile(path.Join(tempDir, commands.DownloadListFilename))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(fileContent)).To(MatchJSON(fmt.Sprintf(`{"product": "%s", "product_version": "2.0.3"}`, path.Join(tempDir, "cf-2.0-build.1.pivotal"))))
+			})
+
+			It("downloads the highest release matching the product-version-regex", func() {
+				err := command.Execute([]string{
+					"--pivnet-api-token", "token",
+					"--pivnet-file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version-regex", `^2\.\d+\.0$`,
+					"--output-directory", tempDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, version := fakePivnetDownloader.ReleaseForVersionArgsForCall(0)
+				Expect(version).To(Equal("2.1.0"))
+			})
+
+			Context("when no release matches", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{
+						"--pivnet-api-token", "token",
+						"--pivnet-file-glob", "*.pivotal",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", ">=2.2.0",
+						"--output-directory", tempDir,
+					})
+					Expect(err).To(MatchError(`could not resolve the product version: no version of elastic-runtime matches the constraint ">=2.2.0"`))
+				})
+			})
+
+			Context("when the releases cannot be fetched", func() {
+				It("returns an error", func() {
+					fakePivnetDownloader.ReleasesForProductSlugReturns(nil, errors.New("some-error"))
+
+					err := command.Execute([]string{
+						"--pivnet-api-token", "token",
+						"--pivnet-file-glob", "*.pivotal",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", "~2.0",
+						"--output-directory", tempDir,
+					})
+					Expect(err).To(MatchError("could not resolve the product version: could not fetch the releases for elastic-runtime: some-error"))
+				})
+			})
+		})
+
+		Context("when the source is s3", func() {
+			var (
+				server  *httptest.Server
+				objects map[string]string
+				etags   map[string]string
+			)
+
+			BeforeEach(func() {
+				objects = map[string]string{
+					"tiles/[elastic-runtime,2.0.0]cf-2.0-build.1.pivotal":  "some-tile",
+					"tiles/[elastic-runtime,2.0.0]srt-2.0-build.1.pivotal": "some-small-tile",
+					"tiles/[elastic-runtime,2.0.1]cf-2.0-build.2.pivotal":  "some-newer-tile",
+				}
+				etags = map[string]string{}
+
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					if req.URL.Path == "/some-bucket" {
+						prefix := req.URL.Query().Get("prefix")
+						w.Write([]byte(`<ListBucketResult>`))
+						for key := range objects {
+							if strings.HasPrefix(key, prefix) {
+								fmt.Fprintf(w, `<Contents><Key>%s</Key></Contents>`, key)
+							}
+						}
+						w.Write([]byte(`</ListBucketResult>`))
+						return
+					}
+
+					content, ok := objects[strings.TrimPrefix(req.URL.Path, "/some-bucket/")]
+					if !ok {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+
+					if etag, ok := etags[strings.TrimPrefix(req.URL.Path, "/some-bucket/")]; ok {
+						w.Header().Set("ETag", etag)
+					}
+
+					http.ServeContent(w, req, "", time.Time{}, strings.NewReader(content))
+				}))
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("downloads the product file from the bucket", func() {
+				err := command.Execute([]string{
+					"--source", "s3",
+					"--s3-endpoint", server.URL,
+					"--s3-bucket", "some-bucket",
+					"--s3-path", "tiles",
+					"--pivnet-file-glob", "cf-*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePivnetDownloader.ReleaseForVersionCallCount()).To(Equal(0))
+
+				productFilePath := path.Join(tempDir, "cf-2.0-build.1.pivotal")
+				contents, err := ioutil.ReadFile(productFilePath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("some-tile"))
+
+				fileContent, err := ioutil.ReadFile(path.Join(tempDir, commands.DownloadListFilename))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(fileContent)).To(MatchJSON(fmt.Sprintf(`{"product": "%s", "product_version": "2.0.0"}`, productFilePath)))
+			})
+
+			It("resolves version constraints against the versions in the bucket", func() {
+				err := command.Execute([]string{
+					"--source", "s3",
+					"--s3-endpoint", server.URL,
+					"--s3-bucket", "some-bucket",
+					"--s3-path", "tiles",
+					"--pivnet-file-glob", "cf-*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "~2.0",
+					"--output-directory", tempDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				contents, err := ioutil.ReadFile(path.Join(tempDir, "cf-2.0-build.2.pivotal"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("some-newer-tile"))
+			})
+
+			It("downloads from a blobstore with a self-signed certificate", func() {
+				tlsServer := httptest.NewTLSServer(server.Config.Handler)
+				defer tlsServer.Close()
+
+				err := command.Execute([]string{
+					"--source", "s3",
+					"--s3-endpoint", tlsServer.URL,
+					"--s3-bucket", "some-bucket",
+					"--s3-path", "tiles",
+					"--s3-skip-ssl-validation",
+					"--pivnet-file-glob", "cf-*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(path.Join(tempDir, "cf-2.0-build.1.pivotal")).To(BeAnExistingFile())
+			})
+
+			Context("when the downloaded file does not match the checksum of the object", func() {
+				It("returns an error", func() {
+					etags["tiles/[elastic-runtime,2.0.0]cf-2.0-build.1.pivotal"] = fmt.Sprintf(`"%x"`, md5.Sum([]byte("some-other-tile")))
+
+					err := command.Execute([]string{
+						"--source", "s3",
+						"--s3-endpoint", server.URL,
+						"--s3-bucket", "some-bucket",
+						"--s3-path", "tiles",
+						"--pivnet-file-glob", "cf-*.pivotal",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", "2.0.0",
+						"--output-directory", tempDir,
+					})
+					Expect(err).To(MatchError(ContainSubstring("does not match tiles/[elastic-runtime,2.0.0]cf-2.0-build.1.pivotal")))
+
+					Expect(path.Join(tempDir, "cf-2.0-build.1.pivotal")).NotTo(BeAnExistingFile())
+				})
+			})
+
+			Context("when the CA certificate cannot be read", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{
+						"--source", "s3",
+						"--s3-endpoint", server.URL,
+						"--s3-bucket", "some-bucket",
+						"--s3-ca-cert", "/missing/ca.pem",
+						"--pivnet-file-glob", "cf-*.pivotal",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", "2.0.0",
+						"--output-directory", tempDir,
+					})
+					Expect(err).To(MatchError(ContainSubstring("could not parse download-product flags: could not read ca cert")))
+					Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeConfig))
+				})
+			})
+
+			Context("when the glob matches multiple files", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{
+						"--source", "s3",
+						"--s3-endpoint", server.URL,
+						"--s3-bucket", "some-bucket",
+						"--s3-path", "tiles",
+						"--pivnet-file-glob", "*.pivotal",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", "2.0.0",
+						"--output-directory", tempDir,
+					})
+					Expect(err).To(MatchError(ContainSubstring("the glob '*.pivotal' matches multiple files")))
+				})
+			})
+
+			Context("when the glob matches no file", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{
+						"--source", "s3",
+						"--s3-endpoint", server.URL,
+						"--s3-bucket", "some-bucket",
+						"--pivnet-file-glob", "*.pivotal",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", "2.0.0",
+						"--output-directory", tempDir,
+					})
+					Expect(err).To(MatchError("could not download product: the glob '*.pivotal' matches no file in bucket some-bucket under [elastic-runtime,2.0.0]"))
+				})
+			})
+
+			Context("when the s3-bucket flag is not provided", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{
+						"--source", "s3",
+						"--pivnet-file-glob", "*.pivotal",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", "2.0.0",
+						"--output-directory", tempDir,
+					})
+					Expect(err).To(MatchError("could not parse download-product flags: missing required flag \"--s3-bucket\""))
+				})
+			})
+
+			Context("when the download-stemcell flag is passed", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{
+						"--source", "s3",
+						"--s3-bucket", "some-bucket",
+						"--pivnet-file-glob", "*.pivotal",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", "2.0.0",
+						"--output-directory", tempDir,
+						"--download-stemcell",
+					})
+					Expect(err).To(MatchError("could not parse download-product flags: --download-stemcell is only supported with the pivnet source"))
+				})
+			})
+		})
+
 		Context("when the --config flag is passed", func() {
 			var (
 				configFile *os.File
@@ -390,10 +840,61 @@ output-directory: %s
 		Context("when a required flag is not provided", func() {
 			It("returns an error", func() {
 				err := command.Execute([]string{})
+				Expect(err).To(MatchError("could not parse download-product flags: missing required flag \"--pivnet-file-glob\""))
+			})
+		})
+
+		Context("when the pivnet-api-token flag is not provided for the pivnet source", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{
+					"--pivnet-file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", "/tmp",
+				})
 				Expect(err).To(MatchError("could not parse download-product flags: missing required flag \"--pivnet-api-token\""))
 			})
 		})
 
+		Context("when neither product-version nor product-version-regex is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{
+					"--pivnet-api-token", "token",
+					"--pivnet-file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--output-directory", "/tmp",
+				})
+				Expect(err).To(MatchError("could not parse download-product flags: missing required flag \"--product-version\""))
+			})
+		})
+
+		Context("when both product-version and product-version-regex are provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{
+					"--pivnet-api-token", "token",
+					"--pivnet-file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--product-version-regex", "2.*",
+					"--output-directory", "/tmp",
+				})
+				Expect(err).To(MatchError("could not parse download-product flags: cannot use both --product-version and --product-version-regex"))
+			})
+		})
+
+		Context("when an unknown source is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{
+					"--source", "ftp",
+					"--pivnet-file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", "/tmp",
+				})
+				Expect(err).To(MatchError("could not parse download-product flags: unknown source \"ftp\": options are pivnet or s3"))
+			})
+		})
+
 		Context("when the release specified is not available", func() {
 			BeforeEach(func() {
 				fakePivnetDownloader.ReleaseForVersionReturns(pivnet.Release{}, fmt.Errorf("some-error"))
@@ -416,7 +917,7 @@ output-directory: %s
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
-				Description:      "This command attempts to download a single product file from Pivotal Network. The API token used must be associated with a user account that has already accepted the EULA for the specified product",
+				Description:      "This command attempts to download a single product file from Pivotal Network. The API token used must be associated with a user account that has already accepted the EULA for the specified product. With --source s3, the file is downloaded from an s3 compatible blobstore instead, where it is stored as '[product-slug,product-version]file-name'",
 				ShortDescription: "downloads a specified product file from Pivotal Network",
 				Flags:            command.Options,
 			}))
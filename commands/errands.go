@@ -21,7 +21,7 @@ type Errands struct {
 	service   errandsService
 	Options   struct {
 		ProductName string `long:"product-name" short:"p" required:"true" description:"name of product"`
-		Format      string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format      string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -34,17 +34,17 @@ func NewErrands(presenter presenters.FormattedPresenter, service errandsService)
 
 func (e Errands) Execute(args []string) error {
 	if _, err := jhanda.Parse(&e.Options, args); err != nil {
-		return fmt.Errorf("could not parse errands flags: %s", err)
+		return configErrorf("could not parse errands flags: %w", err)
 	}
 
 	findOutput, err := e.service.GetStagedProductByName(e.Options.ProductName)
 	if err != nil {
-		return fmt.Errorf("failed to find staged product %q: %s", e.Options.ProductName, err)
+		return fmt.Errorf("failed to find staged product %q: %w", e.Options.ProductName, err)
 	}
 
 	errandsOutput, err := e.service.ListStagedProductErrands(findOutput.Product.GUID)
 	if err != nil {
-		return fmt.Errorf("failed to list errands: %s", err)
+		return fmt.Errorf("failed to list errands: %w", err)
 	}
 
 	var errands []models.Errand
@@ -56,10 +56,12 @@ func (e Errands) Execute(args []string) error {
 		})
 	}
 
-	e.presenter.SetFormat(e.Options.Format)
-	e.presenter.PresentErrands(errands)
+	err = e.presenter.SetFormat(e.Options.Format)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return e.presenter.PresentErrands(errands)
 }
 
 func boolStringFromType(object interface{}) string {
@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/network"
+)
+
+// The exit codes of om. Pipelines can retry on ExitCodeUnavailable, and
+// should fail fast on the others.
+const (
+	ExitCodeError              = 1
+	ExitCodeConfig             = 2
+	ExitCodeAuthentication     = 3
+	ExitCodeNotFound           = 4
+	ExitCodeInstallationFailed = 5
+	ExitCodeUnavailable        = 6
+	ExitCodeUnsupported        = 7
+	ExitCodeLocked             = 8
+)
+
+// ConfigError is returned when the flags, config files or variables given
+// to a command are invalid.
+type ConfigError struct {
+	Err error
+}
+
+func (e ConfigError) Error() string {
+	return e.Err.Error()
+}
+
+func (e ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// NotFoundError is returned when a product, certificate authority or other
+// resource that a command refers to does not exist.
+type NotFoundError struct {
+	Err error
+}
+
+func (e NotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+func (e NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// UnsupportedError is returned when a flag depends on a capability that the
+// version of Ops Manager does not have.
+type UnsupportedError struct {
+	Err error
+}
+
+func (e UnsupportedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e UnsupportedError) Unwrap() error {
+	return e.Err
+}
+
+// InstallationFailedError is returned when an installation, or the deletion
+// of one, finished unsuccessfully.
+type InstallationFailedError struct {
+	InstallationID int
+	Err            error
+}
+
+func (e InstallationFailedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e InstallationFailedError) Unwrap() error {
+	return e.Err
+}
+
+// LockedError is returned when the installation is locked after Ops Manager
+// has rebooted, and there is no decryption passphrase to unlock it.
+type LockedError struct {
+	Err error
+}
+
+func (e LockedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e LockedError) Unwrap() error {
+	return e.Err
+}
+
+type temporary interface {
+	Temporary() bool
+}
+
+// ExitCode returns the exit code for an error returned by a command.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var (
+		configErr       ConfigError
+		notFoundErr     NotFoundError
+		unsupportedErr  UnsupportedError
+		installationErr InstallationFailedError
+		lockedErr       LockedError
+		unlockErr       network.LockedError
+		tokenErr        network.TokenError
+		responseErr     api.ResponseError
+	)
+
+	switch {
+	case errors.As(err, &configErr):
+		return ExitCodeConfig
+	case errors.As(err, &notFoundErr):
+		return ExitCodeNotFound
+	case errors.As(err, &unsupportedErr):
+		return ExitCodeUnsupported
+	case errors.As(err, &installationErr):
+		return ExitCodeInstallationFailed
+	case errors.As(err, &lockedErr), errors.As(err, &unlockErr):
+		return ExitCodeLocked
+	case errors.As(err, &tokenErr) && tokenErr.StatusCode != 0:
+		if tokenErr.StatusCode >= http.StatusInternalServerError {
+			return ExitCodeUnavailable
+		}
+		return ExitCodeAuthentication
+	case errors.As(err, &responseErr):
+		return exitCodeForStatus(responseErr.StatusCode)
+	case isUnavailable(err):
+		return ExitCodeUnavailable
+	}
+
+	return ExitCodeError
+}
+
+func exitCodeForStatus(statusCode int) int {
+	switch {
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ExitCodeAuthentication
+	case statusCode == http.StatusNotFound:
+		return ExitCodeNotFound
+	case statusCode == http.StatusUnprocessableEntity:
+		// Ops Manager rejects invalid configuration with 422 Unprocessable Entity
+		return ExitCodeConfig
+	case statusCode >= http.StatusInternalServerError:
+		return ExitCodeUnavailable
+	}
+
+	return ExitCodeError
+}
+
+// isUnavailable reports whether Ops Manager could not be reached, or reported
+// that it cannot respond yet, as opposed to, for example, a certificate that
+// is not trusted.
+func isUnavailable(err error) bool {
+	var (
+		opErr        *net.OpError
+		netErr       net.Error
+		temporaryErr temporary
+	)
+
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.As(err, &temporaryErr) && temporaryErr.Temporary()
+}
+
+// configErrorf returns a ConfigError with a formatted message.
+func configErrorf(format string, a ...interface{}) error {
+	return ConfigError{Err: fmt.Errorf(format, a...)}
+}
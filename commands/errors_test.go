@@ -0,0 +1,76 @@
+package commands_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/network"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExitCode", func() {
+	responseError := func(statusCode int) error {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(statusCode)
+		}))
+		defer server.Close()
+
+		client := network.NewUnauthenticatedClient(server.URL, &tls.Config{}, time.Second, time.Second)
+		service := api.New(api.ApiInput{Client: client})
+		_, err := service.ListInstallations()
+		Expect(err).To(HaveOccurred())
+
+		return err
+	}
+
+	It("returns 0 without an error", func() {
+		Expect(commands.ExitCode(nil)).To(Equal(0))
+	})
+
+	It("classifies errors, also when they are wrapped", func() {
+		exitCodes := map[error]int{
+			errors.New("some error"):                                                           commands.ExitCodeError,
+			commands.ConfigError{Err: errors.New("bad flag")}:                                  commands.ExitCodeConfig,
+			commands.NotFoundError{Err: errors.New("no such product")}:                         commands.ExitCodeNotFound,
+			commands.UnsupportedError{Err: errors.New("too old")}:                              commands.ExitCodeUnsupported,
+			commands.InstallationFailedError{InstallationID: 42, Err: errors.New("failed")}:    commands.ExitCodeInstallationFailed,
+			network.LockedError{}:                                                              commands.ExitCodeLocked,
+			commands.LockedError{Err: errors.New("locked")}:                                    commands.ExitCodeLocked,
+			network.TokenError{StatusCode: http.StatusUnauthorized, Err: errors.New("denied")}: commands.ExitCodeAuthentication,
+			network.TokenError{StatusCode: http.StatusBadGateway, Err: errors.New("gateway")}:  commands.ExitCodeUnavailable,
+			api.DiagnosticReportUnavailable{}:                                                  commands.ExitCodeUnavailable,
+			&net.OpError{Op: "dial", Err: errors.New("connection refused")}:                    commands.ExitCodeUnavailable,
+		}
+
+		for err, exitCode := range exitCodes {
+			Expect(commands.ExitCode(err)).To(Equal(exitCode), err.Error())
+
+			wrapped := fmt.Errorf("could not execute %q: %w", "some-command", err)
+			Expect(commands.ExitCode(wrapped)).To(Equal(exitCode), err.Error())
+		}
+	})
+
+	It("classifies the responses of Ops Manager", func() {
+		exitCodes := map[int]int{
+			http.StatusUnauthorized:        commands.ExitCodeAuthentication,
+			http.StatusForbidden:           commands.ExitCodeAuthentication,
+			http.StatusNotFound:            commands.ExitCodeNotFound,
+			http.StatusConflict:            commands.ExitCodeError,
+			http.StatusUnprocessableEntity: commands.ExitCodeConfig,
+			http.StatusServiceUnavailable:  commands.ExitCodeUnavailable,
+		}
+
+		for statusCode, exitCode := range exitCodes {
+			Expect(commands.ExitCode(responseError(statusCode))).To(Equal(exitCode), http.StatusText(statusCode))
+		}
+	})
+})
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+)
+
+// Execute runs the command like jhanda.CommandSet.Execute, but keeps the
+// error of the command, so that its exit code can be determined.
+func Execute(commandSet jhanda.CommandSet, command string, args []string) error {
+	cmd, ok := commandSet[command]
+	if !ok {
+		return ConfigError{Err: fmt.Errorf("unknown command: %s", command)}
+	}
+
+	for _, arg := range args {
+		if arg == "--help" || arg == "-h" || arg == "-help" {
+			return commandSet.Execute("help", []string{command})
+		}
+	}
+
+	err := cmd.Execute(args)
+	if err != nil {
+		return fmt.Errorf("could not execute %q: %w", command, err)
+	}
+
+	return nil
+}
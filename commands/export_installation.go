@@ -9,6 +9,7 @@ import (
 type ExportInstallation struct {
 	logger  logger
 	service exportInstallationService
+	results resultWriter
 	Options struct {
 		OutputFile      string `long:"output-file"      short:"o"  required:"true" description:"output path to write installation to"`
 		PollingInterval int    `long:"polling-interval" short:"pi"                 description:"interval (in seconds) at which to print status" default:"1"`
@@ -20,10 +21,11 @@ type exportInstallationService interface {
 	DownloadInstallationAssetCollection(outputFile string, pollingInterval int) error
 }
 
-func NewExportInstallation(service exportInstallationService, logger logger) ExportInstallation {
+func NewExportInstallation(service exportInstallationService, logger logger, results resultWriter) ExportInstallation {
 	return ExportInstallation{
 		logger:  logger,
 		service: service,
+		results: results,
 	}
 }
 
@@ -37,17 +39,19 @@ func (ei ExportInstallation) Usage() jhanda.Usage {
 
 func (ei ExportInstallation) Execute(args []string) error {
 	if _, err := jhanda.Parse(&ei.Options, args); err != nil {
-		return fmt.Errorf("could not parse export-installation flags: %s", err)
+		return configErrorf("could not parse export-installation flags: %w", err)
 	}
 
 	ei.logger.Printf("exporting installation")
 
 	err := ei.service.DownloadInstallationAssetCollection(ei.Options.OutputFile, ei.Options.PollingInterval)
 	if err != nil {
-		return fmt.Errorf("failed to export installation: %s", err)
+		return fmt.Errorf("failed to export installation: %w", err)
 	}
 
 	ei.logger.Printf("finished exporting installation")
 
-	return nil
+	return ei.results.WriteResult(ExportInstallationResult{
+		OutputFile: ei.Options.OutputFile,
+	})
 }
@@ -16,15 +16,17 @@ var _ = Describe("ExportInstallation", func() {
 	var (
 		fakeService *fakes.ExportInstallationService
 		logger      *fakes.Logger
+		results     *fakes.ResultWriter
 	)
 
 	BeforeEach(func() {
 		fakeService = &fakes.ExportInstallationService{}
 		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
 	})
 
 	It("exports the installation", func() {
-		command := commands.NewExportInstallation(fakeService, logger)
+		command := commands.NewExportInstallation(fakeService, logger, results)
 
 		err := command.Execute([]string{
 			"--output-file", "/path/to/output.zip",
@@ -44,11 +46,17 @@ var _ = Describe("ExportInstallation", func() {
 
 		format, v = logger.PrintfArgsForCall(1)
 		Expect(fmt.Sprintf(format, v...)).To(Equal("finished exporting installation"))
+
+		By("writing the output file as the result")
+		Expect(results.WriteResultCallCount()).To(Equal(1))
+		Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ExportInstallationResult{
+			OutputFile: "/path/to/output.zip",
+		}))
 	})
 
 	Context("when polling interval is specified", func() {
 		It("passes the value to the installation service", func() {
-			command := commands.NewExportInstallation(fakeService, logger)
+			command := commands.NewExportInstallation(fakeService, logger, results)
 
 			err := command.Execute([]string{
 				"--output-file", "/path/to/output.zip",
@@ -66,7 +74,7 @@ var _ = Describe("ExportInstallation", func() {
 	Context("failure cases", func() {
 		Context("when an unknown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewExportInstallation(fakeService, logger)
+				command := commands.NewExportInstallation(fakeService, logger, results)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse export-installation flags: flag provided but not defined: -badflag"))
 			})
@@ -74,7 +82,7 @@ var _ = Describe("ExportInstallation", func() {
 
 		Context("when output file is not provided", func() {
 			It("returns an error and prints out usage", func() {
-				command := commands.NewExportInstallation(fakeService, logger)
+				command := commands.NewExportInstallation(fakeService, logger, results)
 				err := command.Execute([]string{})
 				Expect(err).To(MatchError("could not parse export-installation flags: missing required flag \"--output-file\""))
 			})
@@ -82,7 +90,7 @@ var _ = Describe("ExportInstallation", func() {
 
 		Context("when the installation cannot be exported", func() {
 			It("returns an error", func() {
-				command := commands.NewExportInstallation(fakeService, logger)
+				command := commands.NewExportInstallation(fakeService, logger, results)
 				fakeService.DownloadInstallationAssetCollectionReturns(errors.New("some error"))
 
 				err := command.Execute([]string{"--output-file", "/some/path"})
@@ -93,7 +101,7 @@ var _ = Describe("ExportInstallation", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewExportInstallation(nil, nil)
+			command := commands.NewExportInstallation(nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This command will export the current installation of the target Ops Manager.",
 				ShortDescription: "exports the installation of the target Ops Manager",
@@ -0,0 +1,245 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	io "io"
+	sync "sync"
+)
+
+type LiveWriter struct {
+	BypassStub        func() io.Writer
+	bypassMutex       sync.RWMutex
+	bypassArgsForCall []struct {
+	}
+	bypassReturns struct {
+		result1 io.Writer
+	}
+	bypassReturnsOnCall map[int]struct {
+		result1 io.Writer
+	}
+	FlushStub        func() error
+	flushMutex       sync.RWMutex
+	flushArgsForCall []struct {
+	}
+	flushReturns struct {
+		result1 error
+	}
+	flushReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteStub        func([]byte) (int, error)
+	writeMutex       sync.RWMutex
+	writeArgsForCall []struct {
+		arg1 []byte
+	}
+	writeReturns struct {
+		result1 int
+		result2 error
+	}
+	writeReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *LiveWriter) Bypass() io.Writer {
+	fake.bypassMutex.Lock()
+	ret, specificReturn := fake.bypassReturnsOnCall[len(fake.bypassArgsForCall)]
+	fake.bypassArgsForCall = append(fake.bypassArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Bypass", []interface{}{})
+	fake.bypassMutex.Unlock()
+	if fake.BypassStub != nil {
+		return fake.BypassStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.bypassReturns
+	return fakeReturns.result1
+}
+
+func (fake *LiveWriter) BypassCallCount() int {
+	fake.bypassMutex.RLock()
+	defer fake.bypassMutex.RUnlock()
+	return len(fake.bypassArgsForCall)
+}
+
+func (fake *LiveWriter) BypassCalls(stub func() io.Writer) {
+	fake.bypassMutex.Lock()
+	defer fake.bypassMutex.Unlock()
+	fake.BypassStub = stub
+}
+
+func (fake *LiveWriter) BypassReturns(result1 io.Writer) {
+	fake.bypassMutex.Lock()
+	defer fake.bypassMutex.Unlock()
+	fake.BypassStub = nil
+	fake.bypassReturns = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *LiveWriter) BypassReturnsOnCall(i int, result1 io.Writer) {
+	fake.bypassMutex.Lock()
+	defer fake.bypassMutex.Unlock()
+	fake.BypassStub = nil
+	if fake.bypassReturnsOnCall == nil {
+		fake.bypassReturnsOnCall = make(map[int]struct {
+			result1 io.Writer
+		})
+	}
+	fake.bypassReturnsOnCall[i] = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *LiveWriter) Flush() error {
+	fake.flushMutex.Lock()
+	ret, specificReturn := fake.flushReturnsOnCall[len(fake.flushArgsForCall)]
+	fake.flushArgsForCall = append(fake.flushArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Flush", []interface{}{})
+	fake.flushMutex.Unlock()
+	if fake.FlushStub != nil {
+		return fake.FlushStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.flushReturns
+	return fakeReturns.result1
+}
+
+func (fake *LiveWriter) FlushCallCount() int {
+	fake.flushMutex.RLock()
+	defer fake.flushMutex.RUnlock()
+	return len(fake.flushArgsForCall)
+}
+
+func (fake *LiveWriter) FlushCalls(stub func() error) {
+	fake.flushMutex.Lock()
+	defer fake.flushMutex.Unlock()
+	fake.FlushStub = stub
+}
+
+func (fake *LiveWriter) FlushReturns(result1 error) {
+	fake.flushMutex.Lock()
+	defer fake.flushMutex.Unlock()
+	fake.FlushStub = nil
+	fake.flushReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *LiveWriter) FlushReturnsOnCall(i int, result1 error) {
+	fake.flushMutex.Lock()
+	defer fake.flushMutex.Unlock()
+	fake.FlushStub = nil
+	if fake.flushReturnsOnCall == nil {
+		fake.flushReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.flushReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *LiveWriter) Write(arg1 []byte) (int, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.writeMutex.Lock()
+	ret, specificReturn := fake.writeReturnsOnCall[len(fake.writeArgsForCall)]
+	fake.writeArgsForCall = append(fake.writeArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	fake.recordInvocation("Write", []interface{}{arg1Copy})
+	fake.writeMutex.Unlock()
+	if fake.WriteStub != nil {
+		return fake.WriteStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.writeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *LiveWriter) WriteCallCount() int {
+	fake.writeMutex.RLock()
+	defer fake.writeMutex.RUnlock()
+	return len(fake.writeArgsForCall)
+}
+
+func (fake *LiveWriter) WriteCalls(stub func([]byte) (int, error)) {
+	fake.writeMutex.Lock()
+	defer fake.writeMutex.Unlock()
+	fake.WriteStub = stub
+}
+
+func (fake *LiveWriter) WriteArgsForCall(i int) []byte {
+	fake.writeMutex.RLock()
+	defer fake.writeMutex.RUnlock()
+	argsForCall := fake.writeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *LiveWriter) WriteReturns(result1 int, result2 error) {
+	fake.writeMutex.Lock()
+	defer fake.writeMutex.Unlock()
+	fake.WriteStub = nil
+	fake.writeReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LiveWriter) WriteReturnsOnCall(i int, result1 int, result2 error) {
+	fake.writeMutex.Lock()
+	defer fake.writeMutex.Unlock()
+	fake.WriteStub = nil
+	if fake.writeReturnsOnCall == nil {
+		fake.writeReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.writeReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LiveWriter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.bypassMutex.RLock()
+	defer fake.bypassMutex.RUnlock()
+	fake.flushMutex.RLock()
+	defer fake.flushMutex.RUnlock()
+	fake.writeMutex.RLock()
+	defer fake.writeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *LiveWriter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,170 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	sync "sync"
+)
+
+type ResultWriter struct {
+	EnabledStub        func() bool
+	enabledMutex       sync.RWMutex
+	enabledArgsForCall []struct {
+	}
+	enabledReturns struct {
+		result1 bool
+	}
+	enabledReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	WriteResultStub        func(interface{}) error
+	writeResultMutex       sync.RWMutex
+	writeResultArgsForCall []struct {
+		arg1 interface{}
+	}
+	writeResultReturns struct {
+		result1 error
+	}
+	writeResultReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ResultWriter) Enabled() bool {
+	fake.enabledMutex.Lock()
+	ret, specificReturn := fake.enabledReturnsOnCall[len(fake.enabledArgsForCall)]
+	fake.enabledArgsForCall = append(fake.enabledArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Enabled", []interface{}{})
+	fake.enabledMutex.Unlock()
+	if fake.EnabledStub != nil {
+		return fake.EnabledStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.enabledReturns
+	return fakeReturns.result1
+}
+
+func (fake *ResultWriter) EnabledCallCount() int {
+	fake.enabledMutex.RLock()
+	defer fake.enabledMutex.RUnlock()
+	return len(fake.enabledArgsForCall)
+}
+
+func (fake *ResultWriter) EnabledCalls(stub func() bool) {
+	fake.enabledMutex.Lock()
+	defer fake.enabledMutex.Unlock()
+	fake.EnabledStub = stub
+}
+
+func (fake *ResultWriter) EnabledReturns(result1 bool) {
+	fake.enabledMutex.Lock()
+	defer fake.enabledMutex.Unlock()
+	fake.EnabledStub = nil
+	fake.enabledReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *ResultWriter) EnabledReturnsOnCall(i int, result1 bool) {
+	fake.enabledMutex.Lock()
+	defer fake.enabledMutex.Unlock()
+	fake.EnabledStub = nil
+	if fake.enabledReturnsOnCall == nil {
+		fake.enabledReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.enabledReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *ResultWriter) WriteResult(arg1 interface{}) error {
+	fake.writeResultMutex.Lock()
+	ret, specificReturn := fake.writeResultReturnsOnCall[len(fake.writeResultArgsForCall)]
+	fake.writeResultArgsForCall = append(fake.writeResultArgsForCall, struct {
+		arg1 interface{}
+	}{arg1})
+	fake.recordInvocation("WriteResult", []interface{}{arg1})
+	fake.writeResultMutex.Unlock()
+	if fake.WriteResultStub != nil {
+		return fake.WriteResultStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.writeResultReturns
+	return fakeReturns.result1
+}
+
+func (fake *ResultWriter) WriteResultCallCount() int {
+	fake.writeResultMutex.RLock()
+	defer fake.writeResultMutex.RUnlock()
+	return len(fake.writeResultArgsForCall)
+}
+
+func (fake *ResultWriter) WriteResultCalls(stub func(interface{}) error) {
+	fake.writeResultMutex.Lock()
+	defer fake.writeResultMutex.Unlock()
+	fake.WriteResultStub = stub
+}
+
+func (fake *ResultWriter) WriteResultArgsForCall(i int) interface{} {
+	fake.writeResultMutex.RLock()
+	defer fake.writeResultMutex.RUnlock()
+	argsForCall := fake.writeResultArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ResultWriter) WriteResultReturns(result1 error) {
+	fake.writeResultMutex.Lock()
+	defer fake.writeResultMutex.Unlock()
+	fake.WriteResultStub = nil
+	fake.writeResultReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ResultWriter) WriteResultReturnsOnCall(i int, result1 error) {
+	fake.writeResultMutex.Lock()
+	defer fake.writeResultMutex.Unlock()
+	fake.WriteResultStub = nil
+	if fake.writeResultReturnsOnCall == nil {
+		fake.writeResultReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeResultReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ResultWriter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.enabledMutex.RLock()
+	defer fake.enabledMutex.RUnlock()
+	fake.writeResultMutex.RLock()
+	defer fake.writeResultMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ResultWriter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
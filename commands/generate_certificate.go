@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/pivotal-cf/om/api"
 	"strings"
@@ -11,6 +12,7 @@ import (
 type GenerateCertificate struct {
 	service generateCertificateService
 	logger  logger
+	results resultWriter
 	Options struct {
 		Domains string `long:"domains" short:"d" required:"true" description:"domains to generate certificates, delimited by comma, can include wildcard domains"`
 	}
@@ -21,13 +23,13 @@ type generateCertificateService interface {
 	GenerateCertificate(domains api.DomainsInput) (string, error)
 }
 
-func NewGenerateCertificate(service generateCertificateService, logger logger) GenerateCertificate {
-	return GenerateCertificate{service: service, logger: logger}
+func NewGenerateCertificate(service generateCertificateService, logger logger, results resultWriter) GenerateCertificate {
+	return GenerateCertificate{service: service, logger: logger, results: results}
 }
 
 func (g GenerateCertificate) Execute(args []string) error {
 	if _, err := jhanda.Parse(&g.Options, args); err != nil {
-		return fmt.Errorf("could not parse generate-certificate flags: %s", err)
+		return configErrorf("could not parse generate-certificate flags: %w", err)
 	}
 
 	domains := strings.Split(g.Options.Domains, ",")
@@ -44,7 +46,14 @@ func (g GenerateCertificate) Execute(args []string) error {
 	}
 
 	g.logger.Printf(output)
-	return nil
+
+	var result GenerateCertificateResult
+	err = json.Unmarshal([]byte(output), &result)
+	if err != nil {
+		return fmt.Errorf("could not parse generated certificate: %w", err)
+	}
+
+	return g.results.WriteResult(result)
 }
 
 func (g GenerateCertificate) Usage() jhanda.Usage {
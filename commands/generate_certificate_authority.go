@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/presenters"
@@ -12,7 +10,7 @@ type GenerateCertificateAuthority struct {
 	service   generateCertificateAuthorityService
 	presenter presenters.FormattedPresenter
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -27,7 +25,7 @@ func NewGenerateCertificateAuthority(service generateCertificateAuthorityService
 
 func (g GenerateCertificateAuthority) Execute(args []string) error {
 	if _, err := jhanda.Parse(&g.Options, args); err != nil {
-		return fmt.Errorf("could not parse generate-certificate-authority flags: %s", err)
+		return configErrorf("could not parse generate-certificate-authority flags: %w", err)
 	}
 
 	certificateAuthority, err := g.service.GenerateCertificateAuthority()
@@ -35,10 +33,12 @@ func (g GenerateCertificateAuthority) Execute(args []string) error {
 		return err
 	}
 
-	g.presenter.SetFormat(g.Options.Format)
-	g.presenter.PresentCertificateAuthority(certificateAuthority)
+	err = g.presenter.SetFormat(g.Options.Format)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return g.presenter.PresentCertificateAuthority(certificateAuthority)
 }
 
 func (g GenerateCertificateAuthority) Usage() jhanda.Usage {
@@ -15,13 +15,16 @@ var _ = Describe("GenerateCertificate", func() {
 	var (
 		fakeService *fakes.GenerateCertificateService
 		fakeLogger  *fakes.Logger
+		results     *fakes.ResultWriter
 		command     commands.GenerateCertificate
 	)
 
 	BeforeEach(func() {
 		fakeService = &fakes.GenerateCertificateService{}
 		fakeLogger = &fakes.Logger{}
-		command = commands.NewGenerateCertificate(fakeService, fakeLogger)
+		results = &fakes.ResultWriter{}
+		fakeService.GenerateCertificateReturns(`{"certificate": "some-certificate", "key": "some-key"}`, nil)
+		command = commands.NewGenerateCertificate(fakeService, fakeLogger, results)
 	})
 
 	Describe("Execute", func() {
@@ -35,8 +38,6 @@ var _ = Describe("GenerateCertificate", func() {
 		})
 
 		It("prints a json output for the generated certificate", func() {
-			fakeService.GenerateCertificateReturns(`some-json-response`, nil)
-
 			err := command.Execute([]string{
 				"--domains", "*.apps.example.com, *.sys.example.com",
 			})
@@ -44,7 +45,20 @@ var _ = Describe("GenerateCertificate", func() {
 
 			Expect(fakeLogger.PrintfCallCount()).To(Equal(1))
 			format, content := fakeLogger.PrintfArgsForCall(0)
-			Expect(fmt.Sprintf(format, content...)).To(Equal(`some-json-response`))
+			Expect(fmt.Sprintf(format, content...)).To(Equal(`{"certificate": "some-certificate", "key": "some-key"}`))
+		})
+
+		It("writes the certificate and key as the result", func() {
+			err := command.Execute([]string{
+				"--domains", "*.apps.example.com",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.GenerateCertificateResult{
+				Certificate: "some-certificate",
+				Key:         "some-key",
+			}))
 		})
 
 		Context("failure cases", func() {
@@ -55,6 +69,15 @@ var _ = Describe("GenerateCertificate", func() {
 				})
 			})
 
+			It("returns an error when the certificate cannot be parsed", func() {
+				fakeService.GenerateCertificateReturns(`some-json-response`, nil)
+
+				err := command.Execute([]string{
+					"--domains", "*.apps.example.com",
+				})
+				Expect(err).To(MatchError(ContainSubstring("could not parse generated certificate")))
+			})
+
 			It("returns an error when the service fails to generate a certificate", func() {
 				fakeService.GenerateCertificateReturns(`some-json-response`, errors.New("failed to generate certificate"))
 
@@ -3,14 +3,17 @@ package commands
 import (
 	"fmt"
 
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 )
 
 type ImportInstallation struct {
+	varsStore  boshtpl.Variables
 	multipart  multipart
 	logger     logger
 	service    importInstallationService
+	results    resultWriter
 	passphrase string
 	Options    struct {
 		ConfigFile      string `long:"config"                short:"c"                  description:"path to yml file for configuration (keys must match the following command line flags)"`
@@ -25,12 +28,14 @@ type importInstallationService interface {
 	EnsureAvailability(input api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error)
 }
 
-func NewImportInstallation(multipart multipart, service importInstallationService, passphrase string, logger logger) ImportInstallation {
+func NewImportInstallation(multipart multipart, service importInstallationService, passphrase string, logger logger, results resultWriter, varsStore boshtpl.Variables) ImportInstallation {
 	return ImportInstallation{
 		multipart:  multipart,
 		logger:     logger,
 		service:    service,
+		results:    results,
 		passphrase: passphrase,
+		varsStore:  varsStore,
 	}
 }
 
@@ -47,36 +52,39 @@ func (ii ImportInstallation) Execute(args []string) error {
 		return fmt.Errorf("the global decryption-passphrase argument is required for this command")
 	}
 
-	err := loadConfigFile(args, &ii.Options, nil)
+	err := loadConfigFile(args, &ii.Options, nil, ii.varsStore)
 	if err != nil {
-		return fmt.Errorf("could not parse import-installation flags: %s", err)
+		return configErrorf("could not parse import-installation flags: %w", err)
 	}
 
 	ensureAvailabilityOutput, err := ii.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 	if err != nil {
-		return fmt.Errorf("could not check Ops Manager status: %s", err)
+		return fmt.Errorf("could not check Ops Manager status: %w", err)
 	}
 
 	if ensureAvailabilityOutput.Status != api.EnsureAvailabilityStatusUnstarted {
 		ii.logger.Printf("Ops Manager is already configured")
-		return nil
+		return ii.results.WriteResult(ImportInstallationResult{
+			Installation:      ii.Options.Installation,
+			AlreadyConfigured: true,
+		})
 	}
 
 	ii.logger.Printf("processing installation")
 
 	err = ii.multipart.AddFile("installation[file]", ii.Options.Installation)
 	if err != nil {
-		return fmt.Errorf("failed to load installation: %s", err)
+		return fmt.Errorf("failed to load installation: %w", err)
 	}
 
 	err = ii.multipart.AddField("passphrase", ii.passphrase)
 	if err != nil {
-		return fmt.Errorf("failed to insert passphrase: %s", err)
+		return fmt.Errorf("failed to insert passphrase: %w", err)
 	}
 
 	submission := ii.multipart.Finalize()
 	if err != nil {
-		return fmt.Errorf("failed to create multipart form: %s", err)
+		return fmt.Errorf("failed to create multipart form: %w", err)
 	}
 
 	ii.logger.Printf("beginning installation import to Ops Manager")
@@ -88,18 +96,20 @@ func (ii ImportInstallation) Execute(args []string) error {
 		PollingInterval: ii.Options.PollingInterval,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to import installation: %s", err)
+		return fmt.Errorf("failed to import installation: %w", err)
 	}
 
 	ii.logger.Printf("waiting for import to complete, this should take only a couple minutes...")
 	for ensureAvailabilityOutput.Status != api.EnsureAvailabilityStatusComplete {
 		ensureAvailabilityOutput, err = ii.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 		if err != nil {
-			return fmt.Errorf("could not check Ops Manager Status: %s", err)
+			return fmt.Errorf("could not check Ops Manager Status: %w", err)
 		}
 	}
 
 	ii.logger.Printf("finished import")
 
-	return nil
+	return ii.results.WriteResult(ImportInstallationResult{
+		Installation: ii.Options.Installation,
+	})
 }
@@ -22,12 +22,14 @@ var _ = Describe("ImportInstallation", func() {
 		fakeService *fakes.ImportInstallationService
 		multipart   *fakes.Multipart
 		logger      *fakes.Logger
+		results     *fakes.ResultWriter
 	)
 
 	BeforeEach(func() {
 		multipart = &fakes.Multipart{}
 		fakeService = &fakes.ImportInstallationService{}
 		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
 	})
 
 	It("imports an installation", func() {
@@ -50,7 +52,7 @@ var _ = Describe("ImportInstallation", func() {
 			return eaOutputs[fakeService.EnsureAvailabilityCallCount()-1], nil
 		}
 
-		command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger)
+		command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger, results, nil)
 
 		err := command.Execute([]string{
 			"--installation", "/path/to/some-installation",
@@ -86,6 +88,11 @@ var _ = Describe("ImportInstallation", func() {
 
 		format, v = logger.PrintfArgsForCall(3)
 		Expect(fmt.Sprintf(format, v...)).To(Equal("finished import"))
+
+		Expect(results.WriteResultCallCount()).To(Equal(1))
+		Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ImportInstallationResult{
+			Installation: "/path/to/some-installation",
+		}))
 	})
 
 	Context("when polling interval is specified", func() {
@@ -109,7 +116,7 @@ var _ = Describe("ImportInstallation", func() {
 				return eaOutputs[fakeService.EnsureAvailabilityCallCount()-1], nil
 			}
 
-			command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger)
+			command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger, results, nil)
 
 			err := command.Execute([]string{
 				"--installation", "/path/to/some-installation",
@@ -129,7 +136,7 @@ var _ = Describe("ImportInstallation", func() {
 				Status: api.EnsureAvailabilityStatusComplete,
 			}, nil)
 
-			command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger)
+			command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger, results, nil)
 
 			err := command.Execute([]string{
 				"--installation", "/path/to/some-installation",
@@ -139,6 +146,11 @@ var _ = Describe("ImportInstallation", func() {
 			format, v := logger.PrintfArgsForCall(0)
 			Expect(fmt.Sprintf(format, v...)).To(Equal("Ops Manager is already configured"))
 			Expect(fakeService.EnsureAvailabilityCallCount()).To(Equal(1))
+
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.ImportInstallationResult{
+				Installation:      "/path/to/some-installation",
+				AlreadyConfigured: true,
+			}))
 		})
 	})
 
@@ -177,7 +189,7 @@ installation: /path/to/some-installation
 				return eaOutputs[fakeService.EnsureAvailabilityCallCount()-1], nil
 			}
 
-			command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger)
+			command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger, results, nil)
 
 			err := command.Execute([]string{
 				"--config", configFile.Name(),
@@ -214,7 +226,7 @@ installation: /path/to/some-installation
 				return eaOutputs[fakeService.EnsureAvailabilityCallCount()-1], nil
 			}
 
-			command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger)
+			command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger, results, nil)
 
 			err := command.Execute([]string{
 				"--config", configFile.Name(),
@@ -236,7 +248,7 @@ installation: /path/to/some-installation
 	Context("failure cases", func() {
 		Context("when the global decryption-passphrase is not provided", func() {
 			It("returns an error", func() {
-				command := commands.NewImportInstallation(multipart, fakeService, "", logger)
+				command := commands.NewImportInstallation(multipart, fakeService, "", logger, results, nil)
 				err := command.Execute([]string{})
 				Expect(err).To(MatchError("the global decryption-passphrase argument is required for this command"))
 			})
@@ -244,7 +256,7 @@ installation: /path/to/some-installation
 
 		Context("when an unknown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewImportInstallation(multipart, fakeService, "passphrase", logger)
+				command := commands.NewImportInstallation(multipart, fakeService, "passphrase", logger, results, nil)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse import-installation flags: flag provided but not defined: -badflag"))
 			})
@@ -252,7 +264,7 @@ installation: /path/to/some-installation
 
 		Context("when config file cannot be opened", func() {
 			It("returns an error", func() {
-				command := commands.NewConfigureSAMLAuthentication(&fakes.ConfigureAuthenticationService{}, &fakes.Logger{})
+				command := commands.NewConfigureSAMLAuthentication(&fakes.ConfigureAuthenticationService{}, &fakes.Logger{}, &fakes.ResultWriter{}, nil)
 				err := command.Execute([]string{"--config", "something"})
 				Expect(err).To(MatchError("could not parse configure-saml-authentication flags: could not load the config file: open something: no such file or directory"))
 
@@ -261,7 +273,7 @@ installation: /path/to/some-installation
 
 		Context("when the --installation flag is missing", func() {
 			It("returns an error", func() {
-				command := commands.NewImportInstallation(multipart, fakeService, "passphrase", logger)
+				command := commands.NewImportInstallation(multipart, fakeService, "passphrase", logger, results, nil)
 				err := command.Execute([]string{})
 				Expect(err).To(MatchError("could not parse import-installation flags: missing required flag \"--installation\""))
 			})
@@ -270,7 +282,7 @@ installation: /path/to/some-installation
 		Context("when the ensure_availability endpoint returns an error", func() {
 			It("returns an error", func() {
 				fakeService.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{}, errors.New("some error"))
-				command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger)
+				command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger, results, nil)
 				err := command.Execute([]string{"--installation", "/some/path"})
 				Expect(err).To(MatchError("could not check Ops Manager status: some error"))
 			})
@@ -281,7 +293,7 @@ installation: /path/to/some-installation
 				fakeService.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{
 					Status: api.EnsureAvailabilityStatusUnstarted,
 				}, nil)
-				command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger)
+				command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger, results, nil)
 				multipart.AddFileReturns(errors.New("bad file"))
 
 				err := command.Execute([]string{"--installation", "/some/path"})
@@ -294,7 +306,7 @@ installation: /path/to/some-installation
 				fakeService.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{
 					Status: api.EnsureAvailabilityStatusUnstarted,
 				}, nil)
-				command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger)
+				command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger, results, nil)
 				fakeService.UploadInstallationAssetCollectionReturns(errors.New("some installation error"))
 
 				err := command.Execute([]string{"--installation", "/some/path"})
@@ -305,7 +317,7 @@ installation: /path/to/some-installation
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewImportInstallation(nil, nil, "", nil)
+			command := commands.NewImportInstallation(nil, nil, "", nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This unauthenticated command attempts to import an installation to the Ops Manager targeted.",
 				ShortDescription: "imports a given installation to the Ops Manager targeted",
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/models"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type Info struct {
+	presenter presenters.FormattedPresenter
+	service   infoService
+	Options   struct {
+		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
+	}
+}
+
+//go:generate counterfeiter -o ./fakes/info_service.go --fake-name InfoService . infoService
+type infoService interface {
+	Info() (api.Info, error)
+	GetDiagnosticReport() (api.DiagnosticReport, error)
+}
+
+func NewInfo(presenter presenters.FormattedPresenter, service infoService) Info {
+	return Info{
+		presenter: presenter,
+		service:   service,
+	}
+}
+
+func (i Info) Execute(args []string) error {
+	if _, err := jhanda.Parse(&i.Options, args); err != nil {
+		return configErrorf("could not parse info flags: %w", err)
+	}
+
+	info, err := i.service.Info()
+	if err != nil {
+		return fmt.Errorf("could not retrieve info from targetted ops manager: %w", err)
+	}
+
+	opsManagerInfo := models.OpsManagerInfo{
+		Version:  info.Version,
+		Features: []models.Feature{},
+	}
+
+	report, err := i.service.GetDiagnosticReport()
+	switch err.(type) {
+	case nil:
+		opsManagerInfo.InfrastructureType = report.InfrastructureType
+	case api.DiagnosticReportUnavailable:
+		// the infrastructure type is left empty while the report is unavailable
+	default:
+		return fmt.Errorf("failed to retrieve diagnostic report: %w", err)
+	}
+
+	for _, capability := range api.Capabilities {
+		// an unparseable version supports none of the features
+		supported, _ := info.Supports(capability.Name)
+
+		opsManagerInfo.Features = append(opsManagerInfo.Features, models.Feature{
+			Name:           capability.Name,
+			Description:    capability.Description,
+			MinimumVersion: capability.MinimumVersion(),
+			Supported:      supported,
+		})
+	}
+
+	err = i.presenter.SetFormat(i.Options.Format)
+	if err != nil {
+		return err
+	}
+
+	return i.presenter.PresentInfo(opsManagerInfo)
+}
+
+func (i Info) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This authenticated command prints the version and infrastructure type of Ops Manager, and which of the features that om depends on it supports.",
+		ShortDescription: "prints the version and supported features of Ops Manager",
+		Flags:            i.Options,
+	}
+}
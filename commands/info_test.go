@@ -0,0 +1,137 @@
+package commands_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/models"
+	presenterfakes "github.com/pivotal-cf/om/presenters/fakes"
+)
+
+var _ = Describe("Info", func() {
+	var (
+		presenter   *presenterfakes.FormattedPresenter
+		fakeService *fakes.InfoService
+		command     commands.Info
+	)
+
+	BeforeEach(func() {
+		presenter = &presenterfakes.FormattedPresenter{}
+		fakeService = &fakes.InfoService{}
+		command = commands.NewInfo(presenter, fakeService)
+
+		fakeService.InfoReturns(api.Info{Version: "2.1-build.212"}, nil)
+		fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{InfrastructureType: "vsphere"}, nil)
+	})
+
+	Describe("Execute", func() {
+		It("presents the version, infrastructure type and features of Ops Manager", func() {
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(presenter.SetFormatArgsForCall(0)).To(Equal("table"))
+			Expect(presenter.PresentInfoCallCount()).To(Equal(1))
+
+			info := presenter.PresentInfoArgsForCall(0)
+			Expect(info.Version).To(Equal("2.1-build.212"))
+			Expect(info.InfrastructureType).To(Equal("vsphere"))
+			Expect(info.Features).To(HaveLen(len(api.Capabilities)))
+			Expect(info.Features).To(ContainElement(models.Feature{
+				Name:           api.CapabilitySelectiveDeploys,
+				Description:    "deploy only some of the products (apply-changes --product-name)",
+				MinimumVersion: "2.2",
+				Supported:      false,
+			}))
+		})
+
+		It("reports the features of newer versions as supported", func() {
+			fakeService.InfoReturns(api.Info{Version: "2.3-build.79"}, nil)
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, feature := range presenter.PresentInfoArgsForCall(0).Features {
+				Expect(feature.Supported).To(BeTrue(), feature.Name)
+			}
+		})
+
+		It("leaves out the infrastructure type when the diagnostic report is unavailable", func() {
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, api.DiagnosticReportUnavailable{})
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(presenter.PresentInfoArgsForCall(0).InfrastructureType).To(BeEmpty())
+		})
+
+		It("does not support any feature when the version cannot be parsed", func() {
+			fakeService.InfoReturns(api.Info{Version: "unknown"}, nil)
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, feature := range presenter.PresentInfoArgsForCall(0).Features {
+				Expect(feature.Supported).To(BeFalse(), feature.Name)
+			}
+		})
+
+		Context("when the format flag is provided", func() {
+			It("sets the format on the presenter", func() {
+				err := command.Execute([]string{"--format", "json"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(presenter.SetFormatArgsForCall(0)).To(Equal("json"))
+			})
+		})
+
+		Context("failure cases", func() {
+			Context("when an unknown flag is passed", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--unknown-flag"})
+					Expect(err).To(MatchError("could not parse info flags: flag provided but not defined: -unknown-flag"))
+				})
+			})
+
+			Context("when the info cannot be retrieved", func() {
+				It("returns an error", func() {
+					fakeService.InfoReturns(api.Info{}, errors.New("some error"))
+
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("could not retrieve info from targetted ops manager: some error"))
+				})
+			})
+
+			Context("when the diagnostic report cannot be retrieved", func() {
+				It("returns an error", func() {
+					fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("some error"))
+
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("failed to retrieve diagnostic report: some error"))
+				})
+			})
+
+			Context("when the info cannot be presented", func() {
+				It("returns an error", func() {
+					presenter.PresentInfoReturns(errors.New("could not execute template: some error"))
+
+					err := command.Execute([]string{"--format", "template={{.missing}}"})
+					Expect(err).To(MatchError("could not execute template: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This authenticated command prints the version and infrastructure type of Ops Manager, and which of the features that om depends on it supports.",
+				ShortDescription: "prints the version and supported features of Ops Manager",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
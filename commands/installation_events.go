@@ -0,0 +1,397 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	stepStartedPattern  = regexp.MustCompile(`^===== (.+ UTC) Running "(.*)"$`)
+	stepFinishedPattern = regexp.MustCompile(`^===== (.+ UTC) Finished "(.*)"; Duration: (\S+); Exit Status: (\d+)$`)
+	taskStagePattern    = regexp.MustCompile(`^Task (\d+) \| (\d\d:\d\d:\d\d) \| ([^:]+): (.*?)(?: \((\d\d:\d\d:\d\d)\))?$`)
+	taskStageError      = regexp.MustCompile(`^\s+L Error: (.*)$`)
+	taskFinishedPattern = regexp.MustCompile(`^Task (\d+) (done|error|cancelled)$`)
+	deploymentPattern   = regexp.MustCompile(`(?:--deployment=|-d )(\S+)`)
+	instancePattern     = regexp.MustCompile(`^([\w-]+)/([\w-]+)`)
+)
+
+// InstallationEvent is a single entry of the BOSH event log embedded in the
+// installation logs of Ops Manager.
+type InstallationEvent struct {
+	Type     string `json:"type"`
+	Time     string `json:"time,omitempty"`
+	Step     string `json:"step,omitempty"`
+	Product  string `json:"product,omitempty"`
+	Task     int    `json:"task,omitempty"`
+	Stage    string `json:"stage,omitempty"`
+	Job      string `json:"job,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	State    string `json:"state"`
+	Duration string `json:"duration,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// InstallationEventWriter parses the installation logs into events and hands
+// each of them to a presenter. Like LogWriter, it is given the full logs on
+// every flush and only handles what it has not seen before. Incomplete lines
+// are held back until the rest of the line has been received.
+type InstallationEventWriter struct {
+	present func(InstallationEvent) error
+	offset  int
+	current InstallationEvent
+}
+
+func NewInstallationEventWriter(present func(InstallationEvent) error) *InstallationEventWriter {
+	return &InstallationEventWriter{
+		present: present,
+	}
+}
+
+func (w *InstallationEventWriter) Flush(logs string) error {
+	if w.offset >= len(logs) {
+		return nil
+	}
+
+	unread := logs[w.offset:]
+	end := strings.LastIndex(unread, "\n")
+	if end < 0 {
+		return nil
+	}
+
+	for _, line := range strings.Split(unread[:end], "\n") {
+		event, ok := w.parse(strings.TrimRight(line, "\r"))
+		if !ok {
+			continue
+		}
+
+		err := w.present(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.offset += end + 1
+
+	return nil
+}
+
+// Finish handles the logs like Flush, and then the line that is still held
+// back, as no more of it will be received once the installation has finished.
+func (w *InstallationEventWriter) Finish(logs string) error {
+	err := w.Flush(logs)
+	if err != nil {
+		return err
+	}
+
+	if w.offset >= len(logs) {
+		return nil
+	}
+
+	line := logs[w.offset:]
+	w.offset = len(logs)
+
+	event, ok := w.parse(strings.TrimRight(line, "\r"))
+	if !ok {
+		return nil
+	}
+
+	return w.present(event)
+}
+
+func (w *InstallationEventWriter) parse(line string) (InstallationEvent, bool) {
+	if matches := stepFinishedPattern.FindStringSubmatch(line); matches != nil {
+		state := "succeeded"
+		if matches[4] != "0" {
+			state = "failed"
+		}
+
+		event := InstallationEvent{
+			Type:     "step",
+			Time:     matches[1],
+			Step:     stepName(matches[2]),
+			Product:  deploymentName(matches[2]),
+			State:    state,
+			Duration: matches[3],
+		}
+		if state == "failed" {
+			event.Message = fmt.Sprintf("exit status %s", matches[4])
+		}
+		w.current = InstallationEvent{}
+
+		return event, true
+	}
+
+	if matches := stepStartedPattern.FindStringSubmatch(line); matches != nil {
+		w.current = InstallationEvent{
+			Type:    "step",
+			Time:    matches[1],
+			Step:    stepName(matches[2]),
+			Product: deploymentName(matches[2]),
+			State:   "running",
+		}
+
+		return w.current, true
+	}
+
+	if matches := taskStagePattern.FindStringSubmatch(line); matches != nil {
+		task, _ := strconv.Atoi(matches[1])
+		event := InstallationEvent{
+			Type:     "stage",
+			Time:     matches[2],
+			Step:     w.current.Step,
+			Product:  w.current.Product,
+			Task:     task,
+			Stage:    matches[3],
+			State:    "started",
+			Duration: matches[5],
+			Message:  matches[4],
+		}
+
+		if matches[3] == "Error" {
+			event.State = "error"
+		} else if matches[5] != "" {
+			event.State = "finished"
+		}
+
+		lowerStage := strings.ToLower(matches[3])
+		if strings.Contains(lowerStage, "instance") || strings.Contains(lowerStage, "vms") {
+			if instance := instancePattern.FindStringSubmatch(matches[4]); instance != nil {
+				event.Job = instance[1]
+				event.Instance = instance[2]
+			}
+		}
+
+		w.current.Task = task
+		w.current.Stage = event.Stage
+		w.current.Job = event.Job
+		w.current.Instance = event.Instance
+
+		return event, true
+	}
+
+	if matches := taskStageError.FindStringSubmatch(line); matches != nil {
+		return InstallationEvent{
+			Type:     "stage",
+			Step:     w.current.Step,
+			Product:  w.current.Product,
+			Task:     w.current.Task,
+			Stage:    w.current.Stage,
+			Job:      w.current.Job,
+			Instance: w.current.Instance,
+			State:    "error",
+			Message:  matches[1],
+		}, true
+	}
+
+	if matches := taskFinishedPattern.FindStringSubmatch(line); matches != nil {
+		task, _ := strconv.Atoi(matches[1])
+		return InstallationEvent{
+			Type:    "task",
+			Step:    w.current.Step,
+			Product: w.current.Product,
+			Task:    task,
+			State:   matches[2],
+		}, true
+	}
+
+	return InstallationEvent{}, false
+}
+
+// stepName returns the bosh subcommand of a step, e.g. "deploy" for
+// "/usr/local/bin/bosh --environment=10.0.0.10 --deployment=cf-abc deploy /path/to/manifest.yml".
+func stepName(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return command
+	}
+
+	for _, field := range fields[1:] {
+		if !strings.HasPrefix(field, "-") {
+			return field
+		}
+	}
+
+	return filepath.Base(fields[0])
+}
+
+func deploymentName(command string) string {
+	matches := deploymentPattern.FindStringSubmatch(command)
+	if matches == nil {
+		return ""
+	}
+
+	return matches[1]
+}
+
+func presentInstallationEventJSON(logger logger) func(InstallationEvent) error {
+	return func(event InstallationEvent) error {
+		contents, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		logger.Printf("%s", contents)
+		return nil
+	}
+}
+
+// presentInstallationProgress prints one line per step and per finished stage,
+// so that a long running installation can be followed at a glance.
+func presentInstallationProgress(logger logger) func(InstallationEvent) error {
+	return func(event InstallationEvent) error {
+		step := stepLabel(event)
+
+		switch {
+		case event.Type == "step" && event.State == "running":
+			logger.Printf("==> %s (started %s)", step, event.Time)
+		case event.Type == "step" && event.State == "succeeded":
+			logger.Printf("<== %s succeeded in %s", step, event.Duration)
+		case event.Type == "step":
+			logger.Printf("<== FAILED %s in %s (%s)", step, event.Duration, event.Message)
+		case event.Type == "stage" && event.State == "finished":
+			logger.Printf("    task %d | %s: %s (%s)", event.Task, event.Stage, event.Message, event.Duration)
+		case event.Type == "stage" && event.State == "error":
+			logger.Printf("    FAILED task %d | %s: %s", event.Task, event.Stage, event.Message)
+		case event.Type == "task" && event.State != "done":
+			logger.Printf("    FAILED task %d | %s", event.Task, event.State)
+		}
+
+		return nil
+	}
+}
+
+func stepLabel(event InstallationEvent) string {
+	if event.Product == "" {
+		return event.Step
+	}
+
+	return fmt.Sprintf("%s %s", event.Step, event.Product)
+}
+
+//go:generate counterfeiter -o ./fakes/live_writer.go --fake-name LiveWriter . liveWriter
+type liveWriter interface {
+	io.Writer
+	Flush() error
+	Bypass() io.Writer
+}
+
+// InstallationProgress prints the lines of presentInstallationProgress above a
+// status line with the step that is running and how long it has been running
+// for. The status line is redrawn every interval until Close is called.
+type InstallationProgress struct {
+	*InstallationEventWriter
+	liveWriter liveWriter
+	now        func() time.Time
+
+	mutex   sync.Mutex
+	step    string
+	started time.Time
+	ticker  *time.Ticker
+	done    chan struct{}
+}
+
+func NewInstallationProgress(liveWriter liveWriter, interval time.Duration) *InstallationProgress {
+	p := &InstallationProgress{
+		liveWriter: liveWriter,
+		now:        time.Now,
+		ticker:     time.NewTicker(interval),
+		done:       make(chan struct{}),
+	}
+
+	present := presentInstallationProgress(log.New(lineClearingWriter{liveWriter.Bypass()}, "", 0))
+	p.InstallationEventWriter = NewInstallationEventWriter(func(event InstallationEvent) error {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+
+		err := present(event)
+		if err != nil {
+			return err // un-tested
+		}
+
+		if event.Type == "step" {
+			p.step = ""
+			if event.State == "running" {
+				p.step = stepLabel(event)
+				p.started = p.stepStartedAt(event.Time)
+			}
+		}
+
+		return p.redraw()
+	})
+
+	go p.tick()
+
+	return p
+}
+
+// Close stops redrawing the status line.
+func (p *InstallationProgress) Close() error {
+	p.ticker.Stop()
+	close(p.done)
+
+	return nil
+}
+
+func (p *InstallationProgress) tick() {
+	for {
+		select {
+		case <-p.ticker.C:
+			p.mutex.Lock()
+			p.redraw()
+			p.mutex.Unlock()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// redraw is called with the mutex held. The lines of the finished steps clear
+// the status line, so it is only drawn while a step is running.
+func (p *InstallationProgress) redraw() error {
+	if p.step == "" {
+		return nil
+	}
+
+	elapsed := p.now().Sub(p.started).Round(time.Second)
+	fmt.Fprintf(p.liveWriter, "%s... %s running for %s\n", clearLine, p.step, elapsed)
+
+	return p.liveWriter.Flush()
+}
+
+// stepStartedAt returns when the step started according to the logs, so that
+// the elapsed time is right when following an installation that was started
+// earlier, and the current time when it cannot be parsed.
+func (p *InstallationProgress) stepStartedAt(t string) time.Time {
+	started, err := time.Parse("2006-01-02 15:04:05 MST", t)
+	if err != nil {
+		return p.now()
+	}
+
+	return started
+}
+
+// clearLine is the escape sequence that erases the line of the cursor. The
+// live writer moves the cursor back onto the status line without erasing it,
+// so whatever replaces the status line erases it first.
+const clearLine = "\x1b[2K"
+
+type lineClearingWriter struct {
+	io.Writer
+}
+
+func (w lineClearingWriter) Write(p []byte) (int, error) {
+	_, err := io.WriteString(w.Writer, clearLine)
+	if err != nil {
+		return 0, err // un-tested
+	}
+
+	return w.Writer.Write(p)
+}
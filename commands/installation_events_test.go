@@ -0,0 +1,100 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/pivotal-cf/om/commands"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const installationLogs = `===== 2018-06-12 20:32:57 UTC Running "/usr/local/bin/bosh --no-color --non-interactive --tty --environment=10.0.0.10 --deployment=cf-abc123 deploy /var/tempest/workspaces/default/deployments/cf-abc123.yml"
+Using environment '10.0.0.10' as client 'ops_manager'
+
+Task 29 | 20:33:01 | Preparing deployment: Preparing deployment (00:00:04)
+Task 29 | 20:33:05 | Updating instance router: router/1d2e3f (0) (canary)
+Task 29 | 20:38:06 | Updating instance router: router/1d2e3f (0) (canary) (00:05:01)
+                   L Error: 'router/1d2e3f (0)' is not running after update.
+Task 29 | 20:38:06 | Error: 'router/1d2e3f (0)' is not running after update.
+
+Task 29 error
+===== 2018-06-12 20:38:07 UTC Finished "/usr/local/bin/bosh --no-color --non-interactive --tty --environment=10.0.0.10 --deployment=cf-abc123 deploy /var/tempest/workspaces/default/deployments/cf-abc123.yml"; Duration: 310s; Exit Status: 1
+`
+
+var _ = Describe("InstallationEventWriter", func() {
+	var (
+		events []commands.InstallationEvent
+		writer *commands.InstallationEventWriter
+	)
+
+	BeforeEach(func() {
+		events = nil
+		writer = commands.NewInstallationEventWriter(func(event commands.InstallationEvent) error {
+			events = append(events, event)
+			return nil
+		})
+	})
+
+	Describe("Flush", func() {
+		It("parses the BOSH event log into structured events", func() {
+			err := writer.Flush(installationLogs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).To(Equal([]commands.InstallationEvent{
+				{Type: "step", Time: "2018-06-12 20:32:57 UTC", Step: "deploy", Product: "cf-abc123", State: "running"},
+				{Type: "stage", Time: "20:33:01", Step: "deploy", Product: "cf-abc123", Task: 29, Stage: "Preparing deployment", State: "finished", Duration: "00:00:04", Message: "Preparing deployment"},
+				{Type: "stage", Time: "20:33:05", Step: "deploy", Product: "cf-abc123", Task: 29, Stage: "Updating instance router", Job: "router", Instance: "1d2e3f", State: "started", Message: "router/1d2e3f (0) (canary)"},
+				{Type: "stage", Time: "20:38:06", Step: "deploy", Product: "cf-abc123", Task: 29, Stage: "Updating instance router", Job: "router", Instance: "1d2e3f", State: "finished", Duration: "00:05:01", Message: "router/1d2e3f (0) (canary)"},
+				{Type: "stage", Step: "deploy", Product: "cf-abc123", Task: 29, Stage: "Updating instance router", Job: "router", Instance: "1d2e3f", State: "error", Message: "'router/1d2e3f (0)' is not running after update."},
+				{Type: "stage", Time: "20:38:06", Step: "deploy", Product: "cf-abc123", Task: 29, Stage: "Error", State: "error", Message: "'router/1d2e3f (0)' is not running after update."},
+				{Type: "task", Step: "deploy", Product: "cf-abc123", Task: 29, State: "error"},
+				{Type: "step", Time: "2018-06-12 20:38:07 UTC", Step: "deploy", Product: "cf-abc123", State: "failed", Duration: "310s", Message: "exit status 1"},
+			}))
+		})
+
+		It("only handles complete lines it has not seen before", func() {
+			err := writer.Flush(installationLogs[:40])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(BeEmpty())
+
+			err = writer.Flush(installationLogs[:400])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+
+			err = writer.Flush(installationLogs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(8))
+		})
+
+		Context("when presenting an event fails", func() {
+			It("returns an error", func() {
+				writer = commands.NewInstallationEventWriter(func(commands.InstallationEvent) error {
+					return errors.New("failed to present")
+				})
+
+				err := writer.Flush(installationLogs)
+				Expect(err).To(MatchError("failed to present"))
+			})
+		})
+	})
+
+	Describe("Finish", func() {
+		It("handles the last line without a trailing newline", func() {
+			logs := "Task 29 | 20:33:01 | Preparing deployment: Preparing deployment (00:00:04)\nTask 29 done"
+
+			err := writer.Flush(logs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+
+			err = writer.Finish(logs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[1]).To(Equal(commands.InstallationEvent{Type: "task", Task: 29, State: "done"}))
+
+			err = writer.Finish(logs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+		})
+	})
+})
@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 )
@@ -29,7 +27,7 @@ func NewInstallationLog(service installationLogService, logger logger) Installat
 
 func (i InstallationLog) Execute(args []string) error {
 	if _, err := jhanda.Parse(&i.Options, args); err != nil {
-		return fmt.Errorf("could not parse installation-log flags: %s", err)
+		return configErrorf("could not parse installation-log flags: %w", err)
 	}
 
 	output, err := i.service.GetInstallationLogs(i.Options.Id)
@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/models"
@@ -13,7 +11,7 @@ type Installations struct {
 	service   installationsService
 	presenter presenters.FormattedPresenter
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -31,7 +29,7 @@ func NewInstallations(service installationsService, presenter presenters.Formatt
 
 func (i Installations) Execute(args []string) error {
 	if _, err := jhanda.Parse(&i.Options, args); err != nil {
-		return fmt.Errorf("could not parse installations flags: %s", err)
+		return configErrorf("could not parse installations flags: %w", err)
 	}
 
 	installationsOutput, err := i.service.ListInstallations()
@@ -50,10 +48,12 @@ func (i Installations) Execute(args []string) error {
 		})
 	}
 
-	i.presenter.SetFormat(i.Options.Format)
-	i.presenter.PresentInstallations(installations)
+	err = i.presenter.SetFormat(i.Options.Format)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return i.presenter.PresentInstallations(installations)
 }
 
 func (i Installations) Usage() jhanda.Usage {
@@ -14,6 +14,7 @@ import (
 
 type Interpolate struct {
 	environFunc func() []string
+	varsStore   boshtpl.Variables
 	logger      logger
 	Options     struct {
 		ConfigFile string   `long:"config"    short:"c" required:"true" description:"path for file to be interpolated"`
@@ -24,24 +25,45 @@ type Interpolate struct {
 	}
 }
 
+// InterpolateVariables replaces the ((variables)) in contents with the
+// environment variables with the varsEnv prefix and with the vars store, the
+// same way interpolate does.
+func InterpolateVariables(contents []byte, environFunc func() []string, varsEnv string, varsStore boshtpl.Variables) ([]byte, error) {
+	return interpolate(interpolateOptions{
+		templateContents: contents,
+		varsEnvs:         []string{varsEnv},
+		environFunc:      environFunc,
+		varsStore:        varsStore,
+	}, "")
+}
+
 type interpolateOptions struct {
 	templateFile string
-	varsEnvs     []string
-	varsFiles    []string
-	opsFiles     []string
-	environFunc  func() []string
+	// templateContents is used instead of reading templateFile when set
+	templateContents []byte
+	varsEnvs         []string
+	varsFiles        []string
+	opsFiles         []string
+	environFunc      func() []string
+	// varsStore is consulted for any variable that is not provided with
+	// varsFiles or varsEnvs, it is optional
+	varsStore boshtpl.Variables
+	// keepPlaceholders only applies the opsFiles, leaving every ((variable))
+	// in place, so that secrets are not resolved
+	keepPlaceholders bool
 }
 
-func NewInterpolate(environFunc func() []string, logger logger) Interpolate {
+func NewInterpolate(environFunc func() []string, varsStore boshtpl.Variables, logger logger) Interpolate {
 	return Interpolate{
 		environFunc: environFunc,
+		varsStore:   varsStore,
 		logger:      logger,
 	}
 }
 
 func (c Interpolate) Execute(args []string) error {
 	if _, err := jhanda.Parse(&c.Options, args); err != nil {
-		return fmt.Errorf("could not parse interpolate flags: %s", err)
+		return configErrorf("could not parse interpolate flags: %w", err)
 	}
 
 	bytes, err := interpolate(interpolateOptions{
@@ -50,6 +72,7 @@ func (c Interpolate) Execute(args []string) error {
 		environFunc:  c.environFunc,
 		varsEnvs:     c.Options.VarsEnv,
 		opsFiles:     c.Options.OpsFile,
+		varsStore:    c.varsStore,
 	}, c.Options.Path)
 	if err != nil {
 		return err
@@ -69,9 +92,13 @@ func (c Interpolate) Usage() jhanda.Usage {
 }
 
 func interpolate(o interpolateOptions, pathStr string) ([]byte, error) {
-	contents, err := ioutil.ReadFile(o.templateFile)
-	if err != nil {
-		return nil, err
+	var err error
+	contents := o.templateContents
+	if contents == nil {
+		contents, err = ioutil.ReadFile(o.templateFile)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	tpl := boshtpl.NewTemplate(contents)
@@ -142,21 +169,85 @@ func interpolate(o interpolateOptions, pathStr string) ([]byte, error) {
 
 	path, err := patch.NewPointerFromString(pathStr)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse path: %s", err)
+		return nil, fmt.Errorf("cannot parse path: %w", err)
 	}
 
 	if path.IsSet() {
 		evalOpts.PostVarSubstitutionOp = patch.FindOp{Path: path}
 	}
 
-	bytes, err := tpl.Evaluate(staticVars, ops, evalOpts)
+	vars := []boshtpl.Variables{staticVars}
+	var store *recordingVariables
+	if o.varsStore != nil {
+		store = &recordingVariables{variables: o.varsStore}
+		vars = append(vars, store)
+	}
+
+	if o.keepPlaceholders {
+		vars = nil
+		evalOpts.ExpectAllKeys = false
+	}
+
+	bytes, err := tpl.Evaluate(boshtpl.NewMultiVars(vars), ops, evalOpts)
 	if err != nil {
+		// the template does not keep the errors of the vars store, so that
+		// they are recorded to keep, for example, a network error reachable
+		if store != nil && store.err != nil {
+			return nil, varsStoreError{message: err.Error(), err: store.err}
+		}
 		return nil, err
 	}
 
 	return bytes, nil
 }
 
+// recordingVariables records the first error returned by the variables it
+// wraps.
+type recordingVariables struct {
+	variables boshtpl.Variables
+	err       error
+}
+
+func (r *recordingVariables) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
+	value, found, err := r.variables.Get(varDef)
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+
+	return value, found, err
+}
+
+func (r *recordingVariables) List() ([]boshtpl.VariableDefinition, error) {
+	return r.variables.List()
+}
+
+// varsStoreError is returned when the vars store could not be read, as
+// opposed to a template or variables that are invalid.
+type varsStoreError struct {
+	message string
+	err     error
+}
+
+func (e varsStoreError) Error() string {
+	return e.message
+}
+
+func (e varsStoreError) Unwrap() error {
+	return e.err
+}
+
+// interpolationError returns err as a ConfigError, unless the vars store
+// could not be read, so that, for example, an unreachable CredHub keeps its
+// own exit code and can be retried.
+func interpolationError(err error) error {
+	var storeErr varsStoreError
+	if errors.As(err, &storeErr) {
+		return err
+	}
+
+	return ConfigError{Err: err}
+}
+
 func readYAMLFile(path string, dataType interface{}) error {
 	payload, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pivotal-cf/jhanda"
@@ -28,7 +29,7 @@ var _ = Describe("Interpolate", func() {
 
 	BeforeEach(func() {
 		logger = &fakes.Logger{}
-		command = commands.NewInterpolate(func() []string { return nil }, logger)
+		command = commands.NewInterpolate(func() []string { return nil }, nil, logger)
 	})
 
 	Describe("Execute", func() {
@@ -127,6 +128,39 @@ var _ = Describe("Interpolate", func() {
 			})
 		})
 
+		Context("with a vars store", func() {
+			BeforeEach(func() {
+				command = commands.NewInterpolate(func() []string { return nil }, boshtpl.StaticVariables{"hello": "from the store"}, logger)
+			})
+
+			It("looks up variables that are not otherwise provided", func() {
+				err := ioutil.WriteFile(inputFile, []byte(templateWithParameters), 0755)
+				Expect(err).NotTo(HaveOccurred())
+				err = command.Execute([]string{
+					"--config", inputFile,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				content := logger.PrintlnArgsForCall(0)
+				Expect(content[0].(string)).To(MatchYAML("hello: from the store"))
+			})
+
+			It("prefers variables from vars files", func() {
+				err := ioutil.WriteFile(inputFile, []byte(templateWithParameters), 0755)
+				Expect(err).NotTo(HaveOccurred())
+				err = ioutil.WriteFile(varsFile, []byte(varsFileParameter), 0755)
+				Expect(err).NotTo(HaveOccurred())
+				err = command.Execute([]string{
+					"--config", inputFile,
+					"--vars-file", varsFile,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				content := logger.PrintlnArgsForCall(0)
+				Expect(content[0].(string)).To(MatchYAML("hello: world"))
+			})
+		})
+
 		Context("with ops file input", func() {
 			It("succeeds", func() {
 				err := ioutil.WriteFile(inputFile, []byte(templateNoParameters), 0755)
@@ -166,7 +200,7 @@ hello: world`))
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewInterpolate(os.Environ, nil)
+			command := commands.NewInterpolate(os.Environ, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "Interpolates variables into a manifest",
 				ShortDescription: "Interpolates variables into a manifest",
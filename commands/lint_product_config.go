@@ -0,0 +1,530 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/kiln/proofing"
+	"github.com/pivotal-cf/om/config"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type LintProductConfig struct {
+	metadataExtractor metadataExtractor
+	logger            logger
+	Options           struct {
+		Product    string `long:"product" short:"p" required:"true" description:"path to the product file (.pivotal) the config is for"`
+		ConfigFile string `long:"config"  short:"c" required:"true" description:"path to yml file containing the configure-product config to validate"`
+	}
+}
+
+// lintProperty is a property blueprint of the product template, along with
+// the selector option it belongs to, if any.
+type lintProperty struct {
+	blueprint  proofing.SimplePropertyBlueprint
+	options    []proofing.SelectorPropertyOptionTemplate
+	collection []proofing.SimplePropertyBlueprint
+	selector   string
+	option     string
+}
+
+type lintError struct {
+	line    int
+	message string
+}
+
+func NewLintProductConfig(metadataExtractor metadataExtractor, logger logger) LintProductConfig {
+	return LintProductConfig{
+		metadataExtractor: metadataExtractor,
+		logger:            logger,
+	}
+}
+
+func (lpc LintProductConfig) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This command validates a configure-product config file against the metadata of a product file without contacting Ops Manager (Note: values that are ((placeholders)) are not type checked)",
+		ShortDescription: "**EXPERIMENTAL** validates a product config file against a product file",
+		Flags:            lpc.Options,
+	}
+}
+
+func (lpc LintProductConfig) Execute(args []string) error {
+	if _, err := jhanda.Parse(&lpc.Options, args); err != nil {
+		return configErrorf("could not parse lint-product-config flags: %w", err)
+	}
+
+	metadata, err := lpc.metadataExtractor.ExtractMetadata(lpc.Options.Product)
+	if err != nil {
+		return fmt.Errorf("could not extract metadata: %w", err)
+	}
+
+	var template proofing.ProductTemplate
+	err = yaml.Unmarshal(metadata.Raw, &template)
+	if err != nil {
+		return fmt.Errorf("could not parse metadata: %w", err)
+	}
+
+	contents, err := ioutil.ReadFile(lpc.Options.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("could not read config file: %w", err)
+	}
+
+	var cfg config.ProductConfiguration
+	err = yaml.UnmarshalStrict(contents, &cfg)
+	if err != nil {
+		return fmt.Errorf("could not parse config file %s: %w", lpc.Options.ConfigFile, err)
+	}
+
+	var document yamlv3.Node
+	err = yamlv3.Unmarshal(contents, &document)
+	if err != nil {
+		return fmt.Errorf("could not parse config file %s: %w", lpc.Options.ConfigFile, err)
+	}
+
+	linter := productConfigLinter{
+		properties: lintProperties(template),
+		template:   template,
+		config:     cfg,
+		locator:    yamlLocator{document: &document},
+	}
+	errs := linter.lint()
+
+	if len(errs) == 0 {
+		lpc.logger.Printf("%s is valid for %s %s", lpc.Options.ConfigFile, metadata.Name, metadata.Version)
+		return nil
+	}
+
+	for _, e := range errs {
+		lpc.logger.Printf("%s:%d: %s", lpc.Options.ConfigFile, e.line, e.message)
+	}
+
+	return fmt.Errorf("found %d errors in %s", len(errs), lpc.Options.ConfigFile)
+}
+
+func lintProperties(template proofing.ProductTemplate) map[string]lintProperty {
+	properties := map[string]lintProperty{}
+
+	add := func(prefix string, blueprints proofing.PropertyBlueprints) {
+		for _, pb := range blueprints {
+			switch blueprint := pb.(type) {
+			case proofing.SelectorPropertyBlueprint:
+				name := fmt.Sprintf("%s.%s", prefix, blueprint.Name)
+				properties[name] = lintProperty{
+					blueprint: blueprint.SimplePropertyBlueprint,
+					options:   blueprint.OptionTemplates,
+				}
+
+				for _, option := range blueprint.OptionTemplates {
+					for _, optionBlueprint := range option.PropertyBlueprints {
+						properties[fmt.Sprintf("%s.%s.%s", name, option.Name, optionBlueprint.Name)] = lintProperty{
+							blueprint: optionBlueprint,
+							selector:  name,
+							option:    option.Name,
+						}
+					}
+				}
+			case proofing.CollectionPropertyBlueprint:
+				properties[fmt.Sprintf("%s.%s", prefix, blueprint.Name)] = lintProperty{
+					blueprint:  blueprint.SimplePropertyBlueprint,
+					collection: blueprint.PropertyBlueprints,
+				}
+			case proofing.SimplePropertyBlueprint:
+				properties[fmt.Sprintf("%s.%s", prefix, blueprint.Name)] = lintProperty{
+					blueprint: blueprint,
+				}
+			}
+		}
+	}
+
+	add(".properties", template.PropertyBlueprints)
+	for _, jobType := range template.JobTypes {
+		add(fmt.Sprintf(".%s", jobType.Name), jobType.PropertyBlueprints)
+	}
+
+	return properties
+}
+
+type productConfigLinter struct {
+	properties map[string]lintProperty
+	template   proofing.ProductTemplate
+	config     config.ProductConfiguration
+	locator    yamlLocator
+	errors     []lintError
+}
+
+func (l *productConfigLinter) lint() []lintError {
+	// the keys configure-product does not know end up in the inline Field
+	for key := range l.config.Field {
+		l.errorf(l.locator.line(key), "unknown key %q", key)
+	}
+
+	for name, value := range l.config.ProductProperties {
+		l.lintProperty(name, value)
+	}
+
+	l.lintRequiredProperties()
+	l.lintJobs()
+
+	sort.SliceStable(l.errors, func(i, j int) bool {
+		if l.errors[i].line == l.errors[j].line {
+			return l.errors[i].message < l.errors[j].message
+		}
+		return l.errors[i].line < l.errors[j].line
+	})
+
+	return l.errors
+}
+
+func (l *productConfigLinter) errorf(line int, format string, args ...interface{}) {
+	l.errors = append(l.errors, lintError{line: line, message: fmt.Sprintf(format, args...)})
+}
+
+func (l *productConfigLinter) lintProperty(name string, value interface{}) {
+	line := l.locator.line("product-properties", name)
+
+	property, ok := l.properties[name]
+	if !ok {
+		l.errorf(line, "unknown property %q", name)
+		return
+	}
+
+	if !property.blueprint.Configurable {
+		l.errorf(line, "property %q is not configurable", name)
+		return
+	}
+
+	if property.selector != "" {
+		selected, ok := l.selectedOption(property.selector)
+		if ok && selected != property.option {
+			l.errorf(line, "property %q belongs to option %q of %q, but option %q is selected", name, property.option, property.selector, selected)
+		}
+	}
+
+	settings, ok := value.(map[interface{}]interface{})
+	if !ok {
+		l.errorf(line, "property %q must be set with a \"value\" key", name)
+		return
+	}
+
+	if selectedOption, ok := settings["selected_option"]; ok {
+		if property.blueprint.Type != "selector" {
+			l.errorf(l.locator.line("product-properties", name, "selected_option"), "property %q is not a selector and cannot have a selected_option", name)
+		} else if _, found := findSelectorOption(property.options, selectedOption, true); !found && !isPlaceholder(selectedOption) {
+			l.errorf(l.locator.line("product-properties", name, "selected_option"), "invalid option %v for selector %q: options are %s", selectedOption, name, selectorOptionNames(property.options))
+		}
+		return
+	}
+
+	propertyValue, ok := settings["value"]
+	if !ok {
+		l.errorf(line, "property %q must be set with a \"value\" key", name)
+		return
+	}
+
+	valueLine := l.locator.line("product-properties", name, "value")
+	if property.blueprint.Type == "collection" {
+		l.lintCollection(valueLine, name, property.collection, propertyValue)
+		return
+	}
+
+	if property.blueprint.Type == "selector" {
+		if _, found := findSelectorOption(property.options, propertyValue, false); !found && !isPlaceholder(propertyValue) {
+			l.errorf(valueLine, "invalid option %v for selector %q: options are %s", propertyValue, name, selectorOptionNames(property.options))
+		}
+		return
+	}
+
+	if message := checkPropertyType(property.blueprint, propertyValue); message != "" {
+		l.errorf(valueLine, "property %q %s", name, message)
+	}
+}
+
+func (l *productConfigLinter) lintCollection(line int, name string, blueprints []proofing.SimplePropertyBlueprint, value interface{}) {
+	if isPlaceholder(value) {
+		return
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		l.errorf(line, "property %q must be a list of collection items", name)
+		return
+	}
+
+	fields := map[string]proofing.SimplePropertyBlueprint{}
+	for _, blueprint := range blueprints {
+		fields[blueprint.Name] = blueprint
+	}
+
+	for i, item := range items {
+		itemLine := l.locator.line("product-properties", name, "value", i)
+
+		itemFields, ok := item.(map[interface{}]interface{})
+		if !ok {
+			l.errorf(itemLine, "item %d of property %q must be a map of fields", i+1, name)
+			continue
+		}
+
+		for _, blueprint := range blueprints {
+			if _, ok := itemFields[blueprint.Name]; !ok && isRequired(blueprint) {
+				l.errorf(itemLine, "item %d of property %q is missing the required field %q", i+1, name, blueprint.Name)
+			}
+		}
+
+		var fieldNames []string
+		for fieldName := range itemFields {
+			fieldNames = append(fieldNames, fmt.Sprintf("%v", fieldName))
+		}
+		sort.Strings(fieldNames)
+
+		for _, fieldName := range fieldNames {
+			fieldLine := l.locator.line("product-properties", name, "value", i, fieldName)
+
+			blueprint, ok := fields[fieldName]
+			if !ok {
+				l.errorf(fieldLine, "item %d of property %q has the unknown field %q", i+1, name, fieldName)
+				continue
+			}
+
+			if message := checkPropertyType(blueprint, itemFields[fieldName]); message != "" {
+				l.errorf(fieldLine, "field %q of item %d of property %q %s", fieldName, i+1, name, message)
+			}
+		}
+	}
+}
+
+func (l *productConfigLinter) lintRequiredProperties() {
+	line := l.locator.line("product-properties")
+
+	for name, property := range l.properties {
+		if _, ok := l.config.ProductProperties[name]; ok {
+			continue
+		}
+
+		if !property.blueprint.Configurable || !isRequired(property.blueprint) {
+			continue
+		}
+
+		if property.selector != "" {
+			selected, ok := l.selectedOption(property.selector)
+			if !ok || selected != property.option {
+				continue
+			}
+		}
+
+		l.errorf(line, "missing required property %q", name)
+	}
+}
+
+func (l *productConfigLinter) lintJobs() {
+	jobs := map[string]proofing.JobType{}
+	for _, jobType := range l.template.JobTypes {
+		jobs[jobType.Name] = jobType
+	}
+
+	for name := range l.config.ResourceConfigProperties {
+		if _, ok := jobs[name]; !ok {
+			l.errorf(l.locator.line("resource-config", name), "unknown job %q in resource-config", name)
+		}
+	}
+
+	for name := range l.config.ErrandConfigs {
+		if jobType, ok := jobs[name]; !ok || !jobType.Errand {
+			l.errorf(l.locator.line("errand-config", name), "unknown errand %q in errand-config", name)
+		}
+	}
+}
+
+// selectedOption returns the name of the option template that is selected
+// for a selector, either in the config or by its default.
+func (l *productConfigLinter) selectedOption(selector string) (string, bool) {
+	property := l.properties[selector]
+
+	if settings, ok := l.config.ProductProperties[selector].(map[interface{}]interface{}); ok {
+		if selectedOption, ok := settings["selected_option"]; ok {
+			return findSelectorOption(property.options, selectedOption, true)
+		}
+		if value, ok := settings["value"]; ok {
+			return findSelectorOption(property.options, value, false)
+		}
+	}
+
+	if property.blueprint.Default != nil {
+		return findSelectorOption(property.options, property.blueprint.Default, false)
+	}
+
+	return "", false
+}
+
+func findSelectorOption(options []proofing.SelectorPropertyOptionTemplate, value interface{}, byName bool) (string, bool) {
+	selected := fmt.Sprintf("%v", value)
+	for _, option := range options {
+		if option.Name == selected || (!byName && option.SelectValue == selected) {
+			return option.Name, true
+		}
+	}
+
+	return "", false
+}
+
+func selectorOptionNames(options []proofing.SelectorPropertyOptionTemplate) string {
+	var names []string
+	for _, option := range options {
+		names = append(names, option.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func isRequired(blueprint proofing.SimplePropertyBlueprint) bool {
+	if blueprint.Optional || blueprint.Default != nil {
+		return false
+	}
+
+	// these are generated by Ops Manager when they are not set
+	switch blueprint.Type {
+	case "uuid", "salted_credentials":
+		return false
+	}
+
+	return true
+}
+
+func isPlaceholder(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.Contains(s, "((")
+}
+
+// checkPropertyType returns why the value does not match the type of the
+// property blueprint, or an empty string when it does.
+func checkPropertyType(blueprint proofing.SimplePropertyBlueprint, value interface{}) string {
+	if value == nil || isPlaceholder(value) {
+		return ""
+	}
+
+	switch blueprint.Type {
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("must be a boolean, got %v", value)
+		}
+	case "integer", "port":
+		if _, ok := value.(int); !ok {
+			return fmt.Sprintf("must be an integer, got %v", value)
+		}
+	case "dropdown_select":
+		if !hasOption(blueprint.Options, value) {
+			return fmt.Sprintf("must be one of %s, got %v", optionNames(blueprint.Options), value)
+		}
+	case "multi_select_options":
+		values, ok := value.([]interface{})
+		if !ok {
+			return fmt.Sprintf("must be a list of %s", optionNames(blueprint.Options))
+		}
+		for _, v := range values {
+			if !hasOption(blueprint.Options, v) {
+				return fmt.Sprintf("must only contain %s, got %v", optionNames(blueprint.Options), v)
+			}
+		}
+	case "secret":
+		return checkCredentialFields(value, "secret")
+	case "simple_credentials":
+		return checkCredentialFields(value, "identity", "password")
+	case "rsa_cert_credentials":
+		return checkCredentialFields(value, "cert_pem", "private_key_pem")
+	case "rsa_pkey_credentials":
+		return checkCredentialFields(value, "private_key_pem")
+	}
+
+	return ""
+}
+
+func checkCredentialFields(value interface{}, fields ...string) string {
+	credential, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return fmt.Sprintf("must be a map with the keys %s", strings.Join(fields, ", "))
+	}
+
+	for _, field := range fields {
+		if _, ok := credential[field]; !ok {
+			return fmt.Sprintf("is missing the key %q", field)
+		}
+	}
+
+	return ""
+}
+
+func hasOption(options []proofing.PropertyBlueprintOption, value interface{}) bool {
+	for _, option := range options {
+		if option.Name == fmt.Sprintf("%v", value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func optionNames(options []proofing.PropertyBlueprintOption) string {
+	var names []string
+	for _, option := range options {
+		names = append(names, option.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// yamlLocator finds the line of a key in the node tree of a YAML document.
+type yamlLocator struct {
+	document *yamlv3.Node
+}
+
+// line returns the 1-based line of the node at path, where a string is a
+// key of a mapping and an int is an index of a sequence. When the path does
+// not exist, it returns the line of the deepest node of path that does, or 1
+// when not even the first one exists.
+func (l yamlLocator) line(path ...interface{}) int {
+	node := l.document
+	if node.Kind == yamlv3.DocumentNode {
+		if len(node.Content) == 0 {
+			return 1
+		}
+		node = node.Content[0]
+	}
+
+	line := 1
+	for _, step := range path {
+		if node.Kind == yamlv3.AliasNode {
+			node = node.Alias
+		}
+
+		var key, value *yamlv3.Node
+		switch step := step.(type) {
+		case string:
+			if node.Kind != yamlv3.MappingNode {
+				return line
+			}
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == step {
+					key, value = node.Content[i], node.Content[i+1]
+					break
+				}
+			}
+		case int:
+			if node.Kind != yamlv3.SequenceNode || step >= len(node.Content) {
+				return line
+			}
+			key, value = node.Content[step], node.Content[step]
+		}
+
+		if key == nil {
+			return line
+		}
+
+		line = key.Line
+		node = value
+	}
+
+	return line
+}
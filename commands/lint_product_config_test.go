@@ -0,0 +1,308 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const lintProductMetadata = `---
+name: some-product
+product_version: 1.2.3
+property_blueprints:
+- name: some-string
+  type: string
+  configurable: true
+- name: some-boolean
+  type: boolean
+  configurable: true
+  default: false
+- name: some-port
+  type: port
+  configurable: true
+  default: 8080
+- name: some-fixed-value
+  type: string
+  default: fixed
+- name: some-secret
+  type: secret
+  configurable: true
+  optional: true
+- name: some-selector
+  type: selector
+  configurable: true
+  default: Internal
+  option_templates:
+  - name: internal
+    select_value: Internal
+    property_blueprints:
+    - name: some-internal-string
+      type: string
+      configurable: true
+  - name: external
+    select_value: External
+    property_blueprints:
+    - name: some-external-host
+      type: string
+      configurable: true
+- name: some-collection
+  type: collection
+  configurable: true
+  optional: true
+  property_blueprints:
+  - name: name
+    type: string
+    configurable: true
+  - name: enabled
+    type: boolean
+    configurable: true
+    optional: true
+job_types:
+- name: some-job
+  property_blueprints:
+  - name: some-job-integer
+    type: integer
+    configurable: true
+    default: 1
+- name: some-errand
+  errand: true
+`
+
+var _ = Describe("LintProductConfig", func() {
+	var (
+		logger            *fakes.Logger
+		metadataExtractor *fakes.MetadataExtractor
+		command           commands.LintProductConfig
+		configFile        *os.File
+	)
+
+	writeConfig := func(contents string) {
+		_, err := configFile.WriteString(contents)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configFile.Close()).To(Succeed())
+	}
+
+	output := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, v := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, v...))
+		}
+		return lines
+	}
+
+	BeforeEach(func() {
+		var err error
+		configFile, err = ioutil.TempFile("", "config.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = &fakes.Logger{}
+		metadataExtractor = &fakes.MetadataExtractor{}
+		metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+			Name:    "some-product",
+			Version: "1.2.3",
+			Raw:     []byte(lintProductMetadata),
+		}, nil)
+
+		command = commands.NewLintProductConfig(metadataExtractor, logger)
+	})
+
+	AfterEach(func() {
+		os.Remove(configFile.Name())
+	})
+
+	It("accepts a valid config", func() {
+		writeConfig(`---
+product-name: some-product
+product-properties:
+  .properties.some-string:
+    value: some-value
+  .properties.some-boolean:
+    value: true
+  .properties.some-secret:
+    value:
+      secret: ((some-secret))
+  .properties.some-selector:
+    value: Internal
+  .properties.some-selector.internal.some-internal-string:
+    value: ((some-var))
+  .properties.some-collection:
+    value:
+    - name: first
+      enabled: true
+    - name: second
+  .some-job.some-job-integer:
+    value: 3
+resource-config:
+  some-job:
+    instances: 2
+errand-config:
+  some-errand:
+    post-deploy-state: true
+`)
+
+		err := command.Execute([]string{"--product", "/path/to/product.pivotal", "--config", configFile.Name()})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(metadataExtractor.ExtractMetadataArgsForCall(0)).To(Equal("/path/to/product.pivotal"))
+		Expect(output()).To(Equal([]string{
+			fmt.Sprintf("%s is valid for some-product 1.2.3", configFile.Name()),
+		}))
+	})
+
+	It("reports every error with the file and line", func() {
+		writeConfig(`---
+product-name: some-product
+product-properties:
+  .properties.some-strin:
+    value: typo
+  .properties.some-boolean:
+    value: "yes"
+  .properties.some-port:
+    value: eighty
+  .properties.some-fixed-value:
+    value: changed
+  .properties.some-secret:
+    value: not-a-map
+  .properties.some-selector:
+    value: Internal
+  .properties.some-selector.external.some-external-host:
+    value: example.com
+  .properties.some-collection:
+    value:
+    - enabled: maybe
+      unknown: field
+resource-config:
+  some-job:
+    instances: 2
+  missing-job:
+    instances: 1
+errand-config:
+  some-job:
+    post-deploy-state: true
+`)
+
+		err := command.Execute([]string{"--product", "/path/to/product.pivotal", "--config", configFile.Name()})
+		Expect(err).To(MatchError(fmt.Sprintf("found 13 errors in %s", configFile.Name())))
+
+		path := configFile.Name()
+		Expect(output()).To(Equal([]string{
+			fmt.Sprintf(`%s:3: missing required property ".properties.some-selector.internal.some-internal-string"`, path),
+			fmt.Sprintf(`%s:3: missing required property ".properties.some-string"`, path),
+			fmt.Sprintf(`%s:4: unknown property ".properties.some-strin"`, path),
+			fmt.Sprintf(`%s:7: property ".properties.some-boolean" must be a boolean, got yes`, path),
+			fmt.Sprintf(`%s:9: property ".properties.some-port" must be an integer, got eighty`, path),
+			fmt.Sprintf(`%s:10: property ".properties.some-fixed-value" is not configurable`, path),
+			fmt.Sprintf(`%s:13: property ".properties.some-secret" must be a map with the keys secret`, path),
+			fmt.Sprintf(`%s:16: property ".properties.some-selector.external.some-external-host" belongs to option "external" of ".properties.some-selector", but option "internal" is selected`, path),
+			fmt.Sprintf(`%s:20: field "enabled" of item 1 of property ".properties.some-collection" must be a boolean, got maybe`, path),
+			fmt.Sprintf(`%s:20: item 1 of property ".properties.some-collection" is missing the required field "name"`, path),
+			fmt.Sprintf(`%s:21: item 1 of property ".properties.some-collection" has the unknown field "unknown"`, path),
+			fmt.Sprintf(`%s:25: unknown job "missing-job" in resource-config`, path),
+			fmt.Sprintf(`%s:28: unknown errand "some-job" in errand-config`, path),
+		}))
+	})
+
+	It("reports unknown keys and the line of every collection item and field", func() {
+		writeConfig(`---
+product-name: some-product
+product-propertes: {}
+product-properties:
+  .properties.some-string:
+    value: some-value
+  .properties.some-collection:
+    value:
+    - name: first
+      enabled: true
+    - name: second
+      enabled: maybe
+    - enabled: false
+`)
+
+		err := command.Execute([]string{"--product", "/path/to/product.pivotal", "--config", configFile.Name()})
+		Expect(err).To(MatchError(fmt.Sprintf("found 4 errors in %s", configFile.Name())))
+
+		path := configFile.Name()
+		Expect(output()).To(Equal([]string{
+			fmt.Sprintf(`%s:3: unknown key "product-propertes"`, path),
+			fmt.Sprintf(`%s:4: missing required property ".properties.some-selector.internal.some-internal-string"`, path),
+			fmt.Sprintf(`%s:12: field "enabled" of item 2 of property ".properties.some-collection" must be a boolean, got maybe`, path),
+			fmt.Sprintf(`%s:13: item 3 of property ".properties.some-collection" is missing the required field "name"`, path),
+		}))
+	})
+
+	It("checks the selected option of a selector", func() {
+		writeConfig(`---
+product-properties:
+  .properties.some-string:
+    value: some-value
+  .properties.some-selector:
+    value: Somewhere
+  ".properties.some-selector.external.some-external-host":
+    value: example.com
+`)
+
+		err := command.Execute([]string{"--product", "/path/to/product.pivotal", "--config", configFile.Name()})
+		Expect(err).To(MatchError(fmt.Sprintf("found 1 errors in %s", configFile.Name())))
+
+		Expect(output()).To(Equal([]string{
+			fmt.Sprintf(`%s:6: invalid option Somewhere for selector ".properties.some-selector": options are internal, external`, configFile.Name()),
+		}))
+	})
+
+	Context("failure cases", func() {
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.Execute([]string{"--badflag"})
+				Expect(err).To(MatchError("could not parse lint-product-config flags: flag provided but not defined: -badflag"))
+			})
+		})
+
+		Context("when the metadata cannot be extracted", func() {
+			It("returns an error", func() {
+				metadataExtractor.ExtractMetadataReturns(extractor.Metadata{}, errors.New("some error"))
+
+				err := command.Execute([]string{"--product", "/path/to/product.pivotal", "--config", configFile.Name()})
+				Expect(err).To(MatchError("could not extract metadata: some error"))
+			})
+		})
+
+		Context("when the config file cannot be parsed", func() {
+			It("returns an error", func() {
+				writeConfig("%%%")
+
+				err := command.Execute([]string{"--product", "/path/to/product.pivotal", "--config", configFile.Name()})
+				Expect(err).To(MatchError(ContainSubstring("could not parse config file")))
+			})
+		})
+
+		Context("when the config file contains a key twice", func() {
+			It("returns an error", func() {
+				writeConfig("product-name: some-product\nproduct-name: other-product\n")
+
+				err := command.Execute([]string{"--product", "/path/to/product.pivotal", "--config", configFile.Name()})
+				Expect(err).To(MatchError(ContainSubstring("field product-name already set")))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			usage := command.Usage()
+			Expect(usage).To(Equal(jhanda.Usage{
+				Description:      "This command validates a configure-product config file against the metadata of a product file without contacting Ops Manager (Note: values that are ((placeholders)) are not type checked)",
+				ShortDescription: "**EXPERIMENTAL** validates a product config file against a product file",
+				Flags:            command.Options,
+			}))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/jhanda"
 	"gopkg.in/yaml.v2"
 	"reflect"
@@ -11,7 +12,8 @@ import (
 // To use this function, `Config` field must be defined in the command struct being passed in.
 // To load vars, VarsFile and/or VarsEnv must exist in the command struct being passed in.
 // If VarsEnv is used, envFunc must be defined instead of nil
-func loadConfigFile(args []string, command interface{}, envFunc func() []string) error {
+// varsStore is consulted for the vars that are not otherwise provided, it can be nil
+func loadConfigFile(args []string, command interface{}, envFunc func() []string, varsStore boshtpl.Variables) error {
 	_, err := jhanda.Parse(command, args)
 	commandValue := reflect.ValueOf(command).Elem()
 	configFile := commandValue.FieldByName("ConfigFile").String()
@@ -48,14 +50,15 @@ func loadConfigFile(args []string, command interface{}, envFunc func() []string)
 		varsFiles:    varsField,
 		environFunc:  envFunc,
 		opsFiles:     nil,
+		varsStore:    varsStore,
 	}, "")
 	if err != nil {
-		return fmt.Errorf("could not load the config file: %s", err)
+		return fmt.Errorf("could not load the config file: %w", err)
 	}
 
 	err = yaml.Unmarshal(contents, &options)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal config file %s: %s", configFile, err)
+		return fmt.Errorf("failed to unmarshal config file %s: %w", configFile, err)
 	}
 
 	var fileArgs []string
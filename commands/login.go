@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+)
+
+type Login struct {
+	service loginService
+	logger  logger
+}
+
+//go:generate counterfeiter -o ./fakes/login_service.go --fake-name LoginService . loginService
+type loginService interface {
+	Login() error
+}
+
+func NewLogin(service loginService, logger logger) Login {
+	return Login{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (l Login) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This command retrieves a UAA token with the given credentials and caches it, so that other commands for the same target and user reuse it instead of logging in again",
+		ShortDescription: "logs in to the Ops Manager targeted and caches the token",
+	}
+}
+
+func (l Login) Execute(args []string) error {
+	err := l.service.Login()
+	if err != nil {
+		return fmt.Errorf("could not log in: %w", err)
+	}
+
+	l.logger.Printf("logged in successfully")
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Login", func() {
+	var (
+		service *fakes.LoginService
+		logger  *fakes.Logger
+		command commands.Login
+	)
+
+	BeforeEach(func() {
+		service = &fakes.LoginService{}
+		logger = &fakes.Logger{}
+		command = commands.NewLogin(service, logger)
+	})
+
+	Describe("Execute", func() {
+		It("logs in and caches the token", func() {
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.LoginCallCount()).To(Equal(1))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("logged in successfully"))
+		})
+
+		Context("when the token cannot be retrieved", func() {
+			It("returns an error", func() {
+				service.LoginReturns(errors.New("bad credentials"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("could not log in: bad credentials"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This command retrieves a UAA token with the given credentials and caches it, so that other commands for the same target and user reuse it instead of logging in again",
+				ShortDescription: "logs in to the Ops Manager targeted and caches the token",
+			}))
+		})
+	})
+})
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+)
+
+type Logout struct {
+	service logoutService
+	logger  logger
+}
+
+//go:generate counterfeiter -o ./fakes/logout_service.go --fake-name LogoutService . logoutService
+type logoutService interface {
+	Logout() (bool, error)
+}
+
+func NewLogout(service logoutService, logger logger) Logout {
+	return Logout{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (l Logout) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This command removes the cached UAA token for the targeted Ops Manager and user",
+		ShortDescription: "removes the cached token of the Ops Manager targeted",
+	}
+}
+
+func (l Logout) Execute(args []string) error {
+	found, err := l.service.Logout()
+	if err != nil {
+		return fmt.Errorf("could not log out: %w", err)
+	}
+
+	if !found {
+		l.logger.Printf("not logged in, nothing to be done")
+		return nil
+	}
+
+	l.logger.Printf("logged out successfully")
+
+	return nil
+}
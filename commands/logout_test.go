@@ -0,0 +1,71 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logout", func() {
+	var (
+		service *fakes.LogoutService
+		logger  *fakes.Logger
+		command commands.Logout
+	)
+
+	BeforeEach(func() {
+		service = &fakes.LogoutService{}
+		logger = &fakes.Logger{}
+		command = commands.NewLogout(service, logger)
+	})
+
+	Describe("Execute", func() {
+		It("removes the cached token", func() {
+			service.LogoutReturns(true, nil)
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.LogoutCallCount()).To(Equal(1))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("logged out successfully"))
+		})
+
+		Context("when there is no cached token", func() {
+			It("does nothing", func() {
+				service.LogoutReturns(false, nil)
+
+				err := command.Execute([]string{})
+				Expect(err).NotTo(HaveOccurred())
+
+				format, content := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("not logged in, nothing to be done"))
+			})
+		})
+
+		Context("when the cached token cannot be removed", func() {
+			It("returns an error", func() {
+				service.LogoutReturns(false, errors.New("permission denied"))
+
+				err := command.Execute([]string{})
+				Expect(err).To(MatchError("could not log out: permission denied"))
+			})
+		})
+	})
+
+	Describe("Usage", func() {
+		It("returns usage information for the command", func() {
+			Expect(command.Usage()).To(Equal(jhanda.Usage{
+				Description:      "This command removes the cached UAA token for the targeted Ops Manager and user",
+				ShortDescription: "removes the cached token of the Ops Manager targeted",
+			}))
+		})
+	})
+})
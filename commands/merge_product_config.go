@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+
+	"gopkg.in/yaml.v2"
+)
+
+// mergeProductConfigFiles deep-merges the YAML documents of the given
+// configure-product config files, in order, so that environment-specific
+// files can override a shared base:
+//
+//   - product-name has to be the same in every document that sets it
+//   - product-properties are merged by property name; a property in a later
+//     document replaces the whole property of an earlier one, including
+//     collection values
+//   - network-properties, resource-config, errand-config and any other maps
+//     are merged recursively; lists and scalar values are replaced
+//   - a key set to null (~) removes the key from the merged config
+//
+// A single document is returned as is, and so is a single file that cannot
+// be parsed, so that it fails with the usual error when it is interpolated.
+func mergeProductConfigFiles(paths []string) ([]byte, error) {
+	var documents []map[interface{}]interface{}
+	var single, last []byte
+	var decoded int
+
+	for _, path := range paths {
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		last = contents
+
+		decoder := yaml.NewDecoder(bytes.NewReader(contents))
+		for {
+			var document interface{}
+			err = decoder.Decode(&document)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				if len(paths) == 1 {
+					return contents, nil
+				}
+				return nil, fmt.Errorf("%s could not be parsed as valid configuration: %w", path, err)
+			}
+
+			decoded++
+			if document == nil {
+				continue
+			}
+
+			documentMap, ok := document.(map[interface{}]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s could not be parsed as valid configuration: every document must be a map", path)
+			}
+
+			documents = append(documents, documentMap)
+			single = contents
+		}
+	}
+
+	if decoded <= 1 {
+		if single != nil {
+			return single, nil
+		}
+		return last, nil
+	}
+
+	merged := map[interface{}]interface{}{}
+	for _, document := range documents {
+		for key, value := range document {
+			switch key {
+			case "product-name":
+				if existing, ok := merged[key]; ok && value != nil && !reflect.DeepEqual(existing, value) {
+					return nil, fmt.Errorf("config files are for different products: %v and %v", existing, value)
+				}
+				mergeValue(merged, key, value)
+			case "product-properties":
+				properties, ok := value.(map[interface{}]interface{})
+				if !ok {
+					mergeValue(merged, key, value)
+					continue
+				}
+
+				mergedProperties, ok := merged[key].(map[interface{}]interface{})
+				if !ok {
+					mergedProperties = map[interface{}]interface{}{}
+					merged[key] = mergedProperties
+				}
+
+				for name, property := range properties {
+					mergeValue(mergedProperties, name, property)
+				}
+			default:
+				merged[key] = deepMerge(merged[key], value)
+				if value == nil {
+					delete(merged, key)
+				}
+			}
+		}
+	}
+
+	return yaml.Marshal(merged)
+}
+
+// mergeValue sets or, for null, removes a key without merging the value.
+func mergeValue(m map[interface{}]interface{}, key, value interface{}) {
+	if value == nil {
+		delete(m, key)
+		return
+	}
+
+	m[key] = value
+}
+
+func deepMerge(base, override interface{}) interface{} {
+	baseMap, baseIsMap := base.(map[interface{}]interface{})
+	overrideMap, overrideIsMap := override.(map[interface{}]interface{})
+	if !baseIsMap || !overrideIsMap {
+		return override
+	}
+
+	merged := map[interface{}]interface{}{}
+	for key, value := range baseMap {
+		merged[key] = value
+	}
+
+	for key, value := range overrideMap {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+
+		merged[key] = deepMerge(merged[key], value)
+	}
+
+	return merged
+}
@@ -12,12 +12,13 @@ type PendingChanges struct {
 	service   pendingChangesService
 	presenter presenters.FormattedPresenter
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
 //go:generate counterfeiter -o ./fakes/pending_changes_service.go --fake-name PendingChangesService . pendingChangesService
 type pendingChangesService interface {
+	Info() (api.Info, error)
 	ListStagedPendingChanges() (api.PendingChangesOutput, error)
 }
 
@@ -30,18 +31,30 @@ func NewPendingChanges(presenter presenters.FormattedPresenter, service pendingC
 
 func (pc PendingChanges) Execute(args []string) error {
 	if _, err := jhanda.Parse(&pc.Options, args); err != nil {
-		return fmt.Errorf("could not parse pending-changes flags: %s", err)
+		return configErrorf("could not parse pending-changes flags: %w", err)
+	}
+
+	info, err := pc.service.Info()
+	if err != nil {
+		return fmt.Errorf("could not retrieve info from targetted ops manager: %w", err)
+	}
+
+	err = requireCapability(info, api.CapabilityPendingChanges, "om pending-changes")
+	if err != nil {
+		return err
 	}
 
 	output, err := pc.service.ListStagedPendingChanges()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve pending changes %s", err)
+		return fmt.Errorf("failed to retrieve pending changes %w", err)
 	}
 
-	pc.presenter.SetFormat(pc.Options.Format)
-	pc.presenter.PresentPendingChanges(output.ChangeList)
+	err = pc.presenter.SetFormat(pc.Options.Format)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return pc.presenter.PresentPendingChanges(output.ChangeList)
 }
 
 func (pc PendingChanges) Usage() jhanda.Usage {
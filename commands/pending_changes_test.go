@@ -27,6 +27,7 @@ var _ = Describe("PendingChanges", func() {
 
 	Describe("Execute", func() {
 		BeforeEach(func() {
+			pcService.InfoReturns(api.Info{Version: "2.2-build.1"}, nil)
 			pcService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
 				ChangeList: []api.ProductChange{
 					{
@@ -79,6 +80,26 @@ var _ = Describe("PendingChanges", func() {
 				})
 			})
 
+			Context("when Ops Manager cannot list pending changes", func() {
+				It("returns an unsupported error", func() {
+					pcService.InfoReturns(api.Info{Version: "2.1-build.326"}, nil)
+
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("om pending-changes is only available with Ops Manager 2.2 or later: you are running 2.1-build.326"))
+					Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeUnsupported))
+					Expect(pcService.ListStagedPendingChangesCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the info cannot be retrieved", func() {
+				It("returns an error", func() {
+					pcService.InfoReturns(api.Info{}, errors.New("beep boop"))
+
+					err := command.Execute([]string{})
+					Expect(err).To(MatchError("could not retrieve info from targetted ops manager: beep boop"))
+				})
+			})
+
 			Context("when fetching the pending changes fails", func() {
 				It("returns an error", func() {
 					command := commands.NewPendingChanges(presenter, pcService)
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/versions"
+)
+
+type diagnosticReportService interface {
+	GetDiagnosticReport() (api.DiagnosticReport, error)
+}
+
+var stemcellFilePattern = regexp.MustCompile(`bosh-stemcell-(\d+(?:\.\d+)*)-(.+)-go_agent`)
+
+// checkProductDependencies compares the stemcell criteria and required
+// products of the tile with what is uploaded and staged, so a missing
+// dependency is reported in seconds rather than during an apply. Problems are
+// logged as warnings unless failOnMissing is set.
+func checkProductDependencies(service diagnosticReportService, logger logger, metadata extractor.Metadata, failOnMissing bool) error {
+	if metadata.StemcellCriteria.OS == "" && len(metadata.Dependencies) == 0 {
+		return nil
+	}
+
+	report, err := service.GetDiagnosticReport()
+	if err != nil {
+		if _, ok := err.(api.DiagnosticReportUnavailable); ok && !failOnMissing {
+			logger.Printf("warning: could not check the dependencies of %s %s: %s", metadata.Name, metadata.Version, err)
+			return nil
+		}
+		return fmt.Errorf("failed to check product dependencies: %w", err)
+	}
+
+	var problems []string
+
+	criteria := metadata.StemcellCriteria
+	if criteria.OS != "" && !hasCompatibleStemcell(report.Stemcells, criteria) {
+		problems = append(problems, fmt.Sprintf("no compatible %s stemcell is uploaded (requires version %s)", criteria.OS, criteria.Version))
+	}
+
+	for _, dependency := range metadata.Dependencies {
+		if !hasStagedProduct(report.StagedProducts, dependency) {
+			problems = append(problems, fmt.Sprintf("required product %s %s is not staged", dependency.Name, dependency.Version))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	if failOnMissing {
+		return fmt.Errorf("dependencies of %s %s are not met: %s", metadata.Name, metadata.Version, strings.Join(problems, "; "))
+	}
+
+	for _, problem := range problems {
+		logger.Printf("warning: %s", problem)
+	}
+
+	return nil
+}
+
+// hasCompatibleStemcell reports whether one of the uploaded stemcell files
+// is for the required OS and, like Ops Manager, accepts later stemcells of
+// the same major version when patch security updates are enabled.
+func hasCompatibleStemcell(stemcells []string, criteria extractor.StemcellCriteria) bool {
+	required, err := versions.ParseVersion(criteria.Version)
+	anyVersion := err != nil
+
+	for _, stemcell := range stemcells {
+		matches := stemcellFilePattern.FindStringSubmatch(stemcell)
+		if matches == nil {
+			continue
+		}
+
+		if matches[2] != criteria.OS && !strings.HasSuffix(matches[2], "-"+criteria.OS) {
+			continue
+		}
+
+		if anyVersion {
+			return true
+		}
+
+		version, err := versions.ParseVersion(matches[1])
+		if err != nil || version.Major != required.Major {
+			continue
+		}
+
+		cmp := version.Compare(required)
+		if cmp == 0 || (cmp > 0 && criteria.EnablePatchSecurityUpdates) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasStagedProduct(products []api.DiagnosticProduct, dependency extractor.ProductDependency) bool {
+	constraint, err := versions.NewVersionConstraint(dependency.Version)
+	if err != nil {
+		constraint, _ = versions.NewVersionConstraint(">=0")
+	}
+
+	for _, product := range products {
+		if product.Name != dependency.Name {
+			continue
+		}
+
+		// staged versions carry build numbers, e.g. 2.2.3-build.5, which
+		// should not count as prereleases here
+		version, err := versions.ParseVersion(product.Version)
+		if err != nil {
+			return true
+		}
+
+		if constraint.Check(fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)) {
+			return true
+		}
+	}
+
+	return false
+}
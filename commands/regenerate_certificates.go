@@ -5,6 +5,7 @@ import "github.com/pivotal-cf/jhanda"
 type RegenerateCertificates struct {
 	service regenerateCertificatesService
 	logger  logger
+	results resultWriter
 }
 
 //go:generate counterfeiter -o ./fakes/regenerate_certificates_service.go --fake-name RegenerateCertificatesService . regenerateCertificatesService
@@ -12,8 +13,8 @@ type regenerateCertificatesService interface {
 	RegenerateCertificates() error
 }
 
-func NewRegenerateCertificates(service regenerateCertificatesService, logger logger) RegenerateCertificates {
-	return RegenerateCertificates{service: service, logger: logger}
+func NewRegenerateCertificates(service regenerateCertificatesService, logger logger, results resultWriter) RegenerateCertificates {
+	return RegenerateCertificates{service: service, logger: logger, results: results}
 }
 
 func (r RegenerateCertificates) Execute(_ []string) error {
@@ -24,7 +25,7 @@ func (r RegenerateCertificates) Execute(_ []string) error {
 
 	r.logger.Printf("Certificates regenerated.\n")
 
-	return nil
+	return r.results.WriteResult(RegenerateCertificatesResult{Regenerated: true})
 }
 
 func (r RegenerateCertificates) Usage() jhanda.Usage {
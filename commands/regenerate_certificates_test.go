@@ -14,13 +14,15 @@ var _ = Describe("RegenerateCertificates", func() {
 	var (
 		fakeService *fakes.RegenerateCertificatesService
 		fakeLogger  *fakes.Logger
+		results     *fakes.ResultWriter
 		command     commands.RegenerateCertificates
 	)
 
 	BeforeEach(func() {
 		fakeService = &fakes.RegenerateCertificatesService{}
 		fakeLogger = &fakes.Logger{}
-		command = commands.NewRegenerateCertificates(fakeService, fakeLogger)
+		results = &fakes.ResultWriter{}
+		command = commands.NewRegenerateCertificates(fakeService, fakeLogger, results)
 	})
 
 	Describe("Execute", func() {
@@ -32,6 +34,11 @@ var _ = Describe("RegenerateCertificates", func() {
 			Expect(fakeLogger.PrintfCallCount()).To(Equal(1))
 			format, content := fakeLogger.PrintfArgsForCall(0)
 			Expect(fmt.Sprintf(format, content...)).To(Equal("Certificates regenerated.\n"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.RegenerateCertificatesResult{
+				Regenerated: true,
+			}))
 		})
 	})
 
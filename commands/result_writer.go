@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+//go:generate counterfeiter -o ./fakes/result_writer.go --fake-name ResultWriter . resultWriter
+type resultWriter interface {
+	Enabled() bool
+	WriteResult(result interface{}) error
+}
+
+// ResultWriter writes the result of a command, such as the GUID of the
+// product it staged, for pipelines to read instead of the logs. With the
+// text output the logs already describe the result, so nothing is written,
+// and neither does the zero ResultWriter.
+type ResultWriter struct {
+	stdout io.Writer
+	json   bool
+}
+
+func NewResultWriter(output string, stdout io.Writer) (ResultWriter, error) {
+	switch output {
+	case "text":
+		return ResultWriter{stdout: stdout}, nil
+	case "json":
+		return ResultWriter{stdout: stdout, json: true}, nil
+	default:
+		return ResultWriter{}, fmt.Errorf("unknown output %q: options are text or json", output)
+	}
+}
+
+// Enabled reports whether results are written, so that commands only look up
+// what is needed for the result when it is.
+func (w ResultWriter) Enabled() bool {
+	return w.json
+}
+
+func (w ResultWriter) WriteResult(result interface{}) error {
+	if !w.json {
+		return nil
+	}
+
+	contents, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not write result: %w", err)
+	}
+
+	_, err = w.stdout.Write(append(contents, '\n'))
+	if err != nil {
+		return fmt.Errorf("could not write result: %w", err)
+	}
+
+	return nil
+}
+
+type StageProductResult struct {
+	ProductName    string `json:"product_name"`
+	ProductVersion string `json:"product_version"`
+	GUID           string `json:"guid"`
+	AlreadyStaged  bool   `json:"already_staged"`
+}
+
+type UnstageProductResult struct {
+	ProductName string `json:"product_name"`
+}
+
+type ConfigureDirectorResult struct {
+	ConfiguredSections []string `json:"configured_sections"`
+	DryRun             bool     `json:"dry_run"`
+}
+
+type ConfigureProductResult struct {
+	ProductName string `json:"product_name"`
+	GUID        string `json:"guid"`
+	DryRun      bool   `json:"dry_run"`
+}
+
+type ApplyChangesResult struct {
+	InstallationID int    `json:"installation_id"`
+	Status         string `json:"status"`
+}
+
+type DeleteInstallationResult struct {
+	InstallationID  int    `json:"installation_id"`
+	Status          string `json:"status"`
+	NothingToDelete bool   `json:"nothing_to_delete"`
+}
+
+type DownloadProductResult struct {
+	ProductPath    string `json:"product_path"`
+	ProductVersion string `json:"product_version"`
+	StemcellPath   string `json:"stemcell_path,omitempty"`
+}
+
+type UploadProductResult struct {
+	ProductName     string `json:"product_name"`
+	ProductVersion  string `json:"product_version"`
+	AlreadyUploaded bool   `json:"already_uploaded"`
+}
+
+type UploadStemcellResult struct {
+	Stemcell        string `json:"stemcell"`
+	AlreadyUploaded bool   `json:"already_uploaded"`
+}
+
+type AssignStemcellResult struct {
+	ProductName     string `json:"product_name"`
+	GUID            string `json:"guid"`
+	StemcellVersion string `json:"stemcell_version"`
+}
+
+type ExportInstallationResult struct {
+	OutputFile string `json:"output_file"`
+}
+
+type BackupResult struct {
+	Dir     string   `json:"dir"`
+	Removed []string `json:"removed"`
+}
+
+// WaitForReadyResult has the version of Ops Manager only once authentication
+// has been configured, as it cannot be retrieved before.
+type WaitForReadyResult struct {
+	AuthenticationConfigured bool   `json:"authentication_configured"`
+	Version                  string `json:"version,omitempty"`
+}
+
+type ImportInstallationResult struct {
+	Installation      string `json:"installation"`
+	AlreadyConfigured bool   `json:"already_configured"`
+}
+
+type DeleteProductResult struct {
+	ProductName    string `json:"product_name"`
+	ProductVersion string `json:"product_version"`
+}
+
+type DeleteUnusedProductsResult struct {
+	Deleted bool `json:"deleted"`
+}
+
+type RevertStagedChangesResult struct {
+	Reverted bool `json:"reverted"`
+}
+
+type ConfigureAuthenticationResult struct {
+	IdentityProvider  string `json:"identity_provider"`
+	AlreadyConfigured bool   `json:"already_configured"`
+}
+
+type CreateVMExtensionResult struct {
+	Name string `json:"name"`
+}
+
+// CertificateAuthorityResult is the result of the commands that change a
+// certificate authority, which is identified by its GUID.
+type CertificateAuthorityResult struct {
+	GUID string `json:"guid"`
+}
+
+type RegenerateCertificatesResult struct {
+	Regenerated bool `json:"regenerated"`
+}
+
+type GenerateCertificateResult struct {
+	Certificate string `json:"certificate"`
+	Key         string `json:"key"`
+}
+
+// ConvergeResult reports what converge changed, rather than the results of
+// the commands it runs for every step.
+type ConvergeResult struct {
+	DirectorConfigured bool                    `json:"director_configured"`
+	Products           []ConvergeProductResult `json:"products"`
+	ChangesApplied     bool                    `json:"changes_applied"`
+}
+
+type ConvergeProductResult struct {
+	ProductName      string `json:"product_name"`
+	ProductVersion   string `json:"product_version"`
+	Uploaded         bool   `json:"uploaded"`
+	StemcellUploaded bool   `json:"stemcell_uploaded"`
+	Staged           bool   `json:"staged"`
+	StemcellAssigned bool   `json:"stemcell_assigned"`
+	Configured       bool   `json:"configured"`
+}
@@ -0,0 +1,45 @@
+package commands_test
+
+import (
+	"bytes"
+
+	"github.com/pivotal-cf/om/commands"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResultWriter", func() {
+	var stdout *bytes.Buffer
+
+	BeforeEach(func() {
+		stdout = &bytes.Buffer{}
+	})
+
+	It("writes the result as JSON with the json output", func() {
+		writer, err := commands.NewResultWriter("json", stdout)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = writer.WriteResult(commands.ApplyChangesResult{InstallationID: 42, Status: "succeeded"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stdout.String()).To(MatchJSON(`{"installation_id": 42, "status": "succeeded"}`))
+		Expect(writer.Enabled()).To(BeTrue())
+	})
+
+	It("writes nothing with the text output", func() {
+		writer, err := commands.NewResultWriter("text", stdout)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = writer.WriteResult(commands.ApplyChangesResult{InstallationID: 42, Status: "succeeded"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stdout.String()).To(BeEmpty())
+		Expect(writer.Enabled()).To(BeFalse())
+	})
+
+	It("returns an error for an unknown output", func() {
+		_, err := commands.NewResultWriter("xml", stdout)
+		Expect(err).To(MatchError(`unknown output "xml": options are text or json`))
+	})
+})
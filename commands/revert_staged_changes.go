@@ -11,6 +11,7 @@ import (
 type RevertStagedChanges struct {
 	service dashboardService
 	logger  logger
+	results resultWriter
 }
 
 //go:generate counterfeiter -o ./fakes/dashboard_service.go --fake-name DashboardService . dashboardService
@@ -20,18 +21,18 @@ type dashboardService interface {
 	PostInstallForm(ui.PostFormInput) error
 }
 
-func NewRevertStagedChanges(s dashboardService, l logger) RevertStagedChanges {
-	return RevertStagedChanges{service: s, logger: l}
+func NewRevertStagedChanges(s dashboardService, l logger, r resultWriter) RevertStagedChanges {
+	return RevertStagedChanges{service: s, logger: l, results: r}
 }
 
 func (c RevertStagedChanges) Execute(args []string) error {
 	form, err := c.service.GetRevertForm()
 	if err != nil {
-		return fmt.Errorf("could not fetch form: %s", err)
+		return fmt.Errorf("could not fetch form: %w", err)
 	}
 
 	if form == (ui.Form{}) {
-		return nil
+		return c.results.WriteResult(RevertStagedChangesResult{})
 	}
 
 	var formConfig CommonConfiguration
@@ -47,11 +48,11 @@ func (c RevertStagedChanges) Execute(args []string) error {
 	c.logger.Printf("reverting staged changes on the targeted Ops Manager")
 	err = c.service.PostInstallForm(ui.PostFormInput{Form: form, EncodedPayload: formValues.Encode()})
 	if err != nil {
-		return fmt.Errorf("failed to revert staged changes: %s", err)
+		return fmt.Errorf("failed to revert staged changes: %w", err)
 	}
 	c.logger.Printf("done")
 
-	return nil
+	return c.results.WriteResult(RevertStagedChangesResult{Reverted: true})
 }
 
 func (c RevertStagedChanges) Usage() jhanda.Usage {
@@ -17,16 +17,18 @@ var _ = Describe("RevertStagedChanges", func() {
 	var (
 		service *fakes.DashboardService
 		logger  *fakes.Logger
+		results *fakes.ResultWriter
 	)
 
 	BeforeEach(func() {
 		service = &fakes.DashboardService{}
 		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
 	})
 
 	Describe("Execute", func() {
 		It("reverts staged changes on the targeted OpsMan", func() {
-			command := commands.NewRevertStagedChanges(service, logger)
+			command := commands.NewRevertStagedChanges(service, logger, results)
 
 			service.GetRevertFormReturns(ui.Form{
 				Action:            "/installation",
@@ -51,15 +53,21 @@ var _ = Describe("RevertStagedChanges", func() {
 			Expect(fmt.Sprintf(format, content...)).To(Equal("reverting staged changes on the targeted Ops Manager"))
 			format, content = logger.PrintfArgsForCall(1)
 			Expect(fmt.Sprintf(format, content...)).To(Equal("done"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.RevertStagedChangesResult{
+				Reverted: true,
+			}))
 		})
 
 		Context("when there are no staged changes to revert", func() {
 			It("returns without error", func() {
-				command := commands.NewRevertStagedChanges(service, logger)
+				command := commands.NewRevertStagedChanges(service, logger, results)
 				service.GetRevertFormReturns(ui.Form{}, nil)
 				err := command.Execute([]string{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(service.PostInstallFormCallCount()).To(Equal(0))
+				Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.RevertStagedChangesResult{}))
 			})
 		})
 
@@ -68,7 +76,7 @@ var _ = Describe("RevertStagedChanges", func() {
 				It("returns an error", func() {
 					service.GetRevertFormReturns(ui.Form{}, errors.New("meow meow meow"))
 
-					command := commands.NewRevertStagedChanges(service, logger)
+					command := commands.NewRevertStagedChanges(service, logger, results)
 
 					err := command.Execute([]string{""})
 					Expect(err).To(MatchError("could not fetch form: meow meow meow"))
@@ -85,7 +93,7 @@ var _ = Describe("RevertStagedChanges", func() {
 
 					service.PostInstallFormReturns(errors.New("meow meow meow"))
 
-					command := commands.NewRevertStagedChanges(service, logger)
+					command := commands.NewRevertStagedChanges(service, logger, results)
 
 					err := command.Execute([]string{""})
 					Expect(err).To(MatchError("failed to revert staged changes: meow meow meow"))
@@ -96,7 +104,7 @@ var _ = Describe("RevertStagedChanges", func() {
 
 	Describe("Usage", func() {
 		It("returns the usage for the command", func() {
-			command := commands.NewRevertStagedChanges(nil, nil)
+			command := commands.NewRevertStagedChanges(nil, nil, nil)
 
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "reverts staged changes on the installation dashboard page in the target Ops Manager",
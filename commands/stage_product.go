@@ -10,6 +10,7 @@ import (
 type StageProduct struct {
 	logger  logger
 	service stageProductService
+	results resultWriter
 	Options struct {
 		Product string `long:"product-name"    short:"p" required:"true" description:"name of product"`
 		Version string `long:"product-version" short:"v" required:"true" description:"version of product"`
@@ -20,25 +21,27 @@ type StageProduct struct {
 type stageProductService interface {
 	Stage(api.StageProductInput, string) error
 	ListDeployedProducts() ([]api.DeployedProductOutput, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
 	CheckProductAvailability(productName string, productVersion string) (bool, error)
 	GetDiagnosticReport() (api.DiagnosticReport, error)
 }
 
-func NewStageProduct(service stageProductService, logger logger) StageProduct {
+func NewStageProduct(service stageProductService, logger logger, results resultWriter) StageProduct {
 	return StageProduct{
 		logger:  logger,
 		service: service,
+		results: results,
 	}
 }
 
 func (sp StageProduct) Execute(args []string) error {
 	if _, err := jhanda.Parse(&sp.Options, args); err != nil {
-		return fmt.Errorf("could not parse stage-product flags: %s", err)
+		return configErrorf("could not parse stage-product flags: %w", err)
 	}
 
 	diagnosticReport, err := sp.service.GetDiagnosticReport()
 	if err != nil {
-		return fmt.Errorf("failed to stage product: %s", err)
+		return fmt.Errorf("failed to stage product: %w", err)
 	}
 
 	deployedProductGUID := ""
@@ -50,13 +53,13 @@ func (sp StageProduct) Execute(args []string) error {
 		}
 	}
 	if err != nil {
-		return fmt.Errorf("failed to stage product: %s", err)
+		return fmt.Errorf("failed to stage product: %w", err)
 	}
 
 	for _, stagedProduct := range diagnosticReport.StagedProducts {
 		if stagedProduct.Name == sp.Options.Product && stagedProduct.Version == sp.Options.Version {
 			sp.logger.Printf("%s %s is already staged", sp.Options.Product, sp.Options.Version)
-			return nil
+			return sp.writeResult(true)
 		}
 	}
 
@@ -66,7 +69,7 @@ func (sp StageProduct) Execute(args []string) error {
 	}
 
 	if !available {
-		return fmt.Errorf("failed to stage product: cannot find product %s %s", sp.Options.Product, sp.Options.Version)
+		return NotFoundError{Err: fmt.Errorf("failed to stage product: cannot find product %s %s", sp.Options.Product, sp.Options.Version)}
 	}
 
 	sp.logger.Printf("staging %s %s", sp.Options.Product, sp.Options.Version)
@@ -76,12 +79,38 @@ func (sp StageProduct) Execute(args []string) error {
 		ProductVersion: sp.Options.Version,
 	}, deployedProductGUID)
 	if err != nil {
-		return fmt.Errorf("failed to stage product: %s", err)
+		return fmt.Errorf("failed to stage product: %w", err)
 	}
 
 	sp.logger.Printf("finished staging")
 
-	return nil
+	return sp.writeResult(false)
+}
+
+func (sp StageProduct) writeResult(alreadyStaged bool) error {
+	if !sp.results.Enabled() {
+		return nil
+	}
+
+	stagedProducts, err := sp.service.ListStagedProducts()
+	if err != nil {
+		return fmt.Errorf("could not look up the GUID of the staged product: %w", err)
+	}
+
+	result := StageProductResult{
+		ProductName:    sp.Options.Product,
+		ProductVersion: sp.Options.Version,
+		AlreadyStaged:  alreadyStaged,
+	}
+
+	for _, stagedProduct := range stagedProducts.Products {
+		if stagedProduct.Type == sp.Options.Product {
+			result.GUID = stagedProduct.GUID
+			break
+		}
+	}
+
+	return sp.results.WriteResult(result)
 }
 
 func (sp StageProduct) Usage() jhanda.Usage {
@@ -17,17 +17,19 @@ var _ = Describe("StageProduct", func() {
 	var (
 		fakeService *fakes.StageProductService
 		logger      *fakes.Logger
+		results     *fakes.ResultWriter
 	)
 
 	BeforeEach(func() {
 		fakeService = &fakes.StageProductService{}
 		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
 	})
 
 	It("stages a product", func() {
 		fakeService.CheckProductAvailabilityReturns(true, nil)
 
-		command := commands.NewStageProduct(fakeService, logger)
+		command := commands.NewStageProduct(fakeService, logger, results)
 
 		fakeService.ListDeployedProductsReturns([]api.DeployedProductOutput{
 			api.DeployedProductOutput{
@@ -57,13 +59,43 @@ var _ = Describe("StageProduct", func() {
 
 		format, v = logger.PrintfArgsForCall(1)
 		Expect(fmt.Sprintf(format, v...)).To(Equal("finished staging"))
+
+		By("not looking up the GUID without the json output")
+		Expect(fakeService.ListStagedProductsCallCount()).To(Equal(0))
+		Expect(results.WriteResultCallCount()).To(Equal(0))
+	})
+
+	It("writes the result with the GUID of the staged product", func() {
+		results.EnabledReturns(true)
+		fakeService.CheckProductAvailabilityReturns(true, nil)
+		fakeService.ListStagedProductsReturns(api.StagedProductsOutput{
+			Products: []api.StagedProduct{
+				{Type: "some-other-product", GUID: "some-other-guid"},
+				{Type: "some-product", GUID: "some-product-guid"},
+			},
+		}, nil)
+
+		command := commands.NewStageProduct(fakeService, logger, results)
+
+		err := command.Execute([]string{
+			"--product-name", "some-product",
+			"--product-version", "some-version",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(results.WriteResultCallCount()).To(Equal(1))
+		Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.StageProductResult{
+			ProductName:    "some-product",
+			ProductVersion: "some-version",
+			GUID:           "some-product-guid",
+		}))
 	})
 
 	Context("when a product has already been deployed", func() {
 		It("stages the product", func() {
 			fakeService.CheckProductAvailabilityReturns(true, nil)
 
-			command := commands.NewStageProduct(fakeService, logger)
+			command := commands.NewStageProduct(fakeService, logger, results)
 
 			fakeService.ListDeployedProductsReturns([]api.DeployedProductOutput{
 				api.DeployedProductOutput{
@@ -112,7 +144,8 @@ var _ = Describe("StageProduct", func() {
 				},
 			}, nil)
 
-			command := commands.NewStageProduct(fakeService, logger)
+			results.EnabledReturns(true)
+			command := commands.NewStageProduct(fakeService, logger, results)
 
 			err := command.Execute([]string{
 				"--product-name", "some-product",
@@ -124,13 +157,17 @@ var _ = Describe("StageProduct", func() {
 			Expect(fmt.Sprintf(format, v...)).To(Equal("some-product some-version is already staged"))
 
 			Expect(fakeService.StageCallCount()).To(Equal(0))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			result := results.WriteResultArgsForCall(0).(commands.StageProductResult)
+			Expect(result.AlreadyStaged).To(BeTrue())
 		})
 	})
 
 	Context("failure cases", func() {
 		Context("when an unknown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(fakeService, logger, results)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse stage-product flags: flag provided but not defined: -badflag"))
 			})
@@ -138,7 +175,7 @@ var _ = Describe("StageProduct", func() {
 
 		Context("when the product-name flag is not provided", func() {
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(fakeService, logger, results)
 				err := command.Execute([]string{"--product-version", "1.0"})
 				Expect(err).To(MatchError("could not parse stage-product flags: missing required flag \"--product-name\""))
 			})
@@ -146,7 +183,7 @@ var _ = Describe("StageProduct", func() {
 
 		Context("when the product-version flag is not provided", func() {
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(fakeService, logger, results)
 				err := command.Execute([]string{"--product-name", "some-product"})
 				Expect(err).To(MatchError("could not parse stage-product flags: missing required flag \"--product-version\""))
 			})
@@ -158,7 +195,7 @@ var _ = Describe("StageProduct", func() {
 			})
 
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(fakeService, logger, results)
 
 				err := command.Execute([]string{
 					"--product-name", "some-product",
@@ -174,7 +211,7 @@ var _ = Describe("StageProduct", func() {
 			})
 
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(fakeService, logger, results)
 
 				err := command.Execute([]string{
 					"--product-name", "some-product",
@@ -186,7 +223,7 @@ var _ = Describe("StageProduct", func() {
 
 		Context("when the product cannot be staged", func() {
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(fakeService, logger, results)
 				fakeService.CheckProductAvailabilityReturns(true, nil)
 				fakeService.StageReturns(errors.New("some product error"))
 
@@ -195,9 +232,21 @@ var _ = Describe("StageProduct", func() {
 			})
 		})
 
+		Context("when the staged products cannot be fetched", func() {
+			It("returns an error", func() {
+				results.EnabledReturns(true)
+				command := commands.NewStageProduct(fakeService, logger, results)
+				fakeService.CheckProductAvailabilityReturns(true, nil)
+				fakeService.ListStagedProductsReturns(api.StagedProductsOutput{}, errors.New("could not fetch staged products"))
+
+				err := command.Execute([]string{"--product-name", "some-product", "--product-version", "some-version"})
+				Expect(err).To(MatchError("could not look up the GUID of the staged product: could not fetch staged products"))
+			})
+		})
+
 		Context("when the diagnostic report cannot be fetched", func() {
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(fakeService, logger, results)
 				fakeService.CheckProductAvailabilityReturns(true, nil)
 				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("bad diagnostic report"))
 
@@ -214,7 +263,7 @@ var _ = Describe("StageProduct", func() {
 			})
 
 			It("returns an error", func() {
-				command := commands.NewStageProduct(fakeService, logger)
+				command := commands.NewStageProduct(fakeService, logger, results)
 
 				err := command.Execute([]string{
 					"--product-name", "some-product",
@@ -228,7 +277,7 @@ var _ = Describe("StageProduct", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewStageProduct(nil, nil)
+			command := commands.NewStageProduct(nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This command attempts to stage a product in the Ops Manager",
 				ShortDescription: "stages a given product in the Ops Manager targeted",
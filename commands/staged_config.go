@@ -56,7 +56,7 @@ func (ec StagedConfig) Usage() jhanda.Usage {
 
 func (ec StagedConfig) Execute(args []string) error {
 	if _, err := jhanda.Parse(&ec.Options, args); err != nil {
-		return fmt.Errorf("could not parse staged-config flags: %s", err)
+		return configErrorf("could not parse staged-config flags: %w", err)
 	}
 
 	if ec.Options.IncludeCredentials {
@@ -167,7 +167,7 @@ func (ec StagedConfig) Execute(args []string) error {
 
 	output, err := yaml.Marshal(config)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal config: %s", err) // un-tested
+		return fmt.Errorf("failed to unmarshal config: %w", err) // un-tested
 	}
 
 	ec.logger.Println(string(output))
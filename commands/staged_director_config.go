@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 
@@ -51,55 +50,90 @@ func (ec StagedDirectorConfig) Usage() jhanda.Usage {
 
 func (ec StagedDirectorConfig) Execute(args []string) error {
 	if _, err := jhanda.Parse(&ec.Options, args); err != nil {
-		return fmt.Errorf("could not parse staged-config flags: %s", err)
+		return configErrorf("could not parse staged-config flags: %w", err)
 	}
 
-	stagedDirector, err := ec.service.GetStagedProductByName("p-bosh")
+	config, err := ec.stagedConfig()
 	if err != nil {
 		return err
 	}
 
+	configYaml, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	ec.logger.Println(string(configYaml))
+	return nil
+}
+
+// stagedConfig returns the config of the staged director, in the format of
+// configure-director, with its secrets filtered as the options ask for.
+func (ec StagedDirectorConfig) stagedConfig() (map[string]interface{}, error) {
+	stagedDirector, err := ec.service.GetStagedProductByName("p-bosh")
+	if err != nil {
+		return nil, err
+	}
+
 	directorGUID := stagedDirector.Product.GUID
 
 	azs, err := ec.service.GetStagedDirectorAvailabilityZones()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	properties, err := ec.service.GetStagedDirectorProperties()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	networks, err := ec.service.GetStagedDirectorNetworks()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	assignedNetworkAZ, err := ec.service.GetStagedProductNetworksAndAZs(directorGUID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	jobs, err := ec.service.ListStagedProductJobs(directorGUID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	vmExtensions, err := ec.service.ListStagedVMExtensions()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// the guids of the iaas configuration are generated by Ops Manager, so
+	// they cannot be applied to another Ops Manager
+	delete(properties["iaas_configuration"], "guid")
+	for i := range azs.AvailabilityZones {
+		azs.AvailabilityZones[i].IAASConfigurationGUID = ""
 	}
 
 	config := map[string]interface{}{}
 	if azs.AvailabilityZones != nil {
 		config["az-configuration"] = azs.AvailabilityZones
 	}
-	config["director-configuration"] = properties["director_configuration"]
-	config["iaas-configuration"] = properties["iaas_configuration"]
-	config["syslog-configuration"] = properties["syslog_configuration"]
-	config["security-configuration"] = properties["security_configuration"]
-	config["network-assignment"] = assignedNetworkAZ
+
+	// sections that are not set on the director are left out, as
+	// configure-director would otherwise overwrite them with empty values
+	for key, property := range map[string]string{
+		"director-configuration": "director_configuration",
+		"iaas-configuration":     "iaas_configuration",
+		"syslog-configuration":   "syslog_configuration",
+		"security-configuration": "security_configuration",
+	} {
+		if properties[property] != nil {
+			config[key] = properties[property]
+		}
+	}
+	if len(assignedNetworkAZ) > 0 {
+		config["network-assignment"] = assignedNetworkAZ
+	}
 	config["networks-configuration"] = networks
 	config["vmextensions-configuration"] = vmExtensions
 
@@ -107,7 +141,7 @@ func (ec StagedDirectorConfig) Execute(args []string) error {
 	for name, jobGUID := range jobs {
 		resourceConfig, err := ec.service.GetStagedProductJobResourceConfig(directorGUID, jobGUID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		resourceConfigs[name] = resourceConfig
 	}
@@ -120,20 +154,14 @@ func (ec StagedDirectorConfig) Execute(args []string) error {
 	for key, value := range config {
 		returnedVal, err := ec.filterSecrets(key, key, value)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if returnedVal != nil {
 			config[key] = returnedVal
 		}
 	}
 
-	configYaml, err := yaml.Marshal(config)
-	if err != nil {
-		return err
-	}
-
-	ec.logger.Println(string(configYaml))
-	return nil
+	return config, nil
 }
 
 func (ec StagedDirectorConfig) filterSecrets(prefix string, keyName string, value interface{}) (interface{}, error) {
@@ -53,6 +53,7 @@ var _ = Describe("StagedDirectorConfig", func() {
 					},
 				},
 				"iaas_configuration": {
+					"guid":    "some-iaas-guid",
 					"project": "project-id",
 					"key":     "some-key",
 				},
@@ -127,7 +128,6 @@ var _ = Describe("StagedDirectorConfig", func() {
 			Expect(output).To(ContainElement(MatchYAML(`
 az-configuration:
 - name: some-az
-  iaas_configuration_guid: some-iaas-guid
 - name: some-other-az
 director-configuration:
   max_threads: 5
@@ -205,6 +205,46 @@ vmextensions-configuration:
 			})
 		})
 
+		Describe("when the director has not been fully configured", func() {
+			It("leaves out the sections that are not set", func() {
+				fakeService.GetStagedDirectorPropertiesReturns(map[string]map[string]interface{}{
+					"director_configuration": {
+						"max_threads": 5,
+					},
+				}, nil)
+				fakeService.GetStagedProductNetworksAndAZsReturns(map[string]interface{}{}, nil)
+
+				command := commands.NewStagedDirectorConfig(fakeService, logger)
+				err := command.Execute([]string{})
+				Expect(err).NotTo(HaveOccurred())
+
+				output := logger.PrintlnArgsForCall(0)
+				Expect(output).To(ContainElement(MatchYAML(`
+az-configuration:
+- name: some-az
+- name: some-other-az
+director-configuration:
+  max_threads: 5
+networks-configuration:
+  icmp_checks_enabled: false
+  networks:
+  - name: network-1
+resource-configuration:
+  some-job:
+    instances: 1
+    instance_type:
+      id: automatic
+vmextensions-configuration:
+  - name: vm_ext1
+    cloud_properties: 
+      source_dest_check: false
+  - name: vm_ext2
+    cloud_properties:
+      key_name: operations_keypair
+`)))
+			})
+		})
+
 		Describe("with --include-credentials", func() {
 			It("Includes the filtered fields when printing to stdout", func() {
 				command := commands.NewStagedDirectorConfig(fakeService, logger)
@@ -217,7 +257,6 @@ vmextensions-configuration:
 				Expect(output).To(ContainElement(MatchYAML(`
 az-configuration:
 - name: some-az
-  iaas_configuration_guid: some-iaas-guid
 - name: some-other-az
 director-configuration:
   filtered_key: filtered_key
@@ -272,7 +311,6 @@ vmextensions-configuration:
 				Expect(output).To(ContainElement(MatchYAML(`
 az-configuration:
 - name: some-az
-  iaas_configuration_guid: some-iaas-guid
 - name: some-other-az
 director-configuration:
   filtered_key: ((director-configuration_filtered_key))
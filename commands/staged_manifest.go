@@ -30,17 +30,17 @@ func NewStagedManifest(service stagedManifestService, logger logger) StagedManif
 
 func (sm StagedManifest) Execute(args []string) error {
 	if _, err := jhanda.Parse(&sm.Options, args); err != nil {
-		return fmt.Errorf("could not parse staged-manifest flags: %s", err)
+		return configErrorf("could not parse staged-manifest flags: %w", err)
 	}
 
 	output, err := sm.service.GetStagedProductByName(sm.Options.ProductName)
 	if err != nil {
-		return fmt.Errorf("failed to find product: %s", err)
+		return fmt.Errorf("failed to find product: %w", err)
 	}
 
 	manifest, err := sm.service.GetStagedProductManifest(output.Product.GUID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch product manifest: %s", err)
+		return fmt.Errorf("failed to fetch product manifest: %w", err)
 	}
 
 	sm.logger.Print(manifest)
@@ -12,7 +12,7 @@ type StagedProducts struct {
 	presenter presenters.FormattedPresenter
 	service   stagedProductsService
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json,yaml,csv,template=<go template>)"`
 	}
 }
 
@@ -30,20 +30,22 @@ func NewStagedProducts(presenter presenters.FormattedPresenter, service stagedPr
 
 func (sp StagedProducts) Execute(args []string) error {
 	if _, err := jhanda.Parse(&sp.Options, args); err != nil {
-		return fmt.Errorf("could not parse staged-products flags: %s", err)
+		return configErrorf("could not parse staged-products flags: %w", err)
 	}
 
 	diagnosticReport, err := sp.service.GetDiagnosticReport()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve staged products %s", err)
+		return fmt.Errorf("failed to retrieve staged products %w", err)
 	}
 
 	stagedProducts := diagnosticReport.StagedProducts
 
-	sp.presenter.SetFormat(sp.Options.Format)
-	sp.presenter.PresentStagedProducts(stagedProducts)
+	err = sp.presenter.SetFormat(sp.Options.Format)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return sp.presenter.PresentStagedProducts(stagedProducts)
 }
 
 func (sp StagedProducts) Usage() jhanda.Usage {
@@ -27,7 +27,7 @@ func NewTileMetadata(stdout logger) TileMetadata {
 
 func (t TileMetadata) Execute(args []string) error {
 	if _, err := jhanda.Parse(&t.Options, args); err != nil {
-		return fmt.Errorf("could not parse tile-metadata flags: %s", err)
+		return configErrorf("could not parse tile-metadata flags: %w", err)
 	}
 
 	if !t.Options.ProductName && !t.Options.ProductVersion {
@@ -36,20 +36,20 @@ func (t TileMetadata) Execute(args []string) error {
 
 	file, err := zip.OpenReader(t.Options.ProductPath)
 	if err != nil {
-		return fmt.Errorf("failed to open product file: %s", err)
+		return fmt.Errorf("failed to open product file: %w", err)
 	}
 	defer file.Close()
 
 	for _, f := range file.File {
 		matched, err := regexp.MatchString("metadata/.+\\.yml", f.Name)
 		if err != nil {
-			return fmt.Errorf("failed to match file name regex: %s", err)
+			return fmt.Errorf("failed to match file name regex: %w", err)
 		}
 
 		if matched {
 			meta, err := f.Open()
 			if err != nil {
-				return fmt.Errorf("failed to open metadata file: %s", err)
+				return fmt.Errorf("failed to open metadata file: %w", err)
 			}
 
 			type DecodedFile struct {
@@ -59,7 +59,7 @@ func (t TileMetadata) Execute(args []string) error {
 			var v DecodedFile
 			err = yaml.NewDecoder(meta).Decode(&v)
 			if err != nil {
-				return fmt.Errorf("failed to decode metadata file: %s", err)
+				return fmt.Errorf("failed to decode metadata file: %w", err)
 			}
 
 			if t.Options.ProductName {
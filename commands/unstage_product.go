@@ -10,6 +10,7 @@ import (
 type UnstageProduct struct {
 	logger  logger
 	service unstageProductService
+	results resultWriter
 	Options struct {
 		Product string `long:"product-name" short:"p" required:"true" description:"name of product"`
 	}
@@ -20,16 +21,17 @@ type unstageProductService interface {
 	DeleteStagedProduct(api.UnstageProductInput) error
 }
 
-func NewUnstageProduct(service unstageProductService, logger logger) UnstageProduct {
+func NewUnstageProduct(service unstageProductService, logger logger, results resultWriter) UnstageProduct {
 	return UnstageProduct{
 		logger:  logger,
 		service: service,
+		results: results,
 	}
 }
 
 func (up UnstageProduct) Execute(args []string) error {
 	if _, err := jhanda.Parse(&up.Options, args); err != nil {
-		return fmt.Errorf("could not parse unstage-product flags: %s", err)
+		return configErrorf("could not parse unstage-product flags: %w", err)
 	}
 
 	up.logger.Printf("unstaging %s", up.Options.Product)
@@ -39,12 +41,14 @@ func (up UnstageProduct) Execute(args []string) error {
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to unstage product: %s", err)
+		return fmt.Errorf("failed to unstage product: %w", err)
 	}
 
 	up.logger.Printf("finished unstaging")
 
-	return nil
+	return up.results.WriteResult(UnstageProductResult{
+		ProductName: up.Options.Product,
+	})
 }
 
 func (up UnstageProduct) Usage() jhanda.Usage {
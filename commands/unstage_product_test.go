@@ -17,15 +17,17 @@ var _ = Describe("UnstageProduct", func() {
 	var (
 		fakeService *fakes.UnstageProductService
 		logger      *fakes.Logger
+		results     *fakes.ResultWriter
 	)
 
 	BeforeEach(func() {
 		fakeService = &fakes.UnstageProductService{}
 		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
 	})
 
 	It("unstages a product", func() {
-		command := commands.NewUnstageProduct(fakeService, logger)
+		command := commands.NewUnstageProduct(fakeService, logger, results)
 
 		err := command.Execute([]string{
 			"--product-name", "some-product",
@@ -43,12 +45,17 @@ var _ = Describe("UnstageProduct", func() {
 
 		format, v = logger.PrintfArgsForCall(1)
 		Expect(fmt.Sprintf(format, v...)).To(Equal("finished unstaging"))
+
+		Expect(results.WriteResultCallCount()).To(Equal(1))
+		Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.UnstageProductResult{
+			ProductName: "some-product",
+		}))
 	})
 
 	Context("failure cases", func() {
 		Context("when an unknown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewUnstageProduct(fakeService, logger)
+				command := commands.NewUnstageProduct(fakeService, logger, results)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse unstage-product flags: flag provided but not defined: -badflag"))
 			})
@@ -56,7 +63,7 @@ var _ = Describe("UnstageProduct", func() {
 
 		Context("when the product-name flag is not provided", func() {
 			It("returns an error", func() {
-				command := commands.NewUnstageProduct(fakeService, logger)
+				command := commands.NewUnstageProduct(fakeService, logger, results)
 				err := command.Execute([]string{})
 				Expect(err).To(MatchError("could not parse unstage-product flags: missing required flag \"--product-name\""))
 			})
@@ -64,7 +71,7 @@ var _ = Describe("UnstageProduct", func() {
 
 		Context("when the product cannot be unstaged", func() {
 			It("returns an error", func() {
-				command := commands.NewUnstageProduct(fakeService, logger)
+				command := commands.NewUnstageProduct(fakeService, logger, results)
 				fakeService.DeleteStagedProductReturns(errors.New("some product error"))
 
 				err := command.Execute([]string{"--product-name", "some-product"})
@@ -76,7 +83,7 @@ var _ = Describe("UnstageProduct", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewUnstageProduct(nil, nil)
+			command := commands.NewUnstageProduct(nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This command attempts to unstage a product from the Ops Manager",
 				ShortDescription: "unstages a given product from the Ops Manager targeted",
@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/pivotal-cf/jhanda"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/extractor"
@@ -13,12 +14,15 @@ type UploadProduct struct {
 	multipart multipart
 	logger    logger
 	service   uploadProductService
+	results   resultWriter
+	varsStore boshtpl.Variables
 	Options   struct {
-		ConfigFile      string `long:"config"           short:"c"   description:"path to yml file for configuration (keys must match the following command line flags)"`
-		Product         string `long:"product"          short:"p"   description:"path to product" required:"true"`
-		PollingInterval int    `long:"polling-interval" short:"pi"  description:"interval (in seconds) at which to print status" default:"1"`
-		Sha256          string `long:"sha256"                       description:"sha256 of the provided product file to be used for validation"`
-		Version         string `long:"product-version"                      description:"version of the provided product file to be used for validation"`
+		ConfigFile                string `long:"config"           short:"c"   description:"path to yml file for configuration (keys must match the following command line flags)"`
+		Product                   string `long:"product"          short:"p"   description:"path to product" required:"true"`
+		PollingInterval           int    `long:"polling-interval" short:"pi"  description:"interval (in seconds) at which to print status" default:"1"`
+		Sha256                    string `long:"sha256"                       description:"sha256 of the provided product file to be used for validation"`
+		Version                   string `long:"product-version"                      description:"version of the provided product file to be used for validation"`
+		FailOnMissingDependencies bool   `long:"fail-on-missing-dependencies" description:"fail instead of warning when no compatible stemcell is uploaded or a product required by the tile is not staged"`
 	}
 	metadataExtractor metadataExtractor
 }
@@ -27,6 +31,7 @@ type UploadProduct struct {
 type uploadProductService interface {
 	UploadAvailableProduct(api.UploadAvailableProductInput) (api.UploadAvailableProductOutput, error)
 	CheckProductAvailability(string, string) (bool, error)
+	GetDiagnosticReport() (api.DiagnosticReport, error)
 }
 
 //go:generate counterfeiter -o ./fakes/metadata_extractor.go --fake-name MetadataExtractor . metadataExtractor
@@ -34,12 +39,14 @@ type metadataExtractor interface {
 	ExtractMetadata(string) (extractor.Metadata, error)
 }
 
-func NewUploadProduct(multipart multipart, metadataExtractor metadataExtractor, service uploadProductService, logger logger) UploadProduct {
+func NewUploadProduct(multipart multipart, metadataExtractor metadataExtractor, service uploadProductService, logger logger, results resultWriter, varsStore boshtpl.Variables) UploadProduct {
 	return UploadProduct{
 		multipart:         multipart,
 		metadataExtractor: metadataExtractor,
 		logger:            logger,
 		service:           service,
+		results:           results,
+		varsStore:         varsStore,
 	}
 }
 
@@ -52,9 +59,9 @@ func (up UploadProduct) Usage() jhanda.Usage {
 }
 
 func (up UploadProduct) Execute(args []string) error {
-	err := loadConfigFile(args, &up.Options, nil)
+	err := loadConfigFile(args, &up.Options, nil, up.varsStore)
 	if err != nil {
-		return fmt.Errorf("could not parse upload-product flags: %s", err)
+		return configErrorf("could not parse upload-product flags: %w", err)
 	}
 
 	if up.Options.Sha256 != "" {
@@ -74,7 +81,7 @@ func (up UploadProduct) Execute(args []string) error {
 
 	metadata, err := up.metadataExtractor.ExtractMetadata(up.Options.Product)
 	if err != nil {
-		return fmt.Errorf("failed to extract product metadata: %s", err)
+		return fmt.Errorf("failed to extract product metadata: %w", err)
 	}
 
 	if up.Options.Version != "" {
@@ -86,23 +93,32 @@ func (up UploadProduct) Execute(args []string) error {
 
 	prodAvailable, err := up.service.CheckProductAvailability(metadata.Name, metadata.Version)
 	if err != nil {
-		return fmt.Errorf("failed to check product availability: %s", err)
+		return fmt.Errorf("failed to check product availability: %w", err)
 	}
 
 	if prodAvailable {
 		up.logger.Printf("product %s %s is already uploaded, nothing to be done.", metadata.Name, metadata.Version)
-		return nil
+		return up.results.WriteResult(UploadProductResult{
+			ProductName:     metadata.Name,
+			ProductVersion:  metadata.Version,
+			AlreadyUploaded: true,
+		})
+	}
+
+	err = checkProductDependencies(up.service, up.logger, metadata, up.Options.FailOnMissingDependencies)
+	if err != nil {
+		return err
 	}
 
 	up.logger.Printf("processing product")
 	err = up.multipart.AddFile("product[file]", up.Options.Product)
 	if err != nil {
-		return fmt.Errorf("failed to load product: %s", err)
+		return fmt.Errorf("failed to load product: %w", err)
 	}
 
 	submission := up.multipart.Finalize()
 	if err != nil {
-		return fmt.Errorf("failed to create multipart form: %s", err)
+		return fmt.Errorf("failed to create multipart form: %w", err)
 	}
 
 	up.logger.Printf("beginning product upload to Ops Manager")
@@ -114,10 +130,13 @@ func (up UploadProduct) Execute(args []string) error {
 		PollingInterval: up.Options.PollingInterval,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to upload product: %s", err)
+		return fmt.Errorf("failed to upload product: %w", err)
 	}
 
 	up.logger.Printf("finished upload")
 
-	return nil
+	return up.results.WriteResult(UploadProductResult{
+		ProductName:    metadata.Name,
+		ProductVersion: metadata.Version,
+	})
 }
@@ -24,6 +24,7 @@ var _ = Describe("UploadProduct", func() {
 		metadataExtractor *fakes.MetadataExtractor
 		multipart         *fakes.Multipart
 		logger            *fakes.Logger
+		results           *fakes.ResultWriter
 	)
 
 	BeforeEach(func() {
@@ -31,6 +32,7 @@ var _ = Describe("UploadProduct", func() {
 		fakeService = &fakes.UploadProductService{}
 		metadataExtractor = &fakes.MetadataExtractor{}
 		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
 	})
 
 	It("uploads a product", func() {
@@ -41,7 +43,7 @@ var _ = Describe("UploadProduct", func() {
 		}
 		multipart.FinalizeReturns(submission)
 
-		command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+		command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 
 		err := command.Execute([]string{
 			"--product", "/path/to/some-product.tgz",
@@ -70,9 +72,29 @@ var _ = Describe("UploadProduct", func() {
 		Expect(fmt.Sprintf(format, v...)).To(Equal("finished upload"))
 	})
 
+	It("writes the uploaded product as the result", func() {
+		metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+			Name:    "cf",
+			Version: "1.5.0",
+		}, nil)
+
+		command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
+
+		err := command.Execute([]string{
+			"--product", "/path/to/some-product.tgz",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(results.WriteResultCallCount()).To(Equal(1))
+		Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.UploadProductResult{
+			ProductName:    "cf",
+			ProductVersion: "1.5.0",
+		}))
+	})
+
 	Context("when the polling interval is provided", func() {
 		It("passes the value to the products service", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 			err := command.Execute([]string{
 				"--product", "/path/to/some-product.tgz",
 				"--polling-interval", "48",
@@ -84,7 +106,7 @@ var _ = Describe("UploadProduct", func() {
 
 	Context("when the same product is already present", func() {
 		It("does nothing and exits gracefully", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
 				Name:    "cf",
 				Version: "1.5.0",
@@ -105,6 +127,12 @@ var _ = Describe("UploadProduct", func() {
 
 			format, v := logger.PrintfArgsForCall(0)
 			Expect(fmt.Sprintf(format, v...)).To(Equal("product cf 1.5.0 is already uploaded, nothing to be done."))
+
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.UploadProductResult{
+				ProductName:     "cf",
+				ProductVersion:  "1.5.0",
+				AlreadyUploaded: true,
+			}))
 		})
 	})
 
@@ -118,7 +146,7 @@ var _ = Describe("UploadProduct", func() {
 
 			file.WriteString("testing-shasum")
 
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
 				Name:    "cf",
 				Version: "1.5.0",
@@ -151,7 +179,7 @@ var _ = Describe("UploadProduct", func() {
 
 			file.WriteString("testing-shasum")
 
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 			err = command.Execute([]string{
 				"--product", file.Name(),
 				"--sha256", "not-the-correct-shasum",
@@ -161,7 +189,7 @@ var _ = Describe("UploadProduct", func() {
 		})
 
 		It("fails when the file can not calculate a shasum", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 			err := command.Execute([]string{
 				"--product", "/path/to/testing.tgz",
 				"--sha256", "not-the-correct-shasum",
@@ -182,7 +210,7 @@ var _ = Describe("UploadProduct", func() {
 				Name:    "cf",
 				Version: "1.5.0",
 			}, nil)
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 			fakeService.CheckProductAvailabilityStub = func(name, version string) (bool, error) {
 				if name == "cf" && version == "1.5.0" {
 					return true, nil
@@ -212,7 +240,7 @@ var _ = Describe("UploadProduct", func() {
 				Name:    "cf",
 				Version: "1.5.0",
 			}, nil)
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 			err = command.Execute([]string{
 				"--product", file.Name(),
 				"--product-version", "2.5.0",
@@ -248,7 +276,7 @@ product: will-be-overridden-by-command-line
 				Name:    "cf",
 				Version: "1.5.0",
 			}, nil)
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 			fakeService.CheckProductAvailabilityStub = func(name, version string) (bool, error) {
 				if name == "cf" && version == "1.5.0" {
 					return true, nil
@@ -269,10 +297,115 @@ product: will-be-overridden-by-command-line
 		})
 	})
 
+	Context("when the product has stemcell criteria and dependencies", func() {
+		BeforeEach(func() {
+			metadataExtractor.ExtractMetadataReturns(extractor.Metadata{
+				Name:    "some-product",
+				Version: "1.2.3",
+				StemcellCriteria: extractor.StemcellCriteria{
+					OS:                         "ubuntu-xenial",
+					Version:                    "97.19",
+					EnablePatchSecurityUpdates: true,
+				},
+				Dependencies: []extractor.ProductDependency{
+					{Name: "cf", Version: "~> 2.2"},
+				},
+			}, nil)
+		})
+
+		It("uploads the product when they are met", func() {
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				Stemcells: []string{
+					"bosh-stemcell-3586.25-vsphere-esxi-ubuntu-trusty-go_agent.tgz",
+					"light-bosh-stemcell-97.22-google-kvm-ubuntu-xenial-go_agent.tgz",
+				},
+				StagedProducts: []api.DiagnosticProduct{
+					{Name: "cf", Version: "2.3.1-build.5"},
+				},
+			}, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
+			err := command.Execute([]string{"--product", "/path/to/some-product.tgz", "--fail-on-missing-dependencies"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(1))
+		})
+
+		It("warns about missing dependencies and uploads the product", func() {
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				Stemcells: []string{
+					"bosh-stemcell-96.3-vsphere-esxi-ubuntu-xenial-go_agent.tgz",
+					"bosh-stemcell-97.19-vsphere-esxi-ubuntu-trusty-go_agent.tgz",
+				},
+				StagedProducts: []api.DiagnosticProduct{
+					{Name: "cf", Version: "3.0.0-build.1"},
+				},
+			}, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
+			err := command.Execute([]string{"--product", "/path/to/some-product.tgz"})
+			Expect(err).NotTo(HaveOccurred())
+
+			format, v := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("warning: no compatible ubuntu-xenial stemcell is uploaded (requires version 97.19)"))
+
+			format, v = logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("warning: required product cf ~> 2.2 is not staged"))
+
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(1))
+		})
+
+		It("only accepts the exact stemcell version when patch security updates are disabled", func() {
+			metadata := extractor.Metadata{
+				Name:    "some-product",
+				Version: "1.2.3",
+				StemcellCriteria: extractor.StemcellCriteria{
+					OS:      "ubuntu-xenial",
+					Version: "97.19",
+				},
+			}
+			metadataExtractor.ExtractMetadataReturns(metadata, nil)
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				Stemcells: []string{"bosh-stemcell-97.22-vsphere-esxi-ubuntu-xenial-go_agent.tgz"},
+			}, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
+			err := command.Execute([]string{"--product", "/path/to/some-product.tgz", "--fail-on-missing-dependencies"})
+			Expect(err).To(MatchError("dependencies of some-product 1.2.3 are not met: no compatible ubuntu-xenial stemcell is uploaded (requires version 97.19)"))
+		})
+
+		Context("when --fail-on-missing-dependencies is set", func() {
+			It("returns an error without uploading the product", func() {
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
+				err := command.Execute([]string{"--product", "/path/to/some-product.tgz", "--fail-on-missing-dependencies"})
+				Expect(err).To(MatchError("dependencies of some-product 1.2.3 are not met: " +
+					"no compatible ubuntu-xenial stemcell is uploaded (requires version 97.19); " +
+					"required product cf ~> 2.2 is not staged"))
+
+				Expect(multipart.AddFileCallCount()).To(Equal(0))
+				Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the diagnostic report is unavailable", func() {
+			It("warns and uploads the product", func() {
+				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, api.DiagnosticReportUnavailable{})
+
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
+				err := command.Execute([]string{"--product", "/path/to/some-product.tgz"})
+				Expect(err).NotTo(HaveOccurred())
+
+				format, v := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, v...)).To(Equal("warning: could not check the dependencies of some-product 1.2.3: diagnostic report is currently unavailable"))
+				Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(1))
+			})
+		})
+	})
+
 	Context("failure cases", func() {
 		Context("when an unknown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse upload-product flags: flag provided but not defined: -badflag"))
 			})
@@ -280,7 +413,7 @@ product: will-be-overridden-by-command-line
 
 		Context("when the product flag is not provided", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 				err := command.Execute([]string{})
 				Expect(err).To(MatchError("could not parse upload-product flags: missing required flag \"--product\""))
 			})
@@ -289,7 +422,7 @@ product: will-be-overridden-by-command-line
 		Context("when extracting the product metadata returns an error", func() {
 			It("returns an error", func() {
 				metadataExtractor.ExtractMetadataReturns(extractor.Metadata{}, errors.New("some error"))
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 				err := command.Execute([]string{"--product", "/some/path"})
 				Expect(err).To(MatchError("failed to extract product metadata: some error"))
 			})
@@ -298,7 +431,7 @@ product: will-be-overridden-by-command-line
 		Context("when checking for product availability returns an error", func() {
 			It("returns an error", func() {
 				fakeService.CheckProductAvailabilityReturns(true, errors.New("some error"))
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 				err := command.Execute([]string{"--product", "/some/path"})
 				Expect(err).To(MatchError("failed to check product availability: some error"))
 			})
@@ -306,7 +439,7 @@ product: will-be-overridden-by-command-line
 
 		Context("when adding the file fails", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 				multipart.AddFileReturns(errors.New("bad file"))
 
 				err := command.Execute([]string{"--product", "/some/path"})
@@ -316,7 +449,7 @@ product: will-be-overridden-by-command-line
 
 		Context("when the product cannot be uploaded", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, results, nil)
 				fakeService.UploadAvailableProductReturns(api.UploadAvailableProductOutput{}, errors.New("some product error"))
 
 				err := command.Execute([]string{"--product", "/some/path"})
@@ -327,7 +460,7 @@ product: will-be-overridden-by-command-line
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewUploadProduct(nil, nil, nil, nil)
+			command := commands.NewUploadProduct(nil, nil, nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This command attempts to upload a product to the Ops Manager",
 				ShortDescription: "uploads a given product to the Ops Manager targeted",
@@ -16,6 +16,7 @@ type UploadStemcell struct {
 	multipart multipart
 	logger    logger
 	service   uploadStemcellService
+	results   resultWriter
 	Options   struct {
 		Stemcell string `long:"stemcell" short:"s" required:"true" description:"path to stemcell"`
 		Force    bool   `long:"force"    short:"f"                 description:"upload stemcell even if it already exists on the target Ops Manager"`
@@ -37,11 +38,12 @@ type uploadStemcellService interface {
 	GetDiagnosticReport() (api.DiagnosticReport, error)
 }
 
-func NewUploadStemcell(multipart multipart, service uploadStemcellService, logger logger) UploadStemcell {
+func NewUploadStemcell(multipart multipart, service uploadStemcellService, logger logger, results resultWriter) UploadStemcell {
 	return UploadStemcell{
 		multipart: multipart,
 		logger:    logger,
 		service:   service,
+		results:   results,
 	}
 }
 
@@ -55,7 +57,7 @@ func (us UploadStemcell) Usage() jhanda.Usage {
 
 func (us UploadStemcell) Execute(args []string) error {
 	if _, err := jhanda.Parse(&us.Options, args); err != nil {
-		return fmt.Errorf("could not parse upload-stemcell flags: %s", err)
+		return configErrorf("could not parse upload-stemcell flags: %w", err)
 	}
 
 	if us.Options.Shasum != "" {
@@ -81,31 +83,34 @@ func (us UploadStemcell) Execute(args []string) error {
 			case api.DiagnosticReportUnavailable:
 				us.logger.Printf("%s", err)
 			default:
-				return fmt.Errorf("failed to get diagnostic report: %s", err)
+				return fmt.Errorf("failed to get diagnostic report: %w", err)
 			}
 		}
 
 		for _, stemcell := range report.Stemcells {
 			if stemcell == filepath.Base(us.Options.Stemcell) {
 				us.logger.Printf("stemcell has already been uploaded")
-				return nil
+				return us.results.WriteResult(UploadStemcellResult{
+					Stemcell:        stemcell,
+					AlreadyUploaded: true,
+				})
 			}
 		}
 	}
 
 	err := us.multipart.AddFile("stemcell[file]", us.Options.Stemcell)
 	if err != nil {
-		return fmt.Errorf("failed to load stemcell: %s", err)
+		return fmt.Errorf("failed to load stemcell: %w", err)
 	}
 
 	err = us.multipart.AddField("stemcell[floating]", strconv.FormatBool(us.Options.Floating))
 	if err != nil {
-		return fmt.Errorf("failed to load stemcell: %s", err)
+		return fmt.Errorf("failed to load stemcell: %w", err)
 	}
 
 	submission := us.multipart.Finalize()
 	if err != nil {
-		return fmt.Errorf("failed to create multipart form: %s", err)
+		return fmt.Errorf("failed to create multipart form: %w", err)
 	}
 
 	us.logger.Printf("beginning stemcell upload to Ops Manager")
@@ -116,10 +121,12 @@ func (us UploadStemcell) Execute(args []string) error {
 		ContentLength: submission.ContentLength,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to upload stemcell: %s", err)
+		return fmt.Errorf("failed to upload stemcell: %w", err)
 	}
 
 	us.logger.Printf("finished upload")
 
-	return nil
+	return us.results.WriteResult(UploadStemcellResult{
+		Stemcell: filepath.Base(us.Options.Stemcell),
+	})
 }
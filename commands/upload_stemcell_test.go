@@ -22,12 +22,14 @@ var _ = Describe("UploadStemcell", func() {
 		fakeService *fakes.UploadStemcellService
 		multipart   *fakes.Multipart
 		logger      *fakes.Logger
+		results     *fakes.ResultWriter
 	)
 
 	BeforeEach(func() {
 		multipart = &fakes.Multipart{}
 		fakeService = &fakes.UploadStemcellService{}
 		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
 	})
 
 	Context("uploads the stemcell", func() {
@@ -41,7 +43,7 @@ var _ = Describe("UploadStemcell", func() {
 
 			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{Stemcells: []string{}}, nil)
 
-			command := commands.NewUploadStemcell(multipart, fakeService, logger)
+			command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 
 			err := command.Execute([]string{
 				"--stemcell", "/path/to/stemcell.tgz",
@@ -72,6 +74,11 @@ var _ = Describe("UploadStemcell", func() {
 
 			format, v = logger.PrintfArgsForCall(2)
 			Expect(fmt.Sprintf(format, v...)).To(Equal("finished upload"))
+
+			Expect(results.WriteResultCallCount()).To(Equal(1))
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.UploadStemcellResult{
+				Stemcell: "stemcell.tgz",
+			}))
 		})
 
 		It("disables floating", func() {
@@ -84,7 +91,7 @@ var _ = Describe("UploadStemcell", func() {
 
 			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{Stemcells: []string{}}, nil)
 
-			command := commands.NewUploadStemcell(multipart, fakeService, logger)
+			command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 
 			err := command.Execute([]string{
 				"--stemcell", "/path/to/stemcell.tgz",
@@ -133,7 +140,7 @@ var _ = Describe("UploadStemcell", func() {
 					Stemcells: []string{"stemcell.tgz"},
 				}, nil)
 
-				command := commands.NewUploadStemcell(multipart, fakeService, logger)
+				command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 
 				err := command.Execute([]string{
 					"--stemcell", "/path/to/stemcell.tgz",
@@ -142,6 +149,12 @@ var _ = Describe("UploadStemcell", func() {
 
 				format, v := logger.PrintfArgsForCall(1)
 				Expect(fmt.Sprintf(format, v...)).To(Equal("stemcell has already been uploaded"))
+
+				Expect(results.WriteResultCallCount()).To(Equal(1))
+				Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.UploadStemcellResult{
+					Stemcell:        "stemcell.tgz",
+					AlreadyUploaded: true,
+				}))
 			})
 		})
 
@@ -158,7 +171,7 @@ var _ = Describe("UploadStemcell", func() {
 					Stemcells: []string{"stemcell.tgz"},
 				}, nil)
 
-				command := commands.NewUploadStemcell(multipart, fakeService, logger)
+				command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 
 				err := command.Execute([]string{
 					"--stemcell", "/path/to/stemcell.tgz",
@@ -205,7 +218,7 @@ var _ = Describe("UploadStemcell", func() {
 
 			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{Stemcells: []string{}}, nil)
 
-			command := commands.NewUploadStemcell(multipart, fakeService, logger)
+			command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 			err = command.Execute([]string{
 				"--stemcell", file.Name(),
 				"--shasum", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
@@ -224,7 +237,7 @@ var _ = Describe("UploadStemcell", func() {
 
 			file.WriteString("testing-shasum")
 
-			command := commands.NewUploadStemcell(multipart, fakeService, logger)
+			command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 			err = command.Execute([]string{
 				"--stemcell", file.Name(),
 				"--shasum", "not-the-correct-shasum",
@@ -233,7 +246,7 @@ var _ = Describe("UploadStemcell", func() {
 			Expect(err).To(MatchError("expected shasum not-the-correct-shasum does not match file shasum e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"))
 		})
 		It("fails when the file can not calculate a shasum", func() {
-			command := commands.NewUploadStemcell(multipart, fakeService, logger)
+			command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 			err := command.Execute([]string{
 				"--stemcell", "/path/to/testing.tgz",
 				"--shasum", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
@@ -254,7 +267,7 @@ var _ = Describe("UploadStemcell", func() {
 
 			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, api.DiagnosticReportUnavailable{})
 
-			command := commands.NewUploadStemcell(multipart, fakeService, logger)
+			command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 
 			err := command.Execute([]string{
 				"--stemcell", "/path/to/stemcell.tgz",
@@ -289,7 +302,7 @@ var _ = Describe("UploadStemcell", func() {
 	Context("failure cases", func() {
 		Context("when an unknown flag is provided", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadStemcell(multipart, fakeService, logger)
+				command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 				err := command.Execute([]string{"--badflag"})
 				Expect(err).To(MatchError("could not parse upload-stemcell flags: flag provided but not defined: -badflag"))
 			})
@@ -297,7 +310,7 @@ var _ = Describe("UploadStemcell", func() {
 
 		Context("when the --stemcell flag is missing", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadStemcell(multipart, fakeService, logger)
+				command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 				err := command.Execute([]string{})
 				Expect(err).To(MatchError("could not parse upload-stemcell flags: missing required flag \"--stemcell\""))
 			})
@@ -305,7 +318,7 @@ var _ = Describe("UploadStemcell", func() {
 
 		Context("when the file cannot be opened", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadStemcell(multipart, fakeService, logger)
+				command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 				multipart.AddFileReturns(errors.New("bad file"))
 
 				err := command.Execute([]string{"--stemcell", "/some/path"})
@@ -315,7 +328,7 @@ var _ = Describe("UploadStemcell", func() {
 
 		Context("when the stemcell cannot be uploaded", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadStemcell(multipart, fakeService, logger)
+				command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 				fakeService.UploadStemcellReturns(api.StemcellUploadOutput{}, errors.New("some stemcell error"))
 
 				err := command.Execute([]string{"--stemcell", "/some/path"})
@@ -325,7 +338,7 @@ var _ = Describe("UploadStemcell", func() {
 
 		Context("when the diagnostic report cannot be fetched", func() {
 			It("returns an error", func() {
-				command := commands.NewUploadStemcell(multipart, fakeService, logger)
+				command := commands.NewUploadStemcell(multipart, fakeService, logger, results)
 				fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("some diagnostic error"))
 
 				err := command.Execute([]string{"--stemcell", "/some/path"})
@@ -336,7 +349,7 @@ var _ = Describe("UploadStemcell", func() {
 
 	Describe("Usage", func() {
 		It("returns usage information for the command", func() {
-			command := commands.NewUploadStemcell(nil, nil, nil)
+			command := commands.NewUploadStemcell(nil, nil, nil, nil)
 			Expect(command.Usage()).To(Equal(jhanda.Usage{
 				Description:      "This command will upload a stemcell to the target Ops Manager. Unless the force flag is used, if the stemcell already exists that upload will be skipped",
 				ShortDescription: "uploads a given stemcell to the Ops Manager targeted",
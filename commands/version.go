@@ -29,7 +29,7 @@ func (v Version) Usage() jhanda.Usage {
 func (v Version) Execute([]string) error {
 	_, err := v.output.Write(v.version)
 	if err != nil {
-		return fmt.Errorf("could not print version: %s", err)
+		return fmt.Errorf("could not print version: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pivotal-cf/jhanda"
+	"github.com/pivotal-cf/om/api"
+)
+
+//go:generate counterfeiter -o ./fakes/wait_for_ready_service.go --fake-name WaitForReadyService . waitForReadyService
+type waitForReadyService interface {
+	EnsureAvailability(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error)
+	Info() (api.Info, error)
+}
+
+type WaitForReady struct {
+	service      waitForReadyService
+	logger       logger
+	results      resultWriter
+	canDecrypt   bool
+	waitDuration time.Duration
+	Options      struct {
+		Timeout time.Duration `long:"timeout" default:"30m" description:"how long to wait for Ops Manager to be ready, e.g. 30m"`
+	}
+}
+
+func NewWaitForReady(service waitForReadyService, logger logger, results resultWriter, decryptionPassphrase string, waitDuration time.Duration) WaitForReady {
+	return WaitForReady{
+		service:      service,
+		logger:       logger,
+		results:      results,
+		canDecrypt:   decryptionPassphrase != "",
+		waitDuration: waitDuration,
+	}
+}
+
+func (w WaitForReady) Execute(args []string) error {
+	if _, err := jhanda.Parse(&w.Options, args); err != nil {
+		return configErrorf("could not parse wait-for-ready flags: %w", err)
+	}
+
+	deadline := time.Now().Add(w.Options.Timeout)
+
+	var phase string
+	report := func(next string) {
+		if next != phase {
+			w.logger.Printf("%s...", next)
+			phase = next
+		}
+	}
+
+	var lastErr error
+	for {
+		output, err := w.service.EnsureAvailability(api.EnsureAvailabilityInput{})
+		lastErr = err
+
+		switch {
+		case err != nil:
+			report("waiting for Ops Manager to respond")
+		case output.Status == api.EnsureAvailabilityStatusUnstarted:
+			w.logger.Printf("Ops Manager is ready, authentication has not been configured yet")
+			return w.results.WriteResult(WaitForReadyResult{})
+		case output.Status == api.EnsureAvailabilityStatusPending:
+			report("waiting for the authentication system to start")
+		case output.Status == api.EnsureAvailabilityStatusLocked && !w.canDecrypt:
+			return LockedError{Err: errors.New("Ops Manager is locked after a reboot, set --decryption-passphrase to unlock it")}
+		case output.Status == api.EnsureAvailabilityStatusLocked:
+			report("waiting for the installation to be unlocked")
+			fallthrough
+		case output.Status == api.EnsureAvailabilityStatusComplete:
+			// the authenticated request decrypts the installation first
+			// when a decryption passphrase has been given
+			info, err := w.service.Info()
+			if err == nil {
+				w.logger.Printf("Ops Manager is ready")
+				return w.results.WriteResult(WaitForReadyResult{
+					AuthenticationConfigured: true,
+					Version:                  info.Version,
+				})
+			}
+
+			if !isUnavailable(err) {
+				return fmt.Errorf("could not retrieve info from Ops Manager: %w", err)
+			}
+
+			lastErr = err
+			if output.Status == api.EnsureAvailabilityStatusComplete {
+				report("waiting for the Ops Manager API to respond")
+			}
+		default:
+			report("waiting for Ops Manager to report its status")
+		}
+
+		if !time.Now().Add(w.waitDuration).Before(deadline) {
+			return waitTimeoutError{timeout: w.Options.Timeout, phase: phase, err: lastErr}
+		}
+
+		time.Sleep(w.waitDuration)
+	}
+}
+
+func (w WaitForReady) Usage() jhanda.Usage {
+	return jhanda.Usage{
+		Description:      "This command waits until Ops Manager responds, its authentication system has started and, given the global --decryption-passphrase, its installation has been unlocked. It is meant to be run after creating or rebooting the Ops Manager VM.",
+		ShortDescription: "waits for Ops Manager to be ready",
+		Flags:            w.Options,
+	}
+}
+
+// waitTimeoutError is returned when Ops Manager is not ready in time. It is
+// temporary, so that pipelines can retry.
+type waitTimeoutError struct {
+	timeout time.Duration
+	phase   string
+	err     error
+}
+
+func (e waitTimeoutError) Error() string {
+	message := fmt.Sprintf("Ops Manager was not ready after %s, still %s", e.timeout, e.phase)
+	if e.err != nil {
+		message = fmt.Sprintf("%s: %s", message, e.err)
+	}
+
+	return message
+}
+
+func (e waitTimeoutError) Unwrap() error {
+	return e.err
+}
+
+func (e waitTimeoutError) Temporary() bool {
+	return true
+}
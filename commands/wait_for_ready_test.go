@@ -0,0 +1,158 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/network"
+)
+
+var _ = Describe("WaitForReady", func() {
+	var (
+		logger  *fakes.Logger
+		results *fakes.ResultWriter
+		service *fakes.WaitForReadyService
+		command commands.WaitForReady
+	)
+
+	logged := func() []string {
+		var lines []string
+		for i := 0; i < logger.PrintfCallCount(); i++ {
+			format, content := logger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		results = &fakes.ResultWriter{}
+		service = &fakes.WaitForReadyService{}
+		command = commands.NewWaitForReady(service, logger, results, "", 0)
+	})
+
+	It("waits for Ops Manager to respond and the authentication system to start", func() {
+		connectionErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+		service.EnsureAvailabilityReturnsOnCall(0, api.EnsureAvailabilityOutput{}, connectionErr)
+		service.EnsureAvailabilityReturnsOnCall(1, api.EnsureAvailabilityOutput{}, connectionErr)
+		service.EnsureAvailabilityReturnsOnCall(2, api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusPending}, nil)
+		service.EnsureAvailabilityReturnsOnCall(3, api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusComplete}, nil)
+		service.InfoReturns(api.Info{Version: "2.4-build.1"}, nil)
+
+		err := command.Execute([]string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(service.EnsureAvailabilityCallCount()).To(Equal(4))
+		Expect(service.InfoCallCount()).To(Equal(1))
+		Expect(logged()).To(Equal([]string{
+			"waiting for Ops Manager to respond...",
+			"waiting for the authentication system to start...",
+			"Ops Manager is ready",
+		}))
+
+		Expect(results.WriteResultCallCount()).To(Equal(1))
+		Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.WaitForReadyResult{
+			AuthenticationConfigured: true,
+			Version:                  "2.4-build.1",
+		}))
+	})
+
+	It("waits for the Ops Manager API to respond", func() {
+		service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusComplete}, nil)
+		service.InfoReturnsOnCall(0, api.Info{}, api.ResponseError{StatusCode: 503})
+		service.InfoReturnsOnCall(1, api.Info{Version: "2.4-build.1"}, nil)
+
+		err := command.Execute([]string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(service.InfoCallCount()).To(Equal(2))
+		Expect(logged()).To(Equal([]string{
+			"waiting for the Ops Manager API to respond...",
+			"Ops Manager is ready",
+		}))
+	})
+
+	Context("when authentication has not been configured", func() {
+		It("returns without retrieving info", func() {
+			service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusUnstarted}, nil)
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.InfoCallCount()).To(Equal(0))
+			Expect(logged()).To(Equal([]string{
+				"Ops Manager is ready, authentication has not been configured yet",
+			}))
+
+			Expect(results.WriteResultArgsForCall(0)).To(Equal(commands.WaitForReadyResult{}))
+		})
+	})
+
+	Context("when the installation is locked", func() {
+		BeforeEach(func() {
+			service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusLocked}, nil)
+		})
+
+		It("unlocks it with the decryption passphrase", func() {
+			command = commands.NewWaitForReady(service, logger, results, "some-passphrase", 0)
+			service.InfoReturns(api.Info{Version: "2.4-build.1"}, nil)
+
+			err := command.Execute([]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(service.InfoCallCount()).To(Equal(1))
+			Expect(logged()).To(Equal([]string{
+				"waiting for the installation to be unlocked...",
+				"Ops Manager is ready",
+			}))
+		})
+
+		It("returns a locked error without a decryption passphrase", func() {
+			err := command.Execute([]string{})
+			Expect(err).To(MatchError("Ops Manager is locked after a reboot, set --decryption-passphrase to unlock it"))
+			Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeLocked))
+			Expect(service.InfoCallCount()).To(Equal(0))
+		})
+
+		It("returns the error when the passphrase is wrong", func() {
+			command = commands.NewWaitForReady(service, logger, results, "wrong-passphrase", 0)
+			service.InfoReturns(api.Info{}, network.LockedError{})
+
+			err := command.Execute([]string{})
+			Expect(err).To(MatchError("could not retrieve info from Ops Manager: could not unlock ops manager, check if the decryption passphrase is correct"))
+			Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeLocked))
+		})
+	})
+
+	Context("failure cases", func() {
+		It("returns an unavailable error when Ops Manager is not ready in time", func() {
+			command = commands.NewWaitForReady(service, logger, results, "", time.Millisecond)
+			service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{}, &net.OpError{Op: "dial", Err: errors.New("connection refused")})
+
+			err := command.Execute([]string{"--timeout", "10ms"})
+			Expect(err).To(MatchError("Ops Manager was not ready after 10ms, still waiting for Ops Manager to respond: dial: connection refused"))
+			Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeUnavailable))
+		})
+
+		It("returns an error when info cannot be retrieved", func() {
+			service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusComplete}, nil)
+			service.InfoReturns(api.Info{}, api.ResponseError{StatusCode: 401})
+
+			err := command.Execute([]string{})
+			Expect(err).To(MatchError(ContainSubstring("could not retrieve info from Ops Manager")))
+			Expect(commands.ExitCode(err)).To(Equal(commands.ExitCodeAuthentication))
+		})
+
+		It("returns an error when the flags cannot be parsed", func() {
+			err := command.Execute([]string{"--unknown-flag"})
+			Expect(err).To(MatchError("could not parse wait-for-ready flags: flag provided but not defined: -unknown-flag"))
+		})
+	})
+})
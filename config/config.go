@@ -20,3 +20,38 @@ type VMExtensionConfig struct {
 		CloudProperties map[string]interface{} `yaml:"cloud_properties,omitempty"`
 	} `yaml:"vm-extension-config,omitempty"`
 }
+
+type FoundationConfiguration struct {
+	Director *FoundationDirector    `yaml:"director,omitempty"`
+	Products []FoundationProduct    `yaml:"products,omitempty"`
+	Field    map[string]interface{} `yaml:",inline"`
+}
+
+type FoundationDirector struct {
+	Config    string   `yaml:"config"`
+	VarsFiles []string `yaml:"vars-files,omitempty"`
+	OpsFiles  []string `yaml:"ops-files,omitempty"`
+}
+
+type FoundationProduct struct {
+	Name      string              `yaml:"name"`
+	Version   string              `yaml:"version"`
+	File      string              `yaml:"file,omitempty"`
+	Pivnet    *FoundationPivnet   `yaml:"pivnet,omitempty"`
+	Stemcell  *FoundationStemcell `yaml:"stemcell,omitempty"`
+	Config    string              `yaml:"config,omitempty"`
+	VarsFiles []string            `yaml:"vars-files,omitempty"`
+	OpsFiles  []string            `yaml:"ops-files,omitempty"`
+}
+
+type FoundationPivnet struct {
+	Slug         string `yaml:"slug"`
+	FileGlob     string `yaml:"file-glob"`
+	Token        string `yaml:"api-token"`
+	StemcellIaas string `yaml:"stemcell-iaas,omitempty"`
+}
+
+type FoundationStemcell struct {
+	File    string `yaml:"file,omitempty"`
+	Version string `yaml:"version,omitempty"`
+}
@@ -0,0 +1,170 @@
+package download
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pivotal-cf/om/validator"
+)
+
+// CacheEntry describes a product file stored in the cache and the
+// download-product arguments it was downloaded for.
+type CacheEntry struct {
+	Slug          string `json:"slug"`
+	Version       string `json:"version"`
+	Glob          string `json:"glob"`
+	ReleaseID     int    `json:"release_id"`
+	ProductFileID int    `json:"product_file_id"`
+	SHA256        string `json:"sha256"`
+	FileName      string `json:"file_name"`
+}
+
+// Cache is a content addressed store of downloaded product files. Files are
+// stored by their SHA256 under blobs/, and index/ holds one metadata file per
+// slug, version and glob, so that several processes can share a cache
+// without coordinating writes to a single index.
+type Cache struct {
+	dir string
+}
+
+func NewCache(dir string) Cache {
+	return Cache{dir: dir}
+}
+
+// Lookup returns the entry for the given slug, version and glob if its file
+// is in the cache and still matches its checksum.
+func (c Cache) Lookup(slug, version, glob string) (CacheEntry, bool, error) {
+	contents, err := ioutil.ReadFile(c.indexPath(slug, version, glob))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return CacheEntry{}, false, nil
+		}
+		return CacheEntry{}, false, fmt.Errorf("could not read cache index: %w", err)
+	}
+
+	var entry CacheEntry
+	err = json.Unmarshal(contents, &entry)
+	if err != nil {
+		return CacheEntry{}, false, fmt.Errorf("could not parse cache index: %w", err)
+	}
+
+	if entry.Slug != slug || entry.Version != version || entry.Glob != glob || entry.SHA256 == "" {
+		return CacheEntry{}, false, nil
+	}
+
+	sum, err := validator.NewSHA256Calculator().Checksum(c.blobPath(entry.SHA256))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return CacheEntry{}, false, nil
+		}
+		return CacheEntry{}, false, fmt.Errorf("could not calculate the checksum of the cached file: %w", err)
+	}
+
+	if sum != entry.SHA256 {
+		os.Remove(c.blobPath(entry.SHA256))
+		return CacheEntry{}, false, nil
+	}
+
+	return entry, true, nil
+}
+
+// Link places the cached file of an entry at destination. A hardlink is
+// used when the cache and destination are on the same filesystem, otherwise
+// the file is copied.
+func (c Cache) Link(entry CacheEntry, destination string) error {
+	err := os.Remove(destination)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not replace %s: %w", destination, err)
+	}
+
+	return linkOrCopy(c.blobPath(entry.SHA256), destination)
+}
+
+// Store adds the file at path to the cache and records entry in the index.
+func (c Cache) Store(entry CacheEntry, path string) error {
+	for _, dir := range []string{filepath.Join(c.dir, "blobs"), filepath.Join(c.dir, "index")} {
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			return fmt.Errorf("could not create cache directory: %w", err)
+		}
+	}
+
+	blobPath := c.blobPath(entry.SHA256)
+	_, err := os.Stat(blobPath)
+	if os.IsNotExist(err) {
+		tempPath := fmt.Sprintf("%s.%d.tmp", blobPath, os.Getpid())
+		err = linkOrCopy(path, tempPath)
+		if err != nil {
+			return fmt.Errorf("could not add %s to the cache: %w", path, err)
+		}
+
+		err = os.Rename(tempPath, blobPath)
+		if err != nil {
+			os.Remove(tempPath)
+			return fmt.Errorf("could not add %s to the cache: %w", path, err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("could not add %s to the cache: %w", path, err)
+	}
+
+	contents, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	indexPath := c.indexPath(entry.Slug, entry.Version, entry.Glob)
+	tempPath := fmt.Sprintf("%s.%d.tmp", indexPath, os.Getpid())
+	err = ioutil.WriteFile(tempPath, contents, 0644)
+	if err != nil {
+		return fmt.Errorf("could not write cache index: %w", err)
+	}
+
+	err = os.Rename(tempPath, indexPath)
+	if err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("could not write cache index: %w", err)
+	}
+
+	return nil
+}
+
+func (c Cache) blobPath(sum string) string {
+	return filepath.Join(c.dir, "blobs", sum)
+}
+
+func (c Cache) indexPath(slug, version, glob string) string {
+	key := sha256.Sum256([]byte(slug + "\x00" + version + "\x00" + glob))
+	return filepath.Join(c.dir, "index", fmt.Sprintf("%x.json", key))
+}
+
+func linkOrCopy(source, destination string) error {
+	err := os.Link(source, destination)
+	if err == nil {
+		return nil
+	}
+
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	destinationFile, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(destinationFile, sourceFile)
+	if err != nil {
+		destinationFile.Close()
+		os.Remove(destination)
+		return err
+	}
+
+	return destinationFile.Close()
+}
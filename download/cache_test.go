@@ -0,0 +1,121 @@
+package download_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pivotal-cf/om/download"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		cacheDir  string
+		outputDir string
+		cache     download.Cache
+		entry     download.CacheEntry
+		filePath  string
+	)
+
+	BeforeEach(func() {
+		var err error
+		cacheDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		outputDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		filePath = filepath.Join(outputDir, "cf-2.0-build.1.pivotal")
+		err = ioutil.WriteFile(filePath, []byte("some-tile"), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		cache = download.NewCache(cacheDir)
+		entry = download.CacheEntry{
+			Slug:          "elastic-runtime",
+			Version:       "2.0.0",
+			Glob:          "*.pivotal",
+			ReleaseID:     12345,
+			ProductFileID: 54321,
+			SHA256:        fmt.Sprintf("%x", sha256.Sum256([]byte("some-tile"))),
+			FileName:      "cf-2.0-build.1.pivotal",
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(cacheDir)
+		os.RemoveAll(outputDir)
+	})
+
+	It("finds a stored file by slug, version and glob", func() {
+		_, found, err := cache.Lookup("elastic-runtime", "2.0.0", "*.pivotal")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		err = cache.Store(entry, filePath)
+		Expect(err).NotTo(HaveOccurred())
+
+		cached, found, err := cache.Lookup("elastic-runtime", "2.0.0", "*.pivotal")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(cached).To(Equal(entry))
+
+		_, found, err = cache.Lookup("elastic-runtime", "2.0.0", "*.tgz")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		Expect(filepath.Join(cacheDir, "blobs", entry.SHA256)).To(BeAnExistingFile())
+	})
+
+	It("places the cached file at the destination", func() {
+		err := cache.Store(entry, filePath)
+		Expect(err).NotTo(HaveOccurred())
+
+		destination := filepath.Join(outputDir, "other.pivotal")
+		err = cache.Link(entry, destination)
+		Expect(err).NotTo(HaveOccurred())
+
+		contents, err := ioutil.ReadFile(destination)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal("some-tile"))
+	})
+
+	Context("when the cached file no longer matches its checksum", func() {
+		It("is not found and removed from the cache", func() {
+			err := cache.Store(entry, filePath)
+			Expect(err).NotTo(HaveOccurred())
+
+			blobPath := filepath.Join(cacheDir, "blobs", entry.SHA256)
+			err = os.Remove(blobPath)
+			Expect(err).NotTo(HaveOccurred())
+			err = ioutil.WriteFile(blobPath, []byte("corrupted"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := cache.Lookup("elastic-runtime", "2.0.0", "*.pivotal")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(blobPath).NotTo(BeAnExistingFile())
+		})
+	})
+
+	Context("when the index cannot be parsed", func() {
+		It("returns an error", func() {
+			err := cache.Store(entry, filePath)
+			Expect(err).NotTo(HaveOccurred())
+
+			indexFiles, err := filepath.Glob(filepath.Join(cacheDir, "index", "*.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indexFiles).To(HaveLen(1))
+
+			err = ioutil.WriteFile(indexFiles[0], []byte("%%%"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = cache.Lookup("elastic-runtime", "2.0.0", "*.pivotal")
+			Expect(err).To(MatchError(ContainSubstring("could not parse cache index")))
+		})
+	})
+})
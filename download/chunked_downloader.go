@@ -0,0 +1,371 @@
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+
+	pivnetlog "github.com/pivotal-cf/go-pivnet/logger"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/cheggaaa/pb.v1"
+)
+
+const (
+	// StateFileSuffix is appended to the name of the file being downloaded to
+	// track which byte ranges have already been written to disk.
+	StateFileSuffix = ".state"
+
+	maxChunkAttempts = 5
+	stateSaveBytes   = 8 * 1024 * 1024
+)
+
+type httpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+//go:generate counterfeiter -o ./fakes/link_fetcher.go --fake-name LinkFetcher . linkFetcher
+type linkFetcher interface {
+	NewDownloadLink() (string, error)
+}
+
+type chunk struct {
+	Lower   int64 `json:"lower"`
+	Upper   int64 `json:"upper"`
+	Written int64 `json:"written"`
+}
+
+func (c chunk) complete() bool {
+	return c.Lower+c.Written > c.Upper
+}
+
+type downloadState struct {
+	Size   int64   `json:"size"`
+	Chunks []chunk `json:"chunks"`
+}
+
+// ChunkedDownloader downloads a file with parallel HTTP range requests.
+// Progress is recorded next to the file being downloaded, so that a download
+// that was interrupted picks up where it stopped instead of starting over.
+type ChunkedDownloader struct {
+	client httpClient
+	logger pivnetlog.Logger
+	chunks int
+}
+
+func NewChunkedDownloader(client httpClient, logger pivnetlog.Logger, chunks int) ChunkedDownloader {
+	if chunks < 1 {
+		chunks = 1
+	}
+
+	return ChunkedDownloader{
+		client: client,
+		logger: logger,
+		chunks: chunks,
+	}
+}
+
+func (d ChunkedDownloader) Get(location *os.File, fetcher linkFetcher, progressWriter io.Writer) error {
+	contentURL, err := fetcher.NewDownloadLink()
+	if err != nil {
+		return fmt.Errorf("could not fetch download link: %w", err)
+	}
+
+	req, err := http.NewRequest("HEAD", contentURL, nil)
+	if err != nil {
+		return fmt.Errorf("could not construct HEAD request: %w", err)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not make HEAD request: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("during HEAD unexpected status code was returned: %d", resp.StatusCode)
+	}
+
+	if resp.ContentLength <= 0 {
+		return fmt.Errorf("could not determine the size of the file to download")
+	}
+
+	if resp.Request != nil && resp.Request.URL != nil {
+		contentURL = resp.Request.URL.String()
+	}
+
+	statePath := location.Name() + StateFileSuffix
+	state, resumed := d.loadState(statePath, resp.ContentLength)
+	if resumed {
+		d.logger.Info(fmt.Sprintf("resuming partial download of %s", location.Name()))
+	} else {
+		err = location.Truncate(resp.ContentLength)
+		if err != nil {
+			return fmt.Errorf("could not allocate %s: %w", location.Name(), err)
+		}
+	}
+
+	var downloaded int64
+	for _, c := range state.Chunks {
+		downloaded += c.Written
+	}
+
+	bar := pb.New64(resp.ContentLength)
+	bar.SetUnits(pb.U_BYTES)
+	bar.Width = 80
+	bar.Output = progressWriter
+	bar.Set64(downloaded)
+	bar.Start()
+	defer bar.Finish()
+
+	tracker := &stateTracker{
+		path:       statePath,
+		location:   location,
+		state:      state,
+		contentURL: contentURL,
+	}
+
+	err = tracker.save()
+	if err != nil {
+		return err
+	}
+
+	var g errgroup.Group
+	for i := range state.Chunks {
+		index := i
+		if state.Chunks[index].complete() {
+			continue
+		}
+
+		g.Go(func() error {
+			return d.downloadChunk(location, fetcher, tracker, index, bar)
+		})
+	}
+
+	err = g.Wait()
+	if err != nil {
+		saveErr := tracker.save()
+		if saveErr != nil {
+			d.logger.Debug(fmt.Sprintf("could not save download state: %s", saveErr))
+		}
+		return err
+	}
+
+	err = os.Remove(statePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove download state %s: %w", statePath, err)
+	}
+
+	return nil
+}
+
+func (d ChunkedDownloader) loadState(path string, size int64) (downloadState, bool) {
+	contents, err := ioutil.ReadFile(path)
+	if err == nil {
+		var state downloadState
+		err = json.Unmarshal(contents, &state)
+		if err == nil && state.Size == size && len(state.Chunks) > 0 {
+			return state, true
+		}
+
+		d.logger.Debug(fmt.Sprintf("discarding download state %s that does not match the file to download", path))
+	}
+
+	count := int64(d.chunks)
+	if count > size {
+		count = size
+	}
+	chunkSize := size / count
+
+	state := downloadState{Size: size}
+	for i := int64(0); i < count; i++ {
+		upper := (i+1)*chunkSize - 1
+		if i == count-1 {
+			upper = size - 1
+		}
+
+		state.Chunks = append(state.Chunks, chunk{Lower: i * chunkSize, Upper: upper})
+	}
+
+	return state, false
+}
+
+func (d ChunkedDownloader) downloadChunk(location *os.File, fetcher linkFetcher, tracker *stateTracker, index int, bar *pb.ProgressBar) error {
+	var lastErr error
+
+	for attempt := 1; attempt <= maxChunkAttempts; attempt++ {
+		current := tracker.chunk(index)
+		if current.complete() {
+			return nil
+		}
+
+		start := current.Lower + current.Written
+
+		req, err := http.NewRequest("GET", tracker.url(), nil)
+		if err != nil {
+			return fmt.Errorf("could not construct GET request: %w", err)
+		}
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, current.Upper))
+
+		resp, err := d.client.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("download request failed: %w", err)
+			d.logger.Debug(fmt.Sprintf("retrying bytes %d-%d: %s", start, current.Upper, lastErr))
+			continue
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusForbidden:
+			resp.Body.Close()
+
+			link, err := fetcher.NewDownloadLink()
+			if err != nil {
+				return fmt.Errorf("could not fetch download link: %w", err)
+			}
+			tracker.setURL(link)
+
+			lastErr = fmt.Errorf("download link expired")
+			d.logger.Debug("fetched a new download link after the previous one expired")
+			continue
+		case resp.StatusCode == http.StatusOK && start == 0 && current.Upper == tracker.size()-1:
+		case resp.StatusCode != http.StatusPartialContent:
+			resp.Body.Close()
+			return fmt.Errorf("during GET unexpected status code was returned: %d", resp.StatusCode)
+		}
+
+		err = d.copyChunk(location, resp.Body, tracker, index, start, bar)
+		resp.Body.Close()
+		if err == nil {
+			return tracker.save()
+		}
+
+		lastErr = err
+		d.logger.Debug(fmt.Sprintf("retrying bytes %d-%d: %s", start, current.Upper, err))
+	}
+
+	return fmt.Errorf("could not download bytes %d-%d after %d attempts: %w", tracker.chunk(index).Lower, tracker.chunk(index).Upper, maxChunkAttempts, lastErr)
+}
+
+func (d ChunkedDownloader) copyChunk(location *os.File, body io.Reader, tracker *stateTracker, index int, offset int64, bar *pb.ProgressBar) error {
+	buffer := make([]byte, 32*1024)
+	remaining := tracker.chunk(index).Upper - offset + 1
+	var unsaved int64
+
+	for remaining > 0 {
+		if int64(len(buffer)) > remaining {
+			buffer = buffer[:remaining]
+		}
+
+		n, readErr := body.Read(buffer)
+		if n > 0 {
+			_, err := location.WriteAt(buffer[:n], offset)
+			if err != nil {
+				return fmt.Errorf("could not write to %s: %w", location.Name(), err)
+			}
+
+			offset += int64(n)
+			remaining -= int64(n)
+			unsaved += int64(n)
+			bar.Add(n)
+			tracker.advance(index, int64(n))
+
+			if unsaved >= stateSaveBytes {
+				err = tracker.save()
+				if err != nil {
+					return err
+				}
+				unsaved = 0
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	if remaining > 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	return nil
+}
+
+// stateTracker guards the download state and link shared by the chunk
+// downloads and persists the state. The file is synced before the state is written, so the state
+// never claims more bytes than are actually on disk.
+type stateTracker struct {
+	mutex      sync.Mutex
+	path       string
+	location   *os.File
+	state      downloadState
+	contentURL string
+}
+
+func (t *stateTracker) chunk(index int) chunk {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.state.Chunks[index]
+}
+
+func (t *stateTracker) size() int64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.state.Size
+}
+
+func (t *stateTracker) advance(index int, written int64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.state.Chunks[index].Written += written
+}
+
+func (t *stateTracker) url() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.contentURL
+}
+
+func (t *stateTracker) setURL(url string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.contentURL = url
+}
+
+func (t *stateTracker) save() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	err := t.location.Sync()
+	if err != nil {
+		return fmt.Errorf("could not sync %s: %w", t.location.Name(), err)
+	}
+
+	contents, err := json.Marshal(t.state)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(t.path+".tmp", contents, 0644)
+	if err != nil {
+		return fmt.Errorf("could not write download state %s: %w", t.path, err)
+	}
+
+	err = os.Rename(t.path+".tmp", t.path)
+	if err != nil {
+		return fmt.Errorf("could not write download state %s: %w", t.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package download_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pivotal-cf/go-pivnet/logshim"
+	"github.com/pivotal-cf/om/download"
+	"github.com/pivotal-cf/om/download/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChunkedDownloader", func() {
+	var (
+		content     []byte
+		server      *httptest.Server
+		handler     func(w http.ResponseWriter, req *http.Request) bool
+		ranges      []string
+		rangesMutex sync.Mutex
+		fetcher     *fakes.LinkFetcher
+		tempDir     string
+		location    *os.File
+		downloader  download.ChunkedDownloader
+	)
+
+	BeforeEach(func() {
+		content = []byte(strings.Repeat("0123456789", 100))
+		ranges = nil
+		handler = nil
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method == "GET" {
+				rangesMutex.Lock()
+				ranges = append(ranges, req.Header.Get("Range"))
+				rangesMutex.Unlock()
+			}
+
+			if handler != nil && handler(w, req) {
+				return
+			}
+
+			http.ServeContent(w, req, "product.pivotal", time.Time{}, bytes.NewReader(content))
+		}))
+
+		fetcher = &fakes.LinkFetcher{}
+		fetcher.NewDownloadLinkReturns(server.URL+"/product.pivotal", nil)
+
+		var err error
+		tempDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		location, err = os.Create(filepath.Join(tempDir, "product.pivotal.partial"))
+		Expect(err).NotTo(HaveOccurred())
+
+		logger := log.New(GinkgoWriter, "", 0)
+		downloader = download.NewChunkedDownloader(http.DefaultClient, logshim.NewLogShim(logger, logger, true), 4)
+	})
+
+	AfterEach(func() {
+		location.Close()
+		server.Close()
+		os.RemoveAll(tempDir)
+	})
+
+	It("downloads the file with parallel range requests", func() {
+		err := downloader.Get(location, fetcher, ioutil.Discard)
+		Expect(err).NotTo(HaveOccurred())
+
+		downloaded, err := ioutil.ReadFile(location.Name())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(downloaded).To(Equal(content))
+
+		Expect(ranges).To(ConsistOf(
+			"bytes=0-249",
+			"bytes=250-499",
+			"bytes=500-749",
+			"bytes=750-999",
+		))
+
+		_, err = os.Stat(location.Name() + download.StateFileSuffix)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	Context("when a previous download was interrupted", func() {
+		BeforeEach(func() {
+			_, err := location.WriteAt(content[:300], 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			state, err := json.Marshal(map[string]interface{}{
+				"size": len(content),
+				"chunks": []map[string]int64{
+					{"lower": 0, "upper": 249, "written": 250},
+					{"lower": 250, "upper": 499, "written": 50},
+					{"lower": 500, "upper": 749, "written": 0},
+					{"lower": 750, "upper": 999, "written": 0},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(location.Name()+download.StateFileSuffix, state, 0644)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("only downloads the bytes that are missing", func() {
+			err := downloader.Get(location, fetcher, ioutil.Discard)
+			Expect(err).NotTo(HaveOccurred())
+
+			downloaded, err := ioutil.ReadFile(location.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(downloaded).To(Equal(content))
+
+			Expect(ranges).To(ConsistOf(
+				"bytes=300-499",
+				"bytes=500-749",
+				"bytes=750-999",
+			))
+		})
+
+		Context("when the state is for a file of a different size", func() {
+			BeforeEach(func() {
+				content = append(content, []byte("0123456789")...)
+			})
+
+			It("starts the download over", func() {
+				downloader = download.NewChunkedDownloader(http.DefaultClient, logshim.NewLogShim(log.New(GinkgoWriter, "", 0), log.New(GinkgoWriter, "", 0), true), 2)
+
+				err := downloader.Get(location, fetcher, ioutil.Discard)
+				Expect(err).NotTo(HaveOccurred())
+
+				downloaded, err := ioutil.ReadFile(location.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(downloaded).To(Equal(content))
+
+				Expect(ranges).To(ConsistOf("bytes=0-504", "bytes=505-1009"))
+			})
+		})
+	})
+
+	Context("when the connection drops during a download", func() {
+		It("retries from the last byte written", func() {
+			var dropped bool
+			handler = func(w http.ResponseWriter, req *http.Request) bool {
+				if req.Method != "GET" || req.Header.Get("Range") != "bytes=250-499" || dropped {
+					return false
+				}
+				dropped = true
+
+				w.Header().Set("Content-Length", "250")
+				w.Header().Set("Content-Range", "bytes 250-499/1000")
+				w.WriteHeader(http.StatusPartialContent)
+				w.Write(content[250:350])
+
+				return true
+			}
+
+			err := downloader.Get(location, fetcher, ioutil.Discard)
+			Expect(err).NotTo(HaveOccurred())
+
+			downloaded, err := ioutil.ReadFile(location.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(downloaded).To(Equal(content))
+
+			Expect(ranges).To(ContainElement("bytes=350-499"))
+		})
+	})
+
+	Context("when the download link expires", func() {
+		It("fetches a new download link", func() {
+			handler = func(w http.ResponseWriter, req *http.Request) bool {
+				if req.URL.Path == "/expired.pivotal" && req.Method == "GET" {
+					w.WriteHeader(http.StatusForbidden)
+					return true
+				}
+				return false
+			}
+
+			fetcher.NewDownloadLinkReturnsOnCall(0, server.URL+"/expired.pivotal", nil)
+
+			err := downloader.Get(location, fetcher, ioutil.Discard)
+			Expect(err).NotTo(HaveOccurred())
+
+			downloaded, err := ioutil.ReadFile(location.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(downloaded).To(Equal(content))
+
+			Expect(fetcher.NewDownloadLinkCallCount()).To(BeNumerically(">", 1))
+		})
+	})
+
+	Context("failure cases", func() {
+		Context("when the download link cannot be fetched", func() {
+			It("returns an error", func() {
+				fetcher.NewDownloadLinkReturns("", os.ErrPermission)
+
+				err := downloader.Get(location, fetcher, ioutil.Discard)
+				Expect(err).To(MatchError("could not fetch download link: permission denied"))
+				Expect(errors.Is(err, os.ErrPermission)).To(BeTrue())
+			})
+		})
+
+		Context("when a range request fails", func() {
+			It("returns an error and keeps the progress for the next attempt", func() {
+				handler = func(w http.ResponseWriter, req *http.Request) bool {
+					if req.Method == "GET" && req.Header.Get("Range") == "bytes=750-999" {
+						w.WriteHeader(http.StatusInternalServerError)
+						return true
+					}
+					return false
+				}
+
+				err := downloader.Get(location, fetcher, ioutil.Discard)
+				Expect(err).To(MatchError("during GET unexpected status code was returned: 500"))
+
+				state, err := ioutil.ReadFile(location.Name() + download.StateFileSuffix)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state).To(MatchJSON(`{
+					"size": 1000,
+					"chunks": [
+						{"lower": 0, "upper": 249, "written": 250},
+						{"lower": 250, "upper": 499, "written": 250},
+						{"lower": 500, "upper": 749, "written": 250},
+						{"lower": 750, "upper": 999, "written": 0}
+					]
+				}`))
+			})
+		})
+	})
+})
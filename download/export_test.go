@@ -0,0 +1,11 @@
+package download
+
+import "time"
+
+func SetNow(f func() time.Time) {
+	now = f
+}
+
+func ResetNow() {
+	now = time.Now
+}
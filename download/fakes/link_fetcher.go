@@ -0,0 +1,102 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	sync "sync"
+)
+
+type LinkFetcher struct {
+	NewDownloadLinkStub        func() (string, error)
+	newDownloadLinkMutex       sync.RWMutex
+	newDownloadLinkArgsForCall []struct {
+	}
+	newDownloadLinkReturns struct {
+		result1 string
+		result2 error
+	}
+	newDownloadLinkReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *LinkFetcher) NewDownloadLink() (string, error) {
+	fake.newDownloadLinkMutex.Lock()
+	ret, specificReturn := fake.newDownloadLinkReturnsOnCall[len(fake.newDownloadLinkArgsForCall)]
+	fake.newDownloadLinkArgsForCall = append(fake.newDownloadLinkArgsForCall, struct {
+	}{})
+	fake.recordInvocation("NewDownloadLink", []interface{}{})
+	fake.newDownloadLinkMutex.Unlock()
+	if fake.NewDownloadLinkStub != nil {
+		return fake.NewDownloadLinkStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.newDownloadLinkReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *LinkFetcher) NewDownloadLinkCallCount() int {
+	fake.newDownloadLinkMutex.RLock()
+	defer fake.newDownloadLinkMutex.RUnlock()
+	return len(fake.newDownloadLinkArgsForCall)
+}
+
+func (fake *LinkFetcher) NewDownloadLinkCalls(stub func() (string, error)) {
+	fake.newDownloadLinkMutex.Lock()
+	defer fake.newDownloadLinkMutex.Unlock()
+	fake.NewDownloadLinkStub = stub
+}
+
+func (fake *LinkFetcher) NewDownloadLinkReturns(result1 string, result2 error) {
+	fake.newDownloadLinkMutex.Lock()
+	defer fake.newDownloadLinkMutex.Unlock()
+	fake.NewDownloadLinkStub = nil
+	fake.newDownloadLinkReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LinkFetcher) NewDownloadLinkReturnsOnCall(i int, result1 string, result2 error) {
+	fake.newDownloadLinkMutex.Lock()
+	defer fake.newDownloadLinkMutex.Unlock()
+	fake.NewDownloadLinkStub = nil
+	if fake.newDownloadLinkReturnsOnCall == nil {
+		fake.newDownloadLinkReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.newDownloadLinkReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LinkFetcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.newDownloadLinkMutex.RLock()
+	defer fake.newDownloadLinkMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *LinkFetcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
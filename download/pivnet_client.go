@@ -0,0 +1,53 @@
+package download
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/pivotal-cf/go-pivnet"
+	pivnetlog "github.com/pivotal-cf/go-pivnet/logger"
+	"github.com/pivotal-cf/pivnet-cli/gp"
+)
+
+const concurrentDownloads = 10
+
+// PivnetClient is a Pivotal Network client whose product file downloads can
+// be resumed when they are interrupted.
+type PivnetClient struct {
+	*gp.Client
+	client     pivnet.Client
+	downloader ChunkedDownloader
+}
+
+func NewPivnetClient(config pivnet.ClientConfig, logger pivnetlog.Logger) PivnetClient {
+	downloadClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: config.SkipSSLValidation,
+			},
+			Proxy: http.ProxyFromEnvironment,
+		},
+	}
+
+	return PivnetClient{
+		Client:     gp.NewClient(config, logger),
+		client:     pivnet.NewClient(config, logger),
+		downloader: NewChunkedDownloader(downloadClient, logger, concurrentDownloads),
+	}
+}
+
+func (c PivnetClient) DownloadProductFile(location *os.File, productSlug string, releaseID int, productFileID int, progressWriter io.Writer) error {
+	productFile, err := c.client.ProductFiles.GetForRelease(productSlug, releaseID, productFileID)
+	if err != nil {
+		return err
+	}
+
+	downloadLink, err := productFile.DownloadLink()
+	if err != nil {
+		return err
+	}
+
+	return c.downloader.Get(location, pivnet.NewProductFileLinkFetcher(downloadLink, c.client), progressWriter)
+}
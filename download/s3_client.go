@@ -0,0 +1,339 @@
+package download
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	pivnetlog "github.com/pivotal-cf/go-pivnet/logger"
+	"github.com/pivotal-cf/om/network"
+)
+
+var now = time.Now
+
+// md5ETagPattern matches the ETag of objects that were not uploaded in
+// parts, which is the MD5 of their contents.
+var md5ETagPattern = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
+// S3Config locates a bucket in S3 or an S3-compatible blobstore such as
+// MinIO. Buckets are addressed path-style, e.g. https://endpoint/bucket/key.
+// Requests are anonymous when no access key is given.
+type S3Config struct {
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// CACert is trusted in addition to the system certificates, e.g. for an
+	// on-premise blobstore. It is either a path to a file or the PEM
+	// encoded contents.
+	CACert            string
+	SkipSSLValidation bool
+}
+
+// S3Client lists and downloads objects of a single bucket.
+type S3Client struct {
+	client     s3SigningClient
+	config     S3Config
+	downloader ChunkedDownloader
+	logger     pivnetlog.Logger
+}
+
+// NewS3HTTPClient returns a client for the endpoint of config that trusts
+// its CACert, or skips certificate validation when SkipSSLValidation is set.
+func NewS3HTTPClient(config S3Config) (*http.Client, error) {
+	tlsConfig, err := network.NewTLSConfig(config.SkipSSLValidation, config.CACert, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}, nil
+}
+
+func NewS3Client(config S3Config, client httpClient, logger pivnetlog.Logger) S3Client {
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+	}
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+
+	signingClient := s3SigningClient{
+		client: client,
+		config: config,
+	}
+
+	return S3Client{
+		client:     signingClient,
+		config:     config,
+		downloader: NewChunkedDownloader(signingClient, logger, concurrentDownloads),
+		logger:     logger,
+	}
+}
+
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+}
+
+// ListObjects returns the keys of all objects starting with prefix.
+func (c S3Client) ListObjects(prefix string) ([]string, error) {
+	var keys []string
+	var continuationToken string
+
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s?%s", c.config.Endpoint, s3Escape(c.config.Bucket), s3Query(query)), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("could not list objects in bucket %s: %w", c.config.Bucket, err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("could not list objects in bucket %s: unexpected status code %d", c.config.Bucket, resp.StatusCode)
+		}
+
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse object list of bucket %s: %w", c.config.Bucket, err)
+		}
+
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// DownloadObject downloads the object with the given key into location, and
+// verifies it against the checksum of the object.
+func (c S3Client) DownloadObject(location *os.File, key string, progressWriter io.Writer) error {
+	objectURL := fmt.Sprintf("%s/%s/%s", c.config.Endpoint, s3Escape(c.config.Bucket), s3Escape(key))
+
+	err := c.downloader.Get(location, staticLink(objectURL), progressWriter)
+	if err != nil {
+		return err
+	}
+
+	return c.verifyObject(location, key, objectURL)
+}
+
+// verifyObject compares the downloaded file with the checksum the blobstore
+// stores for the object: its SHA256 checksum when it was uploaded with one,
+// or else its ETag, which is the MD5 of objects that were neither uploaded in
+// parts nor encrypted with KMS or a customer key.
+func (c S3Client) verifyObject(location *os.File, key, objectURL string) error {
+	req, err := http.NewRequest("HEAD", objectURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Amz-Checksum-Mode", "ENABLED")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not retrieve the checksum of %s: %w", key, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not retrieve the checksum of %s: unexpected status code %d", key, resp.StatusCode)
+	}
+
+	var expectedSum string
+	var hasher hash.Hash
+
+	etag := strings.Trim(resp.Header.Get("ETag"), `"`)
+	encrypted := resp.Header.Get("X-Amz-Server-Side-Encryption") == "aws:kms" ||
+		resp.Header.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm") != ""
+
+	switch {
+	case resp.Header.Get("X-Amz-Checksum-Sha256") != "":
+		sum, err := base64.StdEncoding.DecodeString(resp.Header.Get("X-Amz-Checksum-Sha256"))
+		if err != nil {
+			return fmt.Errorf("could not decode the SHA256 checksum of %s: %w", key, err)
+		}
+		expectedSum = hex.EncodeToString(sum)
+		hasher = sha256.New()
+	case md5ETagPattern.MatchString(etag) && !encrypted:
+		expectedSum = strings.ToLower(etag)
+		hasher = md5.New()
+	default:
+		c.logger.Info(fmt.Sprintf("could not verify %s, the blobstore has no checksum of its contents", key))
+		return nil
+	}
+
+	_, err = location.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", location.Name(), err)
+	}
+
+	_, err = io.Copy(hasher, location)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", location.Name(), err)
+	}
+
+	sum := hex.EncodeToString(hasher.Sum(nil))
+	if sum != expectedSum {
+		return fmt.Errorf("the checksum of the downloaded file %s does not match %s: expected %s, got %s", location.Name(), key, expectedSum, sum)
+	}
+
+	return nil
+}
+
+type staticLink string
+
+func (l staticLink) NewDownloadLink() (string, error) {
+	return string(l), nil
+}
+
+// s3SigningClient signs every request with AWS Signature Version 4.
+type s3SigningClient struct {
+	client httpClient
+	config S3Config
+}
+
+func (c s3SigningClient) Do(req *http.Request) (*http.Response, error) {
+	if c.config.AccessKeyID != "" {
+		c.sign(req)
+	}
+
+	return c.client.Do(req)
+}
+
+func (c s3SigningClient) sign(req *http.Request) {
+	const payloadHash = "UNSIGNED-PAYLOAD"
+
+	signedAt := now().UTC()
+	amzDate := signedAt.Format("20060102T150405Z")
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", signedAt.Format("20060102"), c.config.Region)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// every x-amz-* header that is sent has to be signed
+	headers := []string{"host"}
+	for name := range req.Header {
+		if strings.HasPrefix(strings.ToLower(name), "x-amz-") {
+			headers = append(headers, strings.ToLower(name))
+		}
+	}
+	sort.Strings(headers)
+
+	var canonicalHeaders string
+	for _, name := range headers {
+		value := req.URL.Host
+		if name != "host" {
+			value = strings.TrimSpace(req.Header.Get(name))
+		}
+		canonicalHeaders += fmt.Sprintf("%s:%s\n", name, value)
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3Query(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	hashedRequest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(hashedRequest[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.config.SecretAccessKey), signedAt.Format("20060102"))
+	key = hmacSHA256(key, c.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.config.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Escape percent-encodes everything but unreserved characters and slashes,
+// as expected in the canonical URI of a signed request.
+func s3Escape(value string) string {
+	var escaped bytes.Buffer
+	for _, b := range []byte(value) {
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || strings.IndexByte("-_.~/", b) >= 0 {
+			escaped.WriteByte(b)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+
+	return escaped.String()
+}
+
+func s3Query(query url.Values) string {
+	var keys []string
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			pairs = append(pairs, strings.Replace(s3Escape(key), "/", "%2F", -1)+"="+strings.Replace(s3Escape(value), "/", "%2F", -1))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
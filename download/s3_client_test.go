@@ -0,0 +1,290 @@
+package download_test
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/go-pivnet/logshim"
+	"github.com/pivotal-cf/om/download"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordingClient struct {
+	requests []*http.Request
+}
+
+func (c *recordingClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(`<ListBucketResult></ListBucketResult>`)),
+	}, nil
+}
+
+var _ = Describe("S3Client", func() {
+	var (
+		server  *httptest.Server
+		objects map[string]string
+		headers map[string]http.Header
+		logger  *logshim.LogShim
+	)
+
+	BeforeEach(func() {
+		objects = map[string]string{
+			"products/[elastic-runtime,2.0.0]cf-2.0-build.1.pivotal":  "some-tile",
+			"products/[elastic-runtime,2.0.0]srt-2.0-build.1.pivotal": "some-other-tile",
+		}
+		headers = map[string]http.Header{}
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/some-bucket" {
+				Expect(req.URL.Query().Get("list-type")).To(Equal("2"))
+
+				prefix := req.URL.Query().Get("prefix")
+				if req.URL.Query().Get("continuation-token") == "" {
+					fmt.Fprintf(w, `<ListBucketResult><IsTruncated>true</IsTruncated><NextContinuationToken>page-2</NextContinuationToken><Contents><Key>%s</Key></Contents></ListBucketResult>`, prefix+"first")
+					return
+				}
+
+				w.Write([]byte(`<ListBucketResult><IsTruncated>false</IsTruncated>`))
+				for key := range objects {
+					if strings.HasPrefix(key, prefix) {
+						fmt.Fprintf(w, `<Contents><Key>%s</Key></Contents>`, key)
+					}
+				}
+				w.Write([]byte(`</ListBucketResult>`))
+				return
+			}
+
+			key := strings.TrimPrefix(req.URL.Path, "/some-bucket/")
+			content, ok := objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			for name, values := range headers[key] {
+				if name == "X-Amz-Checksum-Sha256" && req.Header.Get("X-Amz-Checksum-Mode") != "ENABLED" {
+					continue
+				}
+				w.Header()[name] = values
+			}
+
+			http.ServeContent(w, req, "", time.Time{}, bytes.NewReader([]byte(content)))
+		}))
+
+		stdLogger := log.New(GinkgoWriter, "", 0)
+		logger = logshim.NewLogShim(stdLogger, stdLogger, true)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("ListObjects", func() {
+		It("lists all keys with the prefix", func() {
+			client := download.NewS3Client(download.S3Config{
+				Endpoint: server.URL,
+				Bucket:   "some-bucket",
+			}, http.DefaultClient, logger)
+
+			keys, err := client.ListObjects("products/[elastic-runtime,2.0.0]")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(ConsistOf(
+				"products/[elastic-runtime,2.0.0]first",
+				"products/[elastic-runtime,2.0.0]cf-2.0-build.1.pivotal",
+				"products/[elastic-runtime,2.0.0]srt-2.0-build.1.pivotal",
+			))
+		})
+
+		Context("when the bucket cannot be listed", func() {
+			It("returns an error", func() {
+				client := download.NewS3Client(download.S3Config{
+					Endpoint: server.URL,
+					Bucket:   "missing-bucket",
+				}, http.DefaultClient, logger)
+
+				_, err := client.ListObjects("products/")
+				Expect(err).To(MatchError("could not list objects in bucket missing-bucket: unexpected status code 404"))
+			})
+		})
+	})
+
+	Describe("DownloadObject", func() {
+		It("downloads the object", func() {
+			tempDir, err := ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+
+			location, err := os.Create(filepath.Join(tempDir, "cf-2.0-build.1.pivotal"))
+			Expect(err).NotTo(HaveOccurred())
+			defer location.Close()
+
+			client := download.NewS3Client(download.S3Config{
+				Endpoint: server.URL,
+				Bucket:   "some-bucket",
+			}, http.DefaultClient, logger)
+
+			err = client.DownloadObject(location, "products/[elastic-runtime,2.0.0]cf-2.0-build.1.pivotal", ioutil.Discard)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadFile(location.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-tile"))
+		})
+
+		Context("when the blobstore has a checksum of the object", func() {
+			var (
+				key      string
+				location *os.File
+				client   download.S3Client
+			)
+
+			BeforeEach(func() {
+				key = "products/[elastic-runtime,2.0.0]cf-2.0-build.1.pivotal"
+
+				var err error
+				location, err = ioutil.TempFile("", "cf-2.0-build.1.pivotal")
+				Expect(err).NotTo(HaveOccurred())
+
+				client = download.NewS3Client(download.S3Config{
+					Endpoint: server.URL,
+					Bucket:   "some-bucket",
+				}, http.DefaultClient, logger)
+			})
+
+			AfterEach(func() {
+				location.Close()
+				os.Remove(location.Name())
+			})
+
+			It("verifies the SHA256 checksum", func() {
+				sum := sha256.Sum256([]byte("some-tile"))
+				headers[key] = http.Header{"X-Amz-Checksum-Sha256": {base64.StdEncoding.EncodeToString(sum[:])}}
+
+				err := client.DownloadObject(location, key, ioutil.Discard)
+				Expect(err).NotTo(HaveOccurred())
+
+				sum = sha256.Sum256([]byte("some-other-tile"))
+				headers[key] = http.Header{"X-Amz-Checksum-Sha256": {base64.StdEncoding.EncodeToString(sum[:])}}
+
+				err = client.DownloadObject(location, key, ioutil.Discard)
+				Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("does not match %s: expected %x, got %x", key, sum, sha256.Sum256([]byte("some-tile"))))))
+			})
+
+			It("verifies the MD5 of the ETag", func() {
+				headers[key] = http.Header{"Etag": {fmt.Sprintf(`"%x"`, md5.Sum([]byte("some-tile")))}}
+
+				err := client.DownloadObject(location, key, ioutil.Discard)
+				Expect(err).NotTo(HaveOccurred())
+
+				headers[key] = http.Header{"Etag": {fmt.Sprintf(`"%x"`, md5.Sum([]byte("some-other-tile")))}}
+
+				err = client.DownloadObject(location, key, ioutil.Discard)
+				Expect(err).To(MatchError(ContainSubstring("does not match " + key)))
+			})
+
+			It("does not compare the ETag of objects uploaded in parts", func() {
+				headers[key] = http.Header{"Etag": {fmt.Sprintf(`"%x-2"`, md5.Sum([]byte("some-other-tile")))}}
+
+				err := client.DownloadObject(location, key, ioutil.Discard)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("NewS3HTTPClient", func() {
+		It("trusts the CA certificate of the blobstore", func() {
+			tlsServer := httptest.NewTLSServer(server.Config.Handler)
+			defer tlsServer.Close()
+
+			caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+
+			config := download.S3Config{
+				Endpoint: tlsServer.URL,
+				Bucket:   "some-bucket",
+			}
+
+			httpClient, err := download.NewS3HTTPClient(config)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = download.NewS3Client(config, httpClient, logger).ListObjects("products/")
+			Expect(err).To(MatchError(ContainSubstring("certificate")))
+
+			config.CACert = string(caCert)
+			httpClient, err = download.NewS3HTTPClient(config)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = download.NewS3Client(config, httpClient, logger).ListObjects("products/")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("skips the validation of the certificate", func() {
+			tlsServer := httptest.NewTLSServer(server.Config.Handler)
+			defer tlsServer.Close()
+
+			config := download.S3Config{
+				Endpoint:          tlsServer.URL,
+				Bucket:            "some-bucket",
+				SkipSSLValidation: true,
+			}
+
+			httpClient, err := download.NewS3HTTPClient(config)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = download.NewS3Client(config, httpClient, logger).ListObjects("products/")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error when the CA certificate cannot be read", func() {
+			_, err := download.NewS3HTTPClient(download.S3Config{CACert: "/missing/ca.pem"})
+			Expect(err).To(MatchError(ContainSubstring("could not read ca cert")))
+		})
+	})
+
+	Context("when an access key is given", func() {
+		BeforeEach(func() {
+			download.SetNow(func() time.Time {
+				return time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+			})
+		})
+
+		AfterEach(func() {
+			download.ResetNow()
+		})
+
+		It("signs requests with AWS Signature Version 4", func() {
+			recorder := &recordingClient{}
+			client := download.NewS3Client(download.S3Config{
+				Endpoint:        "http://127.0.0.1:9000",
+				Bucket:          "bucket",
+				AccessKeyID:     "some-access-key",
+				SecretAccessKey: "secret",
+			}, recorder, logger)
+
+			_, err := client.ListObjects("")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(recorder.requests).To(HaveLen(1))
+			req := recorder.requests[0]
+			Expect(req.URL.String()).To(Equal("http://127.0.0.1:9000/bucket?list-type=2&prefix="))
+			Expect(req.Header.Get("X-Amz-Date")).To(Equal("20180102T030405Z"))
+			Expect(req.Header.Get("X-Amz-Content-Sha256")).To(Equal("UNSIGNED-PAYLOAD"))
+			Expect(req.Header.Get("Authorization")).To(Equal("AWS4-HMAC-SHA256 " +
+				"Credential=some-access-key/20180102/us-east-1/s3/aws4_request, " +
+				"SignedHeaders=host;x-amz-content-sha256;x-amz-date, " +
+				"Signature=755a211a4c25525e67e4f08a9719ebb4f10243bf675228a0ac13f9fb09dd59f8"))
+		})
+	})
+})
@@ -13,9 +13,26 @@ import (
 type MetadataExtractor struct{}
 
 type Metadata struct {
-	Name    string
-	Version string `yaml:"product_version"`
-	Raw     []byte
+	Name             string
+	Version          string              `yaml:"product_version"`
+	StemcellCriteria StemcellCriteria    `yaml:"stemcell_criteria"`
+	Dependencies     []ProductDependency `yaml:"requires_product_versions"`
+	Raw              []byte
+}
+
+// StemcellCriteria is the stemcell operating system and minimum version a
+// product can be deployed with.
+type StemcellCriteria struct {
+	OS                         string `yaml:"os"`
+	Version                    string `yaml:"version"`
+	EnablePatchSecurityUpdates bool   `yaml:"enable_patch_security_updates"`
+}
+
+// ProductDependency is another product that has to be installed alongside,
+// with a version constraint such as "~> 2.2".
+type ProductDependency struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
 }
 
 func (me MetadataExtractor) ExtractMetadata(productPath string) (Metadata, error) {
@@ -16,7 +16,14 @@ const (
 	validYAML = `
 ---
 product_version: 1.8.14
-name: some-product`
+name: some-product
+stemcell_criteria:
+  os: ubuntu-xenial
+  version: "97.19"
+  enable_patch_security_updates: true
+requires_product_versions:
+- name: cf
+  version: "~> 2.2"`
 )
 
 var _ = Describe("MetadataExtractor", func() {
@@ -56,12 +63,20 @@ var _ = Describe("MetadataExtractor", func() {
 	})
 
 	Describe("ExtractMetadata", func() {
-		It("Extracts the product name and version from the given pivotal file", func() {
+		It("Extracts the product name, version, stemcell criteria and dependencies from the given pivotal file", func() {
 			metadata, err := metadataExtractor.ExtractMetadata(productFile.Name())
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(metadata.Name).To(Equal("some-product"))
 			Expect(metadata.Version).To(Equal("1.8.14"))
+			Expect(metadata.StemcellCriteria).To(Equal(extractor.StemcellCriteria{
+				OS:                         "ubuntu-xenial",
+				Version:                    "97.19",
+				EnablePatchSecurityUpdates: true,
+			}))
+			Expect(metadata.Dependencies).To(Equal([]extractor.ProductDependency{
+				{Name: "cf", Version: "~> 2.2"},
+			}))
 			Expect(metadata.Raw).To(MatchYAML(validYAML))
 		})
 
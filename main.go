@@ -2,14 +2,19 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
-
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/gosuri/uilive"
+	"github.com/mattn/go-isatty"
 	"github.com/olekukonko/tablewriter"
 	"gopkg.in/yaml.v2"
 
@@ -23,6 +28,7 @@ import (
 	"github.com/pivotal-cf/om/presenters"
 	"github.com/pivotal-cf/om/progress"
 	"github.com/pivotal-cf/om/ui"
+	"github.com/pivotal-cf/om/varsstore"
 )
 
 var version = "unknown"
@@ -34,19 +40,31 @@ type httpClient interface {
 }
 
 type options struct {
-	DecryptionPassphrase string `yaml:"decryption-passphrase" short:"d" long:"decryption-passphrase" env:"OM_DECRYPTION_PASSPHRASE" description:"Passphrase to decrypt the installation if the Ops Manager VM has been rebooted (optional for most commands)"`
-	ClientID             string `yaml:"client-id"            short:"c"  long:"client-id"           env:"OM_CLIENT_ID"                     description:"Client ID for the Ops Manager VM (not required for unauthenticated commands)"`
-	ClientSecret         string `yaml:"client-secret"        short:"s"  long:"client-secret"       env:"OM_CLIENT_SECRET"                 description:"Client Secret for the Ops Manager VM (not required for unauthenticated commands)"`
-	Help                 bool   `                            short:"h"  long:"help"                                       default:"false" description:"prints this usage information"`
-	Password             string `yaml:"password"             short:"p"  long:"password"            env:"OM_PASSWORD"                      description:"admin password for the Ops Manager VM (not required for unauthenticated commands)"`
-	ConnectTimeout       int    `yaml:"connect-timeout"      short:"o"  long:"connect-timeout"                            default:"5"     description:"timeout in seconds to make TCP connections"`
-	RequestTimeout       int    `yaml:"request-timeout"      short:"r"  long:"request-timeout"                            default:"1800"  description:"timeout in seconds for HTTP requests to Ops Manager"`
-	SkipSSLValidation    bool   `yaml:"skip-ssl-validation"  short:"k"  long:"skip-ssl-validation"                        default:"false" description:"skip ssl certificate validation during http requests"`
-	Target               string `yaml:"target"               short:"t"  long:"target"              env:"OM_TARGET"                        description:"location of the Ops Manager VM"`
-	Trace                bool   `yaml:"trace"                short:"tr" long:"trace"                                                      description:"prints HTTP requests and response payloads"`
-	Username             string `yaml:"username"             short:"u"  long:"username"            env:"OM_USERNAME"                      description:"admin username for the Ops Manager VM (not required for unauthenticated commands)"`
-	Env                  string `                            short:"e"  long:"env"                                                        description:"env file with login credentials"`
-	Version              bool   `                            short:"v"  long:"version"                                    default:"false" description:"prints the om release version"`
+	DecryptionPassphrase string   `yaml:"decryption-passphrase" short:"d" long:"decryption-passphrase" env:"OM_DECRYPTION_PASSPHRASE" description:"Passphrase to decrypt the installation if the Ops Manager VM has been rebooted (optional for most commands)"`
+	ClientID             string   `yaml:"client-id"            short:"c"  long:"client-id"           env:"OM_CLIENT_ID"                     description:"Client ID for the Ops Manager VM (not required for unauthenticated commands)"`
+	ClientSecret         string   `yaml:"client-secret"        short:"s"  long:"client-secret"       env:"OM_CLIENT_SECRET"                 description:"Client Secret for the Ops Manager VM (not required for unauthenticated commands)"`
+	Help                 bool     `                            short:"h"  long:"help"                                       default:"false" description:"prints this usage information"`
+	Password             string   `yaml:"password"             short:"p"  long:"password"            env:"OM_PASSWORD"                      description:"admin password for the Ops Manager VM (not required for unauthenticated commands)"`
+	ConnectTimeout       int      `yaml:"connect-timeout"      short:"o"  long:"connect-timeout"                            default:"5"     description:"timeout in seconds to make TCP connections"`
+	RequestTimeout       int      `yaml:"request-timeout"      short:"r"  long:"request-timeout"                            default:"1800"  description:"timeout in seconds for HTTP requests to Ops Manager"`
+	CACert               string   `yaml:"ca-cert"                         long:"ca-cert"             env:"OM_CA_CERT"                       description:"OpsManager CA certificate path or value, added to the system trust pool"`
+	TLSClientCert        string   `yaml:"tls-client-cert"                 long:"tls-client-cert"     env:"OM_TLS_CLIENT_CERT"               description:"client certificate path or value for mutual TLS"`
+	TLSClientKey         string   `yaml:"tls-client-key"                  long:"tls-client-key"      env:"OM_TLS_CLIENT_KEY"                description:"client private key path or value for mutual TLS"`
+	SkipSSLValidation    bool     `yaml:"skip-ssl-validation"  short:"k"  long:"skip-ssl-validation"                        default:"false" description:"skip ssl certificate validation during http requests"`
+	Target               string   `yaml:"target"               short:"t"  long:"target"              env:"OM_TARGET"                        description:"location of the Ops Manager VM"`
+	Trace                bool     `yaml:"trace"                short:"tr" long:"trace"                                                      description:"prints HTTP requests and response payloads, with credentials redacted"`
+	TraceFile            string   `yaml:"trace-file"                      long:"trace-file"                                                 description:"records HTTP requests and responses to a file, in the HAR format if it ends in .har and as JSON lines otherwise"`
+	ReplayFile           string   `yaml:"replay-file"                     long:"replay-file"                                                description:"serves the responses recorded with --trace-file instead of contacting Ops Manager"`
+	TraceUnredacted      bool     `yaml:"trace-unredacted"                long:"trace-unredacted"                                           description:"prints HTTP requests and response payloads without redacting credentials (do not share the output)"`
+	Username             string   `yaml:"username"             short:"u"  long:"username"            env:"OM_USERNAME"                      description:"admin username for the Ops Manager VM (not required for unauthenticated commands)"`
+	Env                  string   `                            short:"e"  long:"env"                                                        description:"env file with login credentials"`
+	EnvName              string   `                                       long:"env-name"            env:"OM_ENV_NAME"                      description:"name of the environment to use from an env file with environments"`
+	Version              bool     `                            short:"v"  long:"version"                                    default:"false" description:"prints the om release version"`
+	VarsStore            []string `yaml:"vars-store"                      long:"vars-store"                                                 description:"store to look up variables not provided with --vars-file or --vars-env (e.g.: credhub://credhub.example.com/prefix, file-exec:./get-secret)"`
+	MaxRetries           int      `yaml:"max-retries"                     long:"max-retries"                                default:"3"     description:"number of times to retry idempotent requests that fail with a connection error or a 502, 503 or 504"`
+	RetryBackoff         int      `yaml:"retry-backoff"                   long:"retry-backoff"                              default:"1"     description:"initial delay in seconds between retries, doubled for every retry"`
+	Output               string   `yaml:"output"                          long:"output"              env:"OM_OUTPUT"       default:"text"  description:"output of commands that change Ops Manager: text, or json for one JSON result on stdout with the logs on stderr"`
+	TokenCache           bool     `yaml:"token-cache"                     long:"token-cache"         env:"OM_TOKEN_CACHE"                   description:"cache UAA tokens in $OM_TOKEN_CACHE_DIR (default: ~/.om/tokens) and reuse them across invocations"`
 }
 
 func main() {
@@ -59,17 +77,17 @@ func main() {
 
 	args, err := jhanda.Parse(&global, os.Args[1:])
 	if err != nil {
-		stderr.Fatal(err)
+		exit(stderr, commands.ConfigError{Err: err})
 	}
 
 	err = setEnvFileProperties(&global)
 	if err != nil {
-		stderr.Fatal(err)
+		exit(stderr, commands.ConfigError{Err: err})
 	}
 
 	globalFlagsUsage, err := jhanda.PrintUsage(global)
 	if err != nil {
-		stderr.Fatal(err)
+		exit(stderr, commands.ConfigError{Err: err})
 	}
 
 	var command string
@@ -91,21 +109,49 @@ func main() {
 
 	requestTimeout := time.Duration(global.RequestTimeout) * time.Second
 	connectTimeout := time.Duration(global.ConnectTimeout) * time.Second
+	retryBackoff := time.Duration(global.RetryBackoff) * time.Second
+
+	tokenCache := network.NewTokenCache(tokenCacheDir())
+
+	tlsConfig, err := network.NewTLSConfig(global.SkipSSLValidation, global.CACert, global.TLSClientCert, global.TLSClientKey)
+	if err != nil {
+		exit(stderr, commands.ConfigError{Err: err})
+	}
+
+	varsStore, err := newVarsStore(global.VarsStore)
+	if err != nil {
+		exit(stderr, commands.ConfigError{Err: err})
+	}
 
 	var unauthenticatedClient, authedClient, authedCookieClient, unauthenticatedProgressClient, authedProgressClient httpClient
-	unauthenticatedClient = network.NewUnauthenticatedClient(global.Target, global.SkipSSLValidation, requestTimeout, connectTimeout)
-	authedClient, err = network.NewOAuthClient(global.Target, global.Username, global.Password, global.ClientID, global.ClientSecret, global.SkipSSLValidation, false, requestTimeout, connectTimeout)
+	unauthenticatedClient = network.NewRetryClient(network.NewUnauthenticatedClient(global.Target, tlsConfig, requestTimeout, connectTimeout), global.MaxRetries, retryBackoff, os.Stderr)
+	oauthClient, err := network.NewOAuthClient(global.Target, global.Username, global.Password, global.ClientID, global.ClientSecret, tlsConfig, false, requestTimeout, connectTimeout)
+	if err != nil {
+		exit(stderr, commands.ConfigError{Err: err})
+	}
+	oauthClient = oauthClient.WithTokenCache(tokenCache, global.TokenCache).WithRetries(global.MaxRetries, retryBackoff)
+	authedClient = network.NewRetryClient(oauthClient, global.MaxRetries, retryBackoff, os.Stderr)
 
 	if global.DecryptionPassphrase != "" {
 		authedClient = network.NewDecryptClient(authedClient, unauthenticatedClient, global.DecryptionPassphrase, os.Stderr)
 	}
 
+	oauthCookieClient, err := network.NewOAuthClient(global.Target, global.Username, global.Password, global.ClientID, global.ClientSecret, tlsConfig, true, requestTimeout, connectTimeout)
 	if err != nil {
-		stderr.Fatal(err)
+		exit(stderr, commands.ConfigError{Err: err})
 	}
-	authedCookieClient, err = network.NewOAuthClient(global.Target, global.Username, global.Password, global.ClientID, global.ClientSecret, global.SkipSSLValidation, true, requestTimeout, connectTimeout)
-	if err != nil {
-		stderr.Fatal(err)
+	oauthCookieClient = oauthCookieClient.WithTokenCache(tokenCache, global.TokenCache).WithRetries(global.MaxRetries, retryBackoff)
+	authedCookieClient = network.NewRetryClient(oauthCookieClient, global.MaxRetries, retryBackoff, os.Stderr)
+
+	if global.ReplayFile != "" {
+		replayClient, err := network.NewReplayClient(global.ReplayFile)
+		if err != nil {
+			exit(stderr, commands.ConfigError{Err: err})
+		}
+
+		unauthenticatedClient = replayClient
+		authedClient = replayClient
+		authedCookieClient = replayClient
 	}
 
 	liveWriter := uilive.New()
@@ -113,14 +159,54 @@ func main() {
 	unauthenticatedProgressClient = network.NewProgressClient(unauthenticatedClient, progress.NewBar(), liveWriter)
 	authedProgressClient = network.NewProgressClient(authedClient, progress.NewBar(), liveWriter)
 
-	if global.Trace {
-		unauthenticatedClient = network.NewTraceClient(unauthenticatedClient, os.Stderr)
-		unauthenticatedProgressClient = network.NewTraceClient(unauthenticatedProgressClient, os.Stderr)
-		authedClient = network.NewTraceClient(authedClient, os.Stderr)
-		authedCookieClient = network.NewTraceClient(authedCookieClient, os.Stderr)
-		authedProgressClient = network.NewTraceClient(authedProgressClient, os.Stderr)
+	if global.Trace || global.TraceUnredacted || global.TraceFile != "" {
+		var traceWriter io.Writer
+		if global.Trace || global.TraceUnredacted {
+			traceWriter = os.Stderr
+		}
+
+		var traceRecorder *network.TraceRecorder
+		if global.TraceFile != "" {
+			traceRecorder, err = network.NewTraceRecorder(global.TraceFile, version)
+			if err != nil {
+				exit(stderr, commands.ConfigError{Err: err})
+			}
+		}
+
+		redact := !global.TraceUnredacted
+		traceClient := func(client httpClient) httpClient {
+			tc := network.NewTraceClient(client, traceWriter, redact)
+			if traceRecorder != nil {
+				return tc.WithRecorder(traceRecorder)
+			}
+			return tc
+		}
+
+		unauthenticatedClient = traceClient(unauthenticatedClient)
+		unauthenticatedProgressClient = traceClient(unauthenticatedProgressClient)
+		authedClient = traceClient(authedClient)
+		authedCookieClient = traceClient(authedCookieClient)
+		authedProgressClient = traceClient(authedProgressClient)
 	}
 
+	results, err := commands.NewResultWriter(global.Output, os.Stdout)
+	if err != nil {
+		exit(stderr, commands.ConfigError{Err: err})
+	}
+
+	// with the json output, stdout is left to the result of the command
+	resultLogger, resultLogOutput := stdout, io.Writer(os.Stdout)
+	if global.Output == "json" {
+		resultLogger, resultLogOutput = stderr, os.Stderr
+	}
+
+	dryRunApi := api.New(api.ApiInput{
+		Client:                 network.NewDryRunClient(authedClient, resultLogOutput, !global.TraceUnredacted),
+		UnauthedClient:         unauthenticatedClient,
+		ProgressClient:         authedProgressClient,
+		UnauthedProgressClient: unauthenticatedProgressClient,
+		Logger:                 stderr,
+	})
 	api := api.New(api.ApiInput{
 		Client:                 authedClient,
 		UnauthedClient:         unauthenticatedClient,
@@ -131,9 +217,9 @@ func main() {
 	ui := ui.New(ui.UiInput{
 		Client: authedCookieClient,
 	})
-	logWriter := commands.NewLogWriter(os.Stdout)
+	logWriter := commands.NewLogWriter(resultLogOutput)
 	tableWriter := tablewriter.NewWriter(os.Stdout)
-	pivnetLogWriter := logshim.NewLogShim(stdout, stdout, global.Trace)
+	pivnetLogWriter := logshim.NewLogShim(resultLogger, resultLogger, global.Trace)
 
 	form := formcontent.NewForm()
 
@@ -141,61 +227,103 @@ func main() {
 
 	pivnetFactory := commands.DefaultPivnetFactory
 
-	presenter := presenters.NewPresenter(presenters.NewTablePresenter(tableWriter), presenters.NewJSONPresenter(os.Stdout))
+	presenter := presenters.NewPresenter(presenters.NewTablePresenter(tableWriter), presenters.NewJSONPresenter(os.Stdout)).
+		AddFormat("yaml", presenters.Static(presenters.NewYAMLPresenter(os.Stdout))).
+		AddFormat("csv", presenters.Static(presenters.NewCSVPresenter(os.Stdout))).
+		AddFormat("template", func(text string) (presenters.Presenter, error) {
+			return presenters.NewTemplatePresenter(os.Stdout, text)
+		})
+	if global.Output == "json" {
+		presenter.AddFormat("table", presenters.Static(presenters.NewJSONPresenter(os.Stdout)))
+	}
+
+	// converge writes one result for all of its steps, so the commands that
+	// it runs discard theirs
+	var noResults commands.ResultWriter
+	convergeCommands := jhanda.CommandSet{}
+	convergeCommands["apply-changes"] = commands.NewApplyChanges(api, api, commands.NewLogWriter(resultLogOutput), resultLogger, noResults, applySleepDuration)
+	convergeCommands["assign-stemcell"] = commands.NewAssignStemcell(api, metadataExtractor, resultLogger, noResults, varsStore)
+	convergeCommands["configure-director"] = commands.NewConfigureDirector(os.Environ, varsStore, api, dryRunApi, resultLogger, noResults)
+	convergeCommands["configure-product"] = commands.NewConfigureProduct(os.Environ, varsStore, api, dryRunApi, resultLogger, noResults)
+	convergeCommands["download-product"] = commands.NewDownloadProduct(os.Environ, varsStore, pivnetLogWriter, resultLogOutput, noResults, pivnetFactory)
+	convergeCommands["stage-product"] = commands.NewStageProduct(api, resultLogger, noResults)
+	convergeCommands["upload-product"] = commands.NewUploadProduct(form, metadataExtractor, api, resultLogger, noResults, varsStore)
+	convergeCommands["upload-stemcell"] = commands.NewUploadStemcell(form, api, resultLogger, noResults)
+
+	applyChanges := commands.NewApplyChanges(api, api, commands.NewLogWriter(resultLogOutput), resultLogger, results, applySleepDuration)
+	if output, ok := resultLogOutput.(*os.File); ok && isatty.IsTerminal(output.Fd()) {
+		statusWriter := uilive.New()
+		statusWriter.Out = output
+		applyChanges = applyChanges.WithLiveWriter(statusWriter)
+	}
 
 	commandSet := jhanda.CommandSet{}
-	commandSet["activate-certificate-authority"] = commands.NewActivateCertificateAuthority(api, stdout)
-	commandSet["apply-changes"] = commands.NewApplyChanges(api, api, logWriter, stdout, applySleepDuration)
-	commandSet["assign-stemcell"] = commands.NewAssignStemcell(api, stdout)
-	commandSet["available-products"] = commands.NewAvailableProducts(api, presenter, stdout)
+	commandSet["activate-certificate-authority"] = commands.NewActivateCertificateAuthority(api, resultLogger, results)
+	commandSet["apply-changes"] = applyChanges
+	commandSet["assign-stemcell"] = commands.NewAssignStemcell(api, metadataExtractor, resultLogger, results, varsStore)
+	commandSet["available-products"] = commands.NewAvailableProducts(api, presenter, resultLogger)
+	commandSet["backup"] = commands.NewBackup(api, resultLogger, results)
 	commandSet["certificate-authorities"] = commands.NewCertificateAuthorities(api, presenter)
 	commandSet["certificate-authority"] = commands.NewCertificateAuthority(api, presenter, stdout)
 	commandSet["config-template"] = commands.NewConfigTemplate(metadataExtractor, stdout)
-	commandSet["configure-authentication"] = commands.NewConfigureAuthentication(api, stdout)
-	commandSet["configure-director"] = commands.NewConfigureDirector(os.Environ, api, stdout)
-	commandSet["configure-product"] = commands.NewConfigureProduct(os.Environ, api, stdout)
-	commandSet["configure-saml-authentication"] = commands.NewConfigureSAMLAuthentication(api, stdout)
+	commandSet["configure-authentication"] = commands.NewConfigureAuthentication(api, resultLogger, results, varsStore)
+	commandSet["configure-director"] = commands.NewConfigureDirector(os.Environ, varsStore, api, dryRunApi, resultLogger, results)
+	commandSet["configure-product"] = commands.NewConfigureProduct(os.Environ, varsStore, api, dryRunApi, resultLogger, results)
+	commandSet["configure-saml-authentication"] = commands.NewConfigureSAMLAuthentication(api, resultLogger, results, varsStore)
+	commandSet["converge"] = commands.NewConverge(os.Environ, varsStore, api, convergeCommands, resultLogger, results)
 	commandSet["create-certificate-authority"] = commands.NewCreateCertificateAuthority(api, presenter)
-	commandSet["create-vm-extension"] = commands.NewCreateVMExtension(os.Environ, api, stdout)
-	commandSet["credential-references"] = commands.NewCredentialReferences(api, presenter, stdout)
+	commandSet["create-vm-extension"] = commands.NewCreateVMExtension(os.Environ, varsStore, api, resultLogger, results)
+	commandSet["credential-references"] = commands.NewCredentialReferences(api, presenter, resultLogger)
 	commandSet["credentials"] = commands.NewCredentials(api, presenter, stdout)
 	commandSet["curl"] = commands.NewCurl(api, stdout, stderr)
-	commandSet["delete-certificate-authority"] = commands.NewDeleteCertificateAuthority(api, stdout)
-	commandSet["delete-installation"] = commands.NewDeleteInstallation(api, logWriter, stdout, applySleepDuration)
-	commandSet["delete-product"] = commands.NewDeleteProduct(api)
-	commandSet["delete-unused-products"] = commands.NewDeleteUnusedProducts(api, stdout)
+	commandSet["delete-certificate-authority"] = commands.NewDeleteCertificateAuthority(api, resultLogger, results)
+	commandSet["delete-installation"] = commands.NewDeleteInstallation(api, logWriter, resultLogger, results, applySleepDuration)
+	commandSet["delete-product"] = commands.NewDeleteProduct(api, results)
+	commandSet["delete-unused-products"] = commands.NewDeleteUnusedProducts(api, resultLogger, results)
 	commandSet["deployed-manifest"] = commands.NewDeployedManifest(api, stdout)
 	commandSet["deployed-products"] = commands.NewDeployedProducts(presenter, api)
-	commandSet["download-product"] = commands.NewDownloadProduct(os.Environ, pivnetLogWriter, os.Stdout, pivnetFactory)
+	commandSet["diff-product-config"] = commands.NewDiffProductConfig(os.Environ, varsStore, api, metadataExtractor, stdout)
+	commandSet["download-product"] = commands.NewDownloadProduct(os.Environ, varsStore, pivnetLogWriter, resultLogOutput, results, pivnetFactory)
 	commandSet["errands"] = commands.NewErrands(presenter, api)
-	commandSet["export-installation"] = commands.NewExportInstallation(api, stderr)
-	commandSet["generate-certificate"] = commands.NewGenerateCertificate(api, stdout)
+	commandSet["export-installation"] = commands.NewExportInstallation(api, stderr, results)
+	commandSet["generate-certificate"] = commands.NewGenerateCertificate(api, resultLogger, results)
 	commandSet["generate-certificate-authority"] = commands.NewGenerateCertificateAuthority(api, presenter)
 	commandSet["help"] = commands.NewHelp(os.Stdout, globalFlagsUsage, commandSet)
-	commandSet["import-installation"] = commands.NewImportInstallation(form, api, global.DecryptionPassphrase, stdout)
+	commandSet["import-installation"] = commands.NewImportInstallation(form, api, global.DecryptionPassphrase, resultLogger, results, varsStore)
+	commandSet["info"] = commands.NewInfo(presenter, api)
 	commandSet["installation-log"] = commands.NewInstallationLog(api, stdout)
 	commandSet["installations"] = commands.NewInstallations(api, presenter)
-	commandSet["interpolate"] = commands.NewInterpolate(os.Environ, stdout)
+	commandSet["interpolate"] = commands.NewInterpolate(os.Environ, varsStore, stdout)
+	commandSet["lint-product-config"] = commands.NewLintProductConfig(metadataExtractor, stdout)
+	commandSet["login"] = commands.NewLogin(oauthClient, resultLogger)
+	commandSet["logout"] = commands.NewLogout(oauthClient, resultLogger)
 	commandSet["pending-changes"] = commands.NewPendingChanges(presenter, api)
-	commandSet["regenerate-certificates"] = commands.NewRegenerateCertificates(api, stdout)
-	commandSet["revert-staged-changes"] = commands.NewRevertStagedChanges(ui, stdout)
-	commandSet["stage-product"] = commands.NewStageProduct(api, stdout)
+	commandSet["regenerate-certificates"] = commands.NewRegenerateCertificates(api, resultLogger, results)
+	commandSet["revert-staged-changes"] = commands.NewRevertStagedChanges(ui, resultLogger, results)
+	commandSet["stage-product"] = commands.NewStageProduct(api, resultLogger, results)
 	commandSet["staged-config"] = commands.NewStagedConfig(api, stdout)
 	commandSet["staged-director-config"] = commands.NewStagedDirectorConfig(api, stdout)
 	commandSet["staged-manifest"] = commands.NewStagedManifest(api, stdout)
 	commandSet["staged-products"] = commands.NewStagedProducts(presenter, api)
 	commandSet["tile-metadata"] = commands.NewTileMetadata(stdout)
-	commandSet["unstage-product"] = commands.NewUnstageProduct(api, stdout)
-	commandSet["upload-product"] = commands.NewUploadProduct(form, metadataExtractor, api, stdout)
-	commandSet["upload-stemcell"] = commands.NewUploadStemcell(form, api, stdout)
+	commandSet["unstage-product"] = commands.NewUnstageProduct(api, resultLogger, results)
+	commandSet["upload-product"] = commands.NewUploadProduct(form, metadataExtractor, api, resultLogger, results, varsStore)
+	commandSet["upload-stemcell"] = commands.NewUploadStemcell(form, api, resultLogger, results)
 	commandSet["version"] = commands.NewVersion(version, os.Stdout)
+	commandSet["wait-for-ready"] = commands.NewWaitForReady(api, resultLogger, results, global.DecryptionPassphrase, applySleepDuration)
 
-	err = commandSet.Execute(command, args)
+	err = commands.Execute(commandSet, command, args)
 	if err != nil {
-		stderr.Fatal(err)
+		exit(stderr, err)
 	}
 }
 
+// exit prints the error and exits with its exit code, see commands.ExitCode.
+func exit(logger *log.Logger, err error) {
+	logger.Println(err)
+	os.Exit(commands.ExitCode(err))
+}
+
 func setEnvFileProperties(global *options) error {
 	if global.Env == "" {
 		return nil
@@ -212,6 +340,20 @@ func setEnvFileProperties(global *options) error {
 		return fmt.Errorf("cannot read env file: %s", err)
 	}
 
+	contents, hasEnvironments, err := selectEnvironment(contents, global.EnvName)
+	if err != nil {
+		return err
+	}
+
+	// only env files with environments are interpolated, so that the values
+	// of existing env files, such as passwords with "((", are kept as they are
+	if hasEnvironments {
+		contents, err = interpolateEnvFile(contents, global)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = yaml.Unmarshal(contents, &opts)
 	if err != nil {
 		return fmt.Errorf("could not parse env file: %s", err)
@@ -232,21 +374,167 @@ func setEnvFileProperties(global *options) error {
 	if global.RequestTimeout == 1800 && opts.RequestTimeout != 0 {
 		global.RequestTimeout = opts.RequestTimeout
 	}
+	if global.Output == "text" && opts.Output != "" {
+		global.Output = opts.Output
+	}
+	if global.MaxRetries == 3 && opts.MaxRetries != 0 {
+		global.MaxRetries = opts.MaxRetries
+	}
+	if global.RetryBackoff == 1 && opts.RetryBackoff != 0 {
+		global.RetryBackoff = opts.RetryBackoff
+	}
 	if global.SkipSSLValidation == false {
 		global.SkipSSLValidation = opts.SkipSSLValidation
 	}
+	if global.CACert == "" {
+		global.CACert = opts.CACert
+	}
+	if global.TLSClientCert == "" {
+		global.TLSClientCert = opts.TLSClientCert
+	}
+	if global.TLSClientKey == "" {
+		global.TLSClientKey = opts.TLSClientKey
+	}
 	if global.Target == "" {
 		global.Target = opts.Target
 	}
 	if global.Trace == false {
 		global.Trace = opts.Trace
 	}
+	if global.TraceUnredacted == false {
+		global.TraceUnredacted = opts.TraceUnredacted
+	}
+	if global.TraceFile == "" {
+		global.TraceFile = opts.TraceFile
+	}
+	if global.ReplayFile == "" {
+		global.ReplayFile = opts.ReplayFile
+	}
 	if global.Username == "" {
 		global.Username = opts.Username
 	}
 	if global.DecryptionPassphrase == "" {
 		global.DecryptionPassphrase = opts.DecryptionPassphrase
 	}
+	if len(global.VarsStore) == 0 {
+		global.VarsStore = opts.VarsStore
+	}
+	if global.TokenCache == false {
+		global.TokenCache = opts.TokenCache
+	}
 
 	return nil
 }
+
+// interpolateEnvFile interpolates the env file with the vars stores, which
+// can be set in the env file, but not with variables.
+func interpolateEnvFile(contents []byte, global *options) ([]byte, error) {
+	var stores struct {
+		VarsStore []string `yaml:"vars-store"`
+	}
+	err := yaml.Unmarshal(contents, &stores)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse env file: %s", err)
+	}
+
+	if len(global.VarsStore) > 0 {
+		stores.VarsStore = global.VarsStore
+	}
+
+	varsStore, err := newVarsStore(stores.VarsStore)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err = commands.InterpolateVariables(contents, os.Environ, "OM_VAR", varsStore)
+	if err != nil {
+		return nil, fmt.Errorf("could not interpolate env file: %s", err)
+	}
+
+	return contents, nil
+}
+
+// selectEnvironment returns the options of the named environment of an env
+// file with environments, on top of the options outside of environments,
+// which are shared by every environment. It returns whether the env file
+// has environments.
+func selectEnvironment(contents []byte, name string) ([]byte, bool, error) {
+	var document map[string]interface{}
+	err := yaml.Unmarshal(contents, &document)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not parse env file: %s", err)
+	}
+
+	value, ok := document["environments"]
+	if !ok {
+		if name != "" {
+			return nil, false, fmt.Errorf("env file does not define any environments, but the environment %q was selected", name)
+		}
+		return contents, false, nil
+	}
+
+	environments, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("could not parse env file: environments must be a map of names to options")
+	}
+
+	var names []string
+	for environmentName := range environments {
+		names = append(names, fmt.Sprintf("%v", environmentName))
+	}
+	sort.Strings(names)
+
+	if name == "" {
+		return nil, false, fmt.Errorf("env file defines the environments %s, select one with --env-name or OM_ENV_NAME", strings.Join(names, ", "))
+	}
+
+	value, ok = environments[name]
+	if !ok {
+		return nil, false, fmt.Errorf("environment %q is not defined in the env file, the environments are: %s", name, strings.Join(names, ", "))
+	}
+
+	environment, ok := value.(map[interface{}]interface{})
+	if !ok && value != nil {
+		return nil, false, fmt.Errorf("could not parse env file: environment %q must be a map of options", name)
+	}
+
+	selected := map[interface{}]interface{}{}
+	for key, value := range document {
+		if key != "environments" {
+			selected[key] = value
+		}
+	}
+	for key, value := range environment {
+		selected[key] = value
+	}
+
+	contents, err = yaml.Marshal(selected)
+	return contents, true, err
+}
+
+// newVarsStore returns the stores at uris, consulted in order.
+func newVarsStore(uris []string) (boshtpl.Variables, error) {
+	var varsStores []boshtpl.Variables
+	for _, uri := range uris {
+		store, err := varsstore.New(uri)
+		if err != nil {
+			return nil, err
+		}
+		varsStores = append(varsStores, store)
+	}
+
+	return boshtpl.NewMultiVars(varsStores), nil
+}
+
+func tokenCacheDir() string {
+	if dir := os.Getenv("OM_TOKEN_CACHE_DIR"); dir != "" {
+		return dir
+	}
+
+	home := os.Getenv("HOME")
+	if home == "" {
+		home = os.Getenv("USERPROFILE")
+	}
+
+	return filepath.Join(home, ".om", "tokens")
+}
@@ -20,3 +20,16 @@ type Errand struct {
 	PostDeployEnabled string `json:"post_deploy_enabled,omitempty"`
 	PreDeleteEnabled  string `json:"pre_delete_enabled,omitempty"`
 }
+
+type OpsManagerInfo struct {
+	Version            string    `json:"version"`
+	InfrastructureType string    `json:"infrastructure_type,omitempty"`
+	Features           []Feature `json:"features"`
+}
+
+type Feature struct {
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	MinimumVersion string `json:"minimum_version"`
+	Supported      bool   `json:"supported"`
+}
@@ -71,17 +71,17 @@ func (c *DecryptClient) decrypt() error {
 		}
 
 		if err != nil {
-			return fmt.Errorf("could not make api request to unlock endpoint: %s", err)
+			return fmt.Errorf("could not make api request to unlock endpoint: %w", err)
 		}
 	}
 
 	if err != nil {
-		return fmt.Errorf("could not make api request to unlock endpoint: %s", err)
+		return fmt.Errorf("could not make api request to unlock endpoint: %w", err)
 	}
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("could not unlock ops manager, check if the decryption passphrase is correct")
+		return LockedError{}
 	}
 
 	return c.waitUntilAvailable()
@@ -100,13 +100,21 @@ func (c DecryptClient) waitUntilAvailable() error {
 		}
 
 		if !IsTemporary(err) {
-			return fmt.Errorf("could not check Ops Manager Status: %s", err)
+			return fmt.Errorf("could not check Ops Manager Status: %w", err)
 		}
 
 		trial++
 	}
 }
 
+// LockedError is returned when Ops Manager could not be unlocked with the
+// decryption passphrase.
+type LockedError struct{}
+
+func (e LockedError) Error() string {
+	return "could not unlock ops manager, check if the decryption passphrase is correct"
+}
+
 type RetryError struct {
 	Err       error
 	Retryable bool
@@ -155,7 +163,7 @@ func (c DecryptClient) checkAvailability() error {
 
 	response, err := c.unauthedClient.Do(request)
 	if err != nil {
-		return NonRetryableError(fmt.Errorf("could not make request round trip: %s", err))
+		return NonRetryableError(fmt.Errorf("could not make request round trip: %w", err))
 	}
 
 	defer response.Body.Close()
@@ -164,7 +172,7 @@ func (c DecryptClient) checkAvailability() error {
 	case http.StatusFound:
 		location, err := url.Parse(response.Header.Get("Location"))
 		if err != nil {
-			return NonRetryableError(fmt.Errorf("could not parse redirect url: %s", err))
+			return NonRetryableError(fmt.Errorf("could not parse redirect url: %w", err))
 		}
 
 		switch location.Path {
@@ -0,0 +1,67 @@
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+type DryRunClient struct {
+	client httpClient
+	writer io.Writer
+	redact bool
+}
+
+func NewDryRunClient(client httpClient, writer io.Writer, redact bool) *DryRunClient {
+	return &DryRunClient{
+		client: client,
+		writer: writer,
+		redact: redact,
+	}
+}
+
+// Do only sends GET and HEAD requests. Every other request is written to the
+// writer and answered with an empty successful response instead. Like the
+// trace, the credentials in the written bodies are redacted, unless redact is
+// false.
+func (c *DryRunClient) Do(request *http.Request) (*http.Response, error) {
+	if request.Method == "GET" || request.Method == "HEAD" {
+		return c.client.Do(request)
+	}
+
+	fmt.Fprintf(c.writer, "%s %s\n", request.Method, request.URL)
+
+	if request.Body != nil {
+		body, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("could not read request body: %w", err)
+		}
+		request.Body.Close()
+
+		if c.redact {
+			if strings.Contains(request.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+				body = redactForm(body)
+			} else {
+				body = redactJSON(body)
+			}
+		}
+
+		if len(body) > 0 {
+			fmt.Fprintf(c.writer, "%s\n", string(body))
+		}
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+		Request:    request,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package network_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-cf/om/network"
+	"github.com/pivotal-cf/om/network/fakes"
+)
+
+var _ = Describe("Dry Run Client", func() {
+	var (
+		fakeClient   *fakes.HttpClient
+		dryRunClient *network.DryRunClient
+
+		out *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fakeClient = &fakes.HttpClient{}
+		fakeClient.DoReturns(&http.Response{StatusCode: http.StatusTeapot}, nil)
+
+		out = gbytes.NewBuffer()
+
+		dryRunClient = network.NewDryRunClient(fakeClient, out, true)
+	})
+
+	It("sends GET requests to the underlying http client", func() {
+		request, err := http.NewRequest("GET", "/api/v0/staged/products", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := dryRunClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+		Expect(fakeClient.DoArgsForCall(0)).To(Equal(request))
+		Expect(resp.StatusCode).To(Equal(http.StatusTeapot))
+		Expect(out.Contents()).To(BeEmpty())
+	})
+
+	It("prints PUT, POST and DELETE requests instead of sending them", func() {
+		for _, method := range []string{"PUT", "POST", "DELETE"} {
+			request, err := http.NewRequest(method, "/api/v0/staged/products/some-guid/properties", strings.NewReader(`{"properties":{}}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err := dryRunClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{}`))
+
+			Expect(out).To(gbytes.Say("%s /api/v0/staged/products/some-guid/properties\n", method))
+			Expect(out).To(gbytes.Say(`\{"properties":\{\}\}\n`))
+		}
+
+		Expect(fakeClient.DoCallCount()).To(Equal(0))
+	})
+
+	It("redacts the credentials in the request body", func() {
+		request, err := http.NewRequest("PUT", "/api/v0/staged/products/some-guid/properties", strings.NewReader(`{"properties":{".properties.some-secret":{"value":{"secret":"some-secret-value"}},".properties.some-user":{"value":{"identity":"admin","password":"some-password"}}}}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dryRunClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(string(out.Contents())).NotTo(ContainSubstring("some-secret-value"))
+		Expect(string(out.Contents())).NotTo(ContainSubstring("some-password"))
+		Expect(string(out.Contents())).To(ContainSubstring(`"identity":"admin"`))
+		Expect(string(out.Contents())).To(ContainSubstring(`"secret":"[REDACTED]"`))
+	})
+
+	It("prints the request body unredacted when redaction is disabled", func() {
+		dryRunClient = network.NewDryRunClient(fakeClient, out, false)
+
+		request, err := http.NewRequest("PUT", "/api/v0/staged/director/properties", strings.NewReader(`{"director_configuration":{"password":"some-password"}}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dryRunClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(string(out.Contents())).To(ContainSubstring(`"password":"some-password"`))
+	})
+
+	It("does not print an empty request body", func() {
+		request, err := http.NewRequest("DELETE", "/api/v0/staged/vm_extensions/some-extension", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dryRunClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(string(out.Contents())).To(Equal("DELETE /api/v0/staged/vm_extensions/some-extension\n"))
+	})
+})
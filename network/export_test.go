@@ -0,0 +1,11 @@
+package network
+
+import "time"
+
+func SetSleep(f func(time.Duration)) {
+	sleep = f
+}
+
+func ResetSleep() {
+	sleep = time.Sleep
+}
@@ -4,12 +4,12 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
-	"io"
 	"net"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -27,9 +27,21 @@ type OAuthClient struct {
 	password      string
 	target        string
 	timeout       time.Duration
+	tokenCache    *TokenCache
+	storeTokens   bool
+	maxRetries    int
+	backoff       time.Duration
+	current       *currentToken
 }
 
-func NewOAuthClient(target, username, password string, clientID, clientSecret string, insecureSkipVerify bool, includeCookies bool, requestTimeout time.Duration, connectTimeout time.Duration) (OAuthClient, error) {
+// currentToken holds the token of a client, and of its copies, so that it is
+// retrieved once per process rather than for every request.
+type currentToken struct {
+	sync.Mutex
+	token *oauth2.Token
+}
+
+func NewOAuthClient(target, username, password string, clientID, clientSecret string, tlsConfig *tls.Config, includeCookies bool, requestTimeout time.Duration, connectTimeout time.Duration) (OAuthClient, error) {
 	conf := &oauth2.Config{
 		ClientID:     "opsman",
 		ClientSecret: "",
@@ -43,10 +55,8 @@ func NewOAuthClient(target, username, password string, clientID, clientSecret st
 
 	httpclient := &http.Client{
 		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: insecureSkipVerify,
-			},
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
 			Dial: (&net.Dialer{
 				Timeout:   connectTimeout,
 				KeepAlive: 30 * time.Second,
@@ -75,19 +85,95 @@ func NewOAuthClient(target, username, password string, clientID, clientSecret st
 		password:      password,
 		target:        target,
 		timeout:       requestTimeout,
+		maxRetries:    TOKEN_ATTEMPT_COUNT - 1,
+		current:       &currentToken{},
 	}, nil
 }
 
+// WithTokenCache returns a copy of the client that reuses tokens from the
+// cache while they are valid and refreshes them when they expire. Tokens of
+// users that are not in the cache yet are only added when store is true.
+func (oc OAuthClient) WithTokenCache(cache TokenCache, store bool) OAuthClient {
+	oc.tokenCache = &cache
+	oc.storeTokens = store
+	return oc
+}
+
+// WithRetries returns a copy of the client that retries retrieving a token
+// up to maxRetries times with the same backoff as the RetryClient.
+func (oc OAuthClient) WithRetries(maxRetries int, backoff time.Duration) OAuthClient {
+	oc.maxRetries = maxRetries
+	oc.backoff = backoff
+	return oc
+}
+
 func (oc OAuthClient) Do(request *http.Request) (*http.Response, error) {
-	var client *http.Client
+	targetURL, err := oc.targetURL()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oc.token(targetURL)
+	if err != nil {
+		return nil, err
+	}
 
+	client := oauth2.NewClient(oc.context, oauth2.StaticTokenSource(token))
+	client.Timeout = oc.timeout
+
+	if oc.jar != nil {
+		client.Jar = oc.jar
+	}
+
+	request.URL.Scheme = targetURL.Scheme
+	request.URL.Host = targetURL.Host
+
+	return client.Do(request)
+}
+
+// Login retrieves a new token with the credentials of the client and adds it
+// to the token cache.
+func (oc OAuthClient) Login() error {
+	if oc.tokenCache == nil {
+		return fmt.Errorf("no token cache is configured")
+	}
+
+	targetURL, err := oc.targetURL()
+	if err != nil {
+		return err
+	}
+
+	token, err := oc.newToken()
+	if err != nil {
+		return err
+	}
+
+	return oc.tokenCache.Store(cacheTarget(targetURL), oc.cacheUser(), token)
+}
+
+// Logout removes the token of the client from the token cache and reports
+// whether there was one.
+func (oc OAuthClient) Logout() (bool, error) {
+	if oc.tokenCache == nil {
+		return false, fmt.Errorf("no token cache is configured")
+	}
+
+	targetURL, err := oc.targetURL()
+	if err != nil {
+		return false, err
+	}
+
+	return oc.tokenCache.Delete(cacheTarget(targetURL), oc.cacheUser())
+}
+
+func (oc OAuthClient) targetURL() (*url.URL, error) {
 	if oc.target == "" {
 		return nil, fmt.Errorf("target flag is required. Run `om help` for more info.")
 	}
 
 	targetURL, err := url.Parse(oc.target)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse target url: %s", err)
+		return nil, fmt.Errorf("could not parse target url: %w", err)
 	}
 
 	if targetURL.Scheme == "" {
@@ -98,97 +184,142 @@ func (oc OAuthClient) Do(request *http.Request) (*http.Response, error) {
 	// when setting the Path value below.
 	targetURL, err = url.Parse(targetURL.String())
 	if err != nil {
-		return nil, fmt.Errorf("could not parse target url: %s", err)
+		return nil, fmt.Errorf("could not parse target url: %w", err)
 	}
 
-	targetURL.Path = "/uaa/oauth/token"
-	oc.oauthConfigCC.TokenURL = targetURL.String()
-	oc.oauthConfig.Endpoint.TokenURL = targetURL.String()
+	tokenURL := *targetURL
+	tokenURL.Path = "/uaa/oauth/token"
+	oc.oauthConfigCC.TokenURL = tokenURL.String()
+	oc.oauthConfig.Endpoint.TokenURL = tokenURL.String()
 
-	if oc.oauthConfigCC.ClientID != "" {
-		client = oc.oauthConfigCC.Client(oc.context)
-	} else {
-		token, err := retrieveTokenWithRetry(oc.oauthConfig, oc.context, oc.username, oc.password)
-		if err != nil {
-			return nil, err
-		}
+	return targetURL, nil
+}
 
-		client = oc.oauthConfig.Client(oc.context, token)
+func (oc OAuthClient) cacheUser() string {
+	if oc.oauthConfigCC.ClientID != "" {
+		return "client:" + oc.oauthConfigCC.ClientID
 	}
 
-	client.Timeout = oc.timeout
+	return oc.username
+}
 
-	if oc.jar != nil {
-		client.Jar = oc.jar
-	}
+func cacheTarget(targetURL *url.URL) string {
+	return fmt.Sprintf("%s://%s", targetURL.Scheme, targetURL.Host)
+}
 
-	request.URL.Scheme = targetURL.Scheme
-	request.URL.Host = targetURL.Host
+// token returns the token of the client while it is valid, and otherwise
+// loads a new one.
+func (oc OAuthClient) token(targetURL *url.URL) (*oauth2.Token, error) {
+	oc.current.Lock()
+	defer oc.current.Unlock()
 
-	// we only want to retry non-modifying actions
-	if request.Method == "GET" {
-		return httpResponseWithRetry(client, request)
+	if oc.current.token.Valid() {
+		return oc.current.token, nil
 	}
 
-	return client.Do(request)
+	token, err := oc.loadToken(targetURL)
+	if err != nil {
+		return nil, err
+	}
+
+	oc.current.token = token
+	return token, nil
 }
 
-func retrieveTokenWithRetry(config *oauth2.Config, ctx context.Context, username, password string) (*oauth2.Token, error) {
-	var token *oauth2.Token
-	var err error
+// loadToken returns the cached token when there is a valid one, or refreshes
+// it, before falling back to retrieving a new token with the credentials.
+func (oc OAuthClient) loadToken(targetURL *url.URL) (*oauth2.Token, error) {
+	if oc.tokenCache == nil {
+		return oc.newToken()
+	}
 
-	for i := 0; i < TOKEN_ATTEMPT_COUNT; i++ {
-		if i != 0 {
-			fmt.Fprintf(os.Stderr, "\nRetrying, attempt %d out of %d...\n", i+1, TOKEN_ATTEMPT_COUNT)
-		}
-		token, err = config.PasswordCredentialsToken(ctx, username, password)
-		if !canRetry(err) {
-			break
+	target := cacheTarget(targetURL)
+	store := oc.storeTokens
+
+	cached, found, err := oc.tokenCache.Load(target, oc.cacheUser())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ignoring cached token: %s\n", err)
+	}
+
+	if found {
+		if cached.Valid() {
+			return cached, nil
 		}
 
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "token could not be retrieved from target url: %s.\n", err)
-		} else {
-			break
+		store = true
+
+		if cached.RefreshToken != "" && oc.oauthConfigCC.ClientID == "" {
+			refreshed, err := oc.oauthConfig.TokenSource(oc.context, cached).Token()
+			if err == nil {
+				return refreshed, oc.tokenCache.Store(target, oc.cacheUser(), refreshed)
+			}
 		}
 	}
 
+	token, err := oc.newToken()
 	if err != nil {
-		return nil, fmt.Errorf("token could not be retrieved from target url: %s", err)
+		return nil, err
+	}
+
+	if store {
+		err = oc.tokenCache.Store(target, oc.cacheUser(), token)
+		if err != nil {
+			return nil, err
+		}
 	}
+
 	return token, nil
 }
 
-func httpResponseWithRetry(client *http.Client, request *http.Request) (*http.Response, error) {
-retry:
-	resp, err := client.Do(request)
-	if client.Timeout == 0 {
-		if canRetry(err) {
-			goto retry
+func (oc OAuthClient) newToken() (*oauth2.Token, error) {
+	var token *oauth2.Token
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		if oc.oauthConfigCC.ClientID != "" {
+			token, err = oc.oauthConfigCC.Token(oc.context)
+		} else {
+			token, err = oc.oauthConfig.PasswordCredentialsToken(oc.context, oc.username, oc.password)
 		}
-	}
 
-	if err != nil {
-		return nil, err
+		if err == nil {
+			return token, nil
+		}
+
+		if attempt > oc.maxRetries || !canRetryToken(err) {
+			tokenErr := TokenError{Err: fmt.Errorf("token could not be retrieved from target url: %w", err)}
+			if retrieveErr, ok := err.(*oauth2.RetrieveError); ok && retrieveErr.Response != nil {
+				tokenErr.StatusCode = retrieveErr.Response.StatusCode
+			}
+
+			return nil, tokenErr
+		}
+
+		fmt.Fprintf(os.Stderr, "token could not be retrieved from target url: %s.\n", err)
+		fmt.Fprintf(os.Stderr, "\nRetrying, attempt %d out of %d...\n", attempt+1, oc.maxRetries+1)
+		sleep(retryDelay(oc.backoff, attempt))
 	}
+}
 
-	return resp, nil
+// TokenError is returned when UAA does not issue a token. StatusCode is
+// the status of the response of UAA, or 0 when it did not respond.
+type TokenError struct {
+	StatusCode int
+	Err        error
 }
 
-func canRetry(err error) bool {
-	if err != nil {
-		if ne, ok := err.(net.Error); ok {
-			if ne.Temporary() {
-				return true
-			}
-		}
+func (e TokenError) Error() string {
+	return e.Err.Error()
+}
 
-		if err == io.EOF {
-			return true
-		}
+func (e TokenError) Unwrap() error {
+	return e.Err
+}
 
-		return false
+func canRetryToken(err error) bool {
+	if retrieveErr, ok := err.(*oauth2.RetrieveError); ok && retrieveErr.Response != nil {
+		return shouldRetry(retrieveErr.Response, nil)
 	}
 
-	return false
+	return canRetry(err)
 }
@@ -3,13 +3,18 @@ package network_test
 import (
 	"bufio"
 	"bytes"
+	"crypto/tls"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/pivotal-cf/om/network"
+	"golang.org/x/oauth2"
 
 	"time"
 
@@ -38,8 +43,19 @@ var _ = Describe("OAuthClient", func() {
 
 				w.Header().Set("Content-Type", "application/json")
 
+				if req.FormValue("grant_type") == "refresh_token" {
+					w.Write([]byte(`{
+						"access_token": "some-refreshed-token",
+						"refresh_token": "some-new-refresh-token",
+						"token_type": "bearer",
+						"expires_in": 3600
+						}`))
+					return
+				}
+
 				w.Write([]byte(`{
 					"access_token": "some-opsman-token",
+					"refresh_token": "some-refresh-token",
 					"token_type": "bearer",
 					"expires_in": 3600
 					}`))
@@ -61,7 +77,7 @@ var _ = Describe("OAuthClient", func() {
 
 	Describe("Do", func() {
 		It("makes a request with authentication", func() {
-			client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+			client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", &tls.Config{InsecureSkipVerify: true}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(callCount).To(Equal(0))
@@ -95,7 +111,7 @@ var _ = Describe("OAuthClient", func() {
 		})
 
 		It("makes a request with client credentials", func() {
-			client, err := network.NewOAuthClient(server.URL, "", "", "client_id", "client_secret", true, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+			client, err := network.NewOAuthClient(server.URL, "", "", "client_id", "client_secret", &tls.Config{InsecureSkipVerify: true}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(callCount).To(Equal(0))
@@ -126,6 +142,22 @@ var _ = Describe("OAuthClient", func() {
 			}))
 		})
 
+		It("retrieves the token once for all the requests of the client", func() {
+			client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", &tls.Config{InsecureSkipVerify: true}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := 0; i < 5; i++ {
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.WithRetries(1, 0).Do(req)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(callCount).To(Equal(1))
+			Expect(authHeader).To(Equal("Bearer some-opsman-token"))
+		})
+
 		Context("when passing a url with no scheme", func() {
 			It("defaults to HTTPS", func() {
 				noScheme, err := url.Parse(server.URL)
@@ -134,7 +166,7 @@ var _ = Describe("OAuthClient", func() {
 				noScheme.Scheme = ""
 				finalURL := noScheme.String()
 
-				client, err := network.NewOAuthClient(finalURL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+				client, err := network.NewOAuthClient(finalURL, "opsman-username", "opsman-password", "", "", &tls.Config{InsecureSkipVerify: true}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
 				Expect(err).NotTo(HaveOccurred())
 
 				req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -150,7 +182,7 @@ var _ = Describe("OAuthClient", func() {
 		Context("when insecureSkipVerify is configured", func() {
 			Context("when it is set to false", func() {
 				It("throws an error for invalid certificates", func() {
-					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", false, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", &tls.Config{}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -163,7 +195,7 @@ var _ = Describe("OAuthClient", func() {
 
 			Context("when it is set to true", func() {
 				It("does not verify certificates", func() {
-					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", &tls.Config{InsecureSkipVerify: true}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -178,7 +210,7 @@ var _ = Describe("OAuthClient", func() {
 		Context("when includeCookies is configured", func() {
 			Context("when it is set to true", func() {
 				It("has a cookie jar", func() {
-					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, true, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", &tls.Config{InsecureSkipVerify: true}, true, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -200,7 +232,7 @@ var _ = Describe("OAuthClient", func() {
 
 			Context("when it is false", func() {
 				It("does not collect any of the cookies", func() {
-					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", true, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+					client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", &tls.Config{InsecureSkipVerify: true}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -220,6 +252,128 @@ var _ = Describe("OAuthClient", func() {
 			})
 		})
 
+		Context("when a token cache is configured", func() {
+			var (
+				cacheDir string
+				cache    network.TokenCache
+			)
+
+			newClient := func(store bool) network.OAuthClient {
+				client, err := network.NewOAuthClient(server.URL, "opsman-username", "opsman-password", "", "", &tls.Config{InsecureSkipVerify: true}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+				Expect(err).NotTo(HaveOccurred())
+				return client.WithTokenCache(cache, store)
+			}
+
+			doRequest := func(client network.OAuthClient) {
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			cacheFiles := func() []string {
+				files, err := filepath.Glob(filepath.Join(cacheDir, "tokens", "*.json"))
+				Expect(err).NotTo(HaveOccurred())
+				return files
+			}
+
+			BeforeEach(func() {
+				var err error
+				cacheDir, err = ioutil.TempDir("", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				cache = network.NewTokenCache(filepath.Join(cacheDir, "tokens"))
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(cacheDir)
+			})
+
+			It("reuses the token across clients", func() {
+				doRequest(newClient(true))
+				doRequest(newClient(true))
+				doRequest(newClient(false))
+
+				Expect(callCount).To(Equal(1))
+				Expect(authHeader).To(Equal("Bearer some-opsman-token"))
+
+				info, err := os.Stat(filepath.Join(cacheDir, "tokens"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0700)))
+
+				Expect(cacheFiles()).To(HaveLen(1))
+				info, err = os.Stat(cacheFiles()[0])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+			})
+
+			It("does not add tokens to the cache unless asked to", func() {
+				doRequest(newClient(false))
+				doRequest(newClient(false))
+
+				Expect(callCount).To(Equal(2))
+				Expect(cacheFiles()).To(BeEmpty())
+			})
+
+			It("refreshes an expired token", func() {
+				err := cache.Store(server.URL, "opsman-username", &oauth2.Token{
+					AccessToken:  "some-expired-token",
+					RefreshToken: "some-refresh-token",
+					TokenType:    "bearer",
+					Expiry:       time.Now().Add(-time.Minute),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				doRequest(newClient(false))
+
+				Expect(callCount).To(Equal(1))
+				Expect(authHeader).To(Equal("Bearer some-refreshed-token"))
+
+				req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(receivedRequest)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.ParseForm()).To(Succeed())
+				Expect(req.Form.Get("grant_type")).To(Equal("refresh_token"))
+				Expect(req.Form.Get("refresh_token")).To(Equal("some-refresh-token"))
+
+				token, found, err := cache.Load(server.URL, "opsman-username")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(token.AccessToken).To(Equal("some-refreshed-token"))
+				Expect(token.RefreshToken).To(Equal("some-new-refresh-token"))
+			})
+
+			It("ignores cache files that other users can read", func() {
+				doRequest(newClient(true))
+				Expect(os.Chmod(cacheFiles()[0], 0644)).To(Succeed())
+
+				doRequest(newClient(false))
+				Expect(callCount).To(Equal(2))
+			})
+
+			Describe("Login and Logout", func() {
+				It("adds the token to and removes it from the cache", func() {
+					client := newClient(false)
+
+					err := client.Login()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(callCount).To(Equal(1))
+
+					doRequest(client)
+					Expect(callCount).To(Equal(1))
+
+					found, err := client.Logout()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(cacheFiles()).To(BeEmpty())
+
+					found, err = client.Logout()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when an error occurs", func() {
 			Context("when the initial token cannot be retrieved", func() {
 				var badServer *httptest.Server
@@ -231,7 +385,7 @@ var _ = Describe("OAuthClient", func() {
 				})
 
 				It("returns an error", func() {
-					client, err := network.NewOAuthClient(badServer.URL, "username", "password", "", "", true, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+					client, err := network.NewOAuthClient(badServer.URL, "username", "password", "", "", &tls.Config{InsecureSkipVerify: true}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -242,9 +396,67 @@ var _ = Describe("OAuthClient", func() {
 				})
 			})
 
+			Context("when the token endpoint is temporarily unavailable", func() {
+				var (
+					flakyServer *httptest.Server
+					attempts    int
+				)
+
+				BeforeEach(func() {
+					attempts = 0
+					flakyServer = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+						if req.URL.Path == "/uaa/oauth/token" {
+							attempts++
+							if attempts < 3 {
+								w.WriteHeader(http.StatusBadGateway)
+								return
+							}
+
+							w.Header().Set("Content-Type", "application/json")
+							w.Write([]byte(`{"access_token": "some-opsman-token", "token_type": "bearer", "expires_in": 3600}`))
+							return
+						}
+
+						w.WriteHeader(http.StatusNoContent)
+					}))
+
+					network.SetSleep(func(time.Duration) {})
+				})
+
+				AfterEach(func() {
+					network.ResetSleep()
+					flakyServer.Close()
+				})
+
+				It("retries retrieving the token", func() {
+					client, err := network.NewOAuthClient(flakyServer.URL, "username", "password", "", "", &tls.Config{InsecureSkipVerify: true}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+					Expect(err).NotTo(HaveOccurred())
+
+					req, err := http.NewRequest("GET", "/some/path", nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					resp, err := client.WithRetries(2, time.Second).Do(req)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(attempts).To(Equal(3))
+				})
+
+				It("gives up after the configured number of retries", func() {
+					client, err := network.NewOAuthClient(flakyServer.URL, "username", "password", "", "", &tls.Config{InsecureSkipVerify: true}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+					Expect(err).NotTo(HaveOccurred())
+
+					req, err := http.NewRequest("GET", "/some/path", nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = client.WithRetries(1, time.Second).Do(req)
+					Expect(err).To(MatchError(ContainSubstring("token could not be retrieved from target url: oauth2: cannot fetch token: 502")))
+					Expect(attempts).To(Equal(2))
+				})
+			})
+
 			Context("when the target url is empty", func() {
 				It("returns an error", func() {
-					client, err := network.NewOAuthClient("", "username", "password", "", "", false, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+					client, err := network.NewOAuthClient("", "username", "password", "", "", &tls.Config{}, false, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
 					Expect(err).NotTo(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -0,0 +1,195 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var sensitiveHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+}
+
+// sensitiveJSONString matches string values of sensitive keys in bodies that
+// cannot be parsed, such as chunked or truncated ones.
+var sensitiveJSONString = regexp.MustCompile(`(?i)("[^"]*(?:password|passphrase|secret|private_key|token)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+var sensitiveKeyParts = []string{
+	"password",
+	"passphrase",
+	"secret",
+	"private_key",
+	"token",
+}
+
+// redactDump replaces the values of credential headers, and of the
+// passwords, passphrases, secrets, tokens and credentials in a JSON or form
+// encoded body, of a dumped request or response.
+func redactDump(dump []byte) []byte {
+	separator := []byte("\r\n\r\n")
+
+	var head, body []byte
+	if i := bytes.Index(dump, separator); i >= 0 {
+		head, body = dump[:i], dump[i+len(separator):]
+	} else {
+		head = dump
+	}
+
+	lines := strings.Split(string(head), "\r\n")
+	formEncoded := false
+	for i, line := range lines {
+		if i == 0 {
+			continue
+		}
+
+		colon := strings.Index(line, ":")
+		if colon < 0 {
+			continue
+		}
+
+		name := strings.ToLower(strings.TrimSpace(line[:colon]))
+		if sensitiveHeaders[name] {
+			lines[i] = line[:colon] + ": " + redacted
+		}
+		if name == "content-type" && strings.Contains(line[colon:], "application/x-www-form-urlencoded") {
+			formEncoded = true
+		}
+	}
+
+	output := []byte(strings.Join(lines, "\r\n"))
+	if body == nil {
+		return output
+	}
+
+	if formEncoded {
+		body = redactForm(body)
+	} else {
+		body = redactJSON(body)
+	}
+
+	return append(append(output, separator...), body...)
+}
+
+func redactJSON(body []byte) []byte {
+	var document interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(body), &document); err != nil {
+		return sensitiveJSONString.ReplaceAllFunc(body, func(match []byte) []byte {
+			key := sensitiveJSONString.FindSubmatch(match)[1]
+			if bytes.Contains(bytes.ToLower(key), []byte(`"token_type"`)) {
+				return match
+			}
+			return []byte(string(key) + `"` + redacted + `"`)
+		})
+	}
+
+	if !redactValue(document) {
+		return body
+	}
+
+	contents, err := json.Marshal(document)
+	if err != nil {
+		return body // un-tested
+	}
+
+	return contents
+}
+
+// redactValue redacts the scalar values of sensitive keys of every map in
+// the document, and the values of credentials, in place. It reports whether anything was
+// redacted.
+func redactValue(value interface{}) bool {
+	changed := false
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if isScalar(child) && isSensitiveKey(key) {
+				v[key] = redacted
+				changed = true
+				continue
+			}
+
+			if key == "credential" {
+				if credential, ok := child.(map[string]interface{}); ok {
+					if _, ok := credential["value"]; ok {
+						credential["value"] = redacted
+						changed = true
+						continue
+					}
+				}
+			}
+
+			if redactValue(child) {
+				changed = true
+			}
+		}
+
+		// properties that are credentials are marked with "credential": true
+		if isCredential, ok := v["credential"].(bool); ok && isCredential {
+			if child, ok := v["value"]; ok && child != nil {
+				v["value"] = redacted
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if redactValue(child) {
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
+
+func redactForm(body []byte) []byte {
+	pairs := strings.Split(string(body), "&")
+	for i, pair := range pairs {
+		key := pair
+		if equals := strings.Index(pair, "="); equals >= 0 {
+			key = pair[:equals]
+		}
+
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			continue
+		}
+
+		if isSensitiveKey(name) {
+			pairs[i] = key + "=" + url.QueryEscape(redacted)
+		}
+	}
+
+	return []byte(strings.Join(pairs, "&"))
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, float64, bool:
+		return true
+	}
+
+	return false
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if key == "token_type" {
+		return false
+	}
+
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+
+	return false
+}
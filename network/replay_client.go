@@ -0,0 +1,153 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ReplayClient serves the responses recorded in a trace file instead of
+// making requests. Requests are matched by method, path and query, and the
+// responses for the same request are served in the order they were recorded,
+// with the last one served again once they run out, so that polling ends.
+type ReplayClient struct {
+	entries map[string][]TraceEntry
+	mutex   sync.Mutex
+}
+
+func NewReplayClient(path string) (*ReplayClient, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read trace file: %w", err)
+	}
+
+	entries, err := parseTraceFile(contents)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse trace file %s: %w", path, err)
+	}
+
+	client := &ReplayClient{
+		entries: map[string][]TraceEntry{},
+	}
+
+	for _, entry := range entries {
+		requestURL, err := url.Parse(entry.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse trace file %s: %w", path, err)
+		}
+
+		key := replayKey(entry.Request.Method, requestURL)
+		client.entries[key] = append(client.entries[key], entry)
+	}
+
+	return client, nil
+}
+
+func (c *ReplayClient) Do(request *http.Request) (*http.Response, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := replayKey(request.Method, request.URL)
+
+	entries := c.entries[key]
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no recorded response for %s", key)
+	}
+
+	entry := entries[0]
+	if len(entries) > 1 {
+		c.entries[key] = entries[1:]
+	}
+
+	if request.Body != nil {
+		request.Body.Close()
+	}
+
+	if entry.Response == nil {
+		return nil, errors.New(entry.Error)
+	}
+
+	// an empty body would be taken for the actual response
+	if entry.Response.BodyTruncated {
+		return nil, fmt.Errorf("the recorded response for %s has no body, as it was too large to be recorded", key)
+	}
+
+	body, err := decodeBody(entry.Response.Body, entry.Response.BodyEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode recorded response for %s: %w", key, err)
+	}
+
+	header := entry.Response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.Response.StatusCode, http.StatusText(entry.Response.StatusCode)),
+		StatusCode:    entry.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
+
+func replayKey(method string, requestURL *url.URL) string {
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	key := method + " " + requestURL.EscapedPath()
+	if requestURL.RawQuery != "" {
+		key += "?" + requestURL.RawQuery
+	}
+
+	return key
+}
+
+func parseTraceFile(contents []byte) ([]TraceEntry, error) {
+	trimmed := bytes.TrimSpace(contents)
+
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var har harFile
+		err := json.Unmarshal(trimmed, &har)
+		if err == nil && har.Log.Version != "" {
+			return fromHAR(har), nil
+		}
+	}
+
+	var entries []TraceEntry
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	// the recorded bodies may be escaped or base64 encoded
+	scanner.Buffer(make([]byte, 64*1024), 8*maxRecordedBodySize)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var entry TraceEntry
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,299 @@
+package network_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/om/network"
+	"github.com/pivotal-cf/om/network/fakes"
+)
+
+var _ = Describe("Trace files", func() {
+	var (
+		tempDir    string
+		fakeClient *fakes.HttpClient
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "trace")
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeClient = &fakes.HttpClient{}
+		fakeClient.DoStub = func(request *http.Request) (*http.Response, error) {
+			body := `{"status": "running"}`
+			if fakeClient.DoCallCount() > 2 {
+				body = `{"status": "succeeded"}`
+			}
+
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				Header:        http.Header{"Content-Type": []string{"application/json"}},
+				Body:          ioutil.NopCloser(strings.NewReader(body)),
+				ContentLength: int64(len(body)),
+			}, nil
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	record := func(path string, redact bool) {
+		recorder, err := network.NewTraceRecorder(path, "1.2.3")
+		Expect(err).NotTo(HaveOccurred())
+
+		traceClient := network.NewTraceClient(fakeClient, nil, redact).WithRecorder(recorder)
+
+		request, err := http.NewRequest("PUT", "/api/v0/staged/director/properties", strings.NewReader(`{"password": "some-password"}`))
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set("Authorization", "Bearer some-token")
+		_, err = traceClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 2; i++ {
+			request, err = http.NewRequest("GET", "/api/v0/installations/1", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err := traceClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			// the response body can still be read after it has been recorded
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).NotTo(BeEmpty())
+		}
+	}
+
+	replay := func(client *network.ReplayClient, method, path string) (int, string) {
+		request, err := http.NewRequest(method, path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return response.StatusCode, string(body)
+	}
+
+	It("writes JSON lines, with credentials redacted", func() {
+		path := filepath.Join(tempDir, "trace.jsonl")
+		record(path, true)
+
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+		Expect(lines).To(HaveLen(3))
+
+		var entry network.TraceEntry
+		Expect(json.Unmarshal([]byte(lines[0]), &entry)).To(Succeed())
+		Expect(entry.Request.Method).To(Equal("PUT"))
+		Expect(entry.Request.URL).To(Equal("/api/v0/staged/director/properties"))
+		Expect(entry.Request.Header.Get("Authorization")).To(Equal("[REDACTED]"))
+		Expect(entry.Request.Body).To(Equal(`{"password":"[REDACTED]"}`))
+		Expect(entry.Response.StatusCode).To(Equal(http.StatusOK))
+		Expect(entry.Response.Body).To(Equal(`{"status": "running"}`))
+	})
+
+	It("writes HAR files", func() {
+		path := filepath.Join(tempDir, "trace.har")
+		record(path, false)
+
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		var har struct {
+			Log struct {
+				Version string
+				Creator struct{ Name, Version string }
+				Entries []struct {
+					Request struct {
+						Method   string
+						URL      string
+						PostData struct{ Text string }
+					}
+					Response struct {
+						Status  int
+						Content struct{ Text string }
+					}
+				}
+			}
+		}
+		Expect(json.Unmarshal(contents, &har)).To(Succeed())
+
+		Expect(har.Log.Version).To(Equal("1.2"))
+		Expect(har.Log.Creator.Name).To(Equal("om"))
+		Expect(har.Log.Creator.Version).To(Equal("1.2.3"))
+		Expect(har.Log.Entries).To(HaveLen(3))
+		Expect(har.Log.Entries[0].Request.Method).To(Equal("PUT"))
+		Expect(har.Log.Entries[0].Request.PostData.Text).To(Equal(`{"password": "some-password"}`))
+		Expect(har.Log.Entries[2].Request.URL).To(Equal("/api/v0/installations/1"))
+		Expect(har.Log.Entries[2].Response.Status).To(Equal(http.StatusOK))
+		Expect(har.Log.Entries[2].Response.Content.Text).To(Equal(`{"status": "succeeded"}`))
+	})
+
+	It("keeps the HAR file complete after every request", func() {
+		path := filepath.Join(tempDir, "trace.har")
+		recorder, err := network.NewTraceRecorder(path, "1.2.3")
+		Expect(err).NotTo(HaveOccurred())
+
+		entries := func() []interface{} {
+			contents, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			var har struct {
+				Log struct {
+					Entries []interface{}
+				}
+			}
+			Expect(json.Unmarshal(contents, &har)).To(Succeed())
+			return har.Log.Entries
+		}
+
+		Expect(entries()).To(BeEmpty())
+
+		for i := 1; i <= 3; i++ {
+			err = recorder.Record(network.TraceEntry{Request: network.TraceRequest{Method: "GET", URL: "/api/v0/installations/1"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries()).To(HaveLen(i))
+		}
+	})
+
+	for _, extension := range []string{"jsonl", "har"} {
+		extension := extension
+
+		It("replays the responses recorded in a "+extension+" file in order", func() {
+			path := filepath.Join(tempDir, "trace."+extension)
+			record(path, true)
+
+			client, err := network.NewReplayClient(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			status, body := replay(client, "PUT", "/api/v0/staged/director/properties")
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(Equal(`{"status": "running"}`))
+
+			_, body = replay(client, "GET", "/api/v0/installations/1")
+			Expect(body).To(Equal(`{"status": "running"}`))
+
+			_, body = replay(client, "GET", "/api/v0/installations/1")
+			Expect(body).To(Equal(`{"status": "succeeded"}`))
+
+			_, body = replay(client, "GET", "/api/v0/installations/1")
+			Expect(body).To(Equal(`{"status": "succeeded"}`))
+		})
+	}
+
+	It("records and replays response bodies that are too large to be dumped", func() {
+		largeBody := strings.Repeat("a", 2*1024*1024)
+		fakeClient.DoStub = nil
+		fakeClient.DoReturns(&http.Response{
+			StatusCode:    http.StatusOK,
+			Body:          ioutil.NopCloser(strings.NewReader(largeBody)),
+			ContentLength: int64(len(largeBody)),
+		}, nil)
+
+		path := filepath.Join(tempDir, "trace.jsonl")
+		recorder, err := network.NewTraceRecorder(path, "1.2.3")
+		Expect(err).NotTo(HaveOccurred())
+
+		request, err := http.NewRequest("GET", "/api/v0/staged/products/some-guid/manifest", nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = network.NewTraceClient(fakeClient, nil, false).WithRecorder(recorder).Do(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err := network.NewReplayClient(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, body := replay(client, "GET", "/api/v0/staged/products/some-guid/manifest")
+		Expect(body).To(Equal(largeBody))
+	})
+
+	for _, extension := range []string{"jsonl", "har"} {
+		extension := extension
+
+		It("records that a body was too large to be recorded in a "+extension+" file", func() {
+			fakeClient.DoStub = nil
+			fakeClient.DoReturns(&http.Response{
+				StatusCode:    http.StatusOK,
+				Body:          ioutil.NopCloser(strings.NewReader("some-installation")),
+				ContentLength: 1024 * 1024 * 1024,
+			}, nil)
+
+			path := filepath.Join(tempDir, "trace."+extension)
+			recorder, err := network.NewTraceRecorder(path, "1.2.3")
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("GET", "/api/v0/installation_asset_collection", nil)
+			Expect(err).NotTo(HaveOccurred())
+			response, err := network.NewTraceClient(fakeClient, nil, false).WithRecorder(recorder).Do(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			// the body is left to the caller
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal("some-installation"))
+
+			client, err := network.NewReplayClient(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err = http.NewRequest("GET", "/api/v0/installation_asset_collection", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.Do(request)
+			Expect(err).To(MatchError("the recorded response for GET /api/v0/installation_asset_collection has no body, as it was too large to be recorded"))
+		})
+	}
+
+	Context("failure cases", func() {
+		It("returns an error for requests that were not recorded", func() {
+			path := filepath.Join(tempDir, "trace.jsonl")
+			record(path, true)
+
+			client, err := network.NewReplayClient(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("GET", "/api/v0/info", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.Do(request)
+			Expect(err).To(MatchError("no recorded response for GET /api/v0/info"))
+		})
+
+		It("returns the recorded error of a request", func() {
+			path := filepath.Join(tempDir, "trace.jsonl")
+			Expect(ioutil.WriteFile(path, []byte(`{"request": {"method": "GET", "url": "/api/v0/info"}, "error": "connection reset by peer"}`), 0600)).To(Succeed())
+
+			client, err := network.NewReplayClient(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("GET", "/api/v0/info", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.Do(request)
+			Expect(err).To(MatchError("connection reset by peer"))
+		})
+
+		It("returns an error when the trace file cannot be parsed", func() {
+			path := filepath.Join(tempDir, "trace.jsonl")
+			Expect(ioutil.WriteFile(path, []byte("{}\nnot-json\n"), 0600)).To(Succeed())
+
+			_, err := network.NewReplayClient(path)
+			Expect(err).To(MatchError(ContainSubstring("could not parse trace file " + path + ": line 2:")))
+		})
+
+		It("returns an error when the trace file cannot be read", func() {
+			_, err := network.NewReplayClient(filepath.Join(tempDir, "missing.jsonl"))
+			Expect(err).To(MatchError(ContainSubstring("could not read trace file")))
+		})
+	})
+})
@@ -0,0 +1,131 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const maxRetryDelay = time.Minute
+
+var sleep = time.Sleep
+
+// RetryClient retries idempotent requests that fail with a connection error
+// or with a 502, 503 or 504 from a load balancer in front of Ops Manager. The
+// delay between attempts doubles each time, starting at backoff, and is
+// randomized so that parallel jobs do not retry in lockstep.
+type RetryClient struct {
+	client     httpClient
+	maxRetries int
+	backoff    time.Duration
+	stderr     io.Writer
+}
+
+func NewRetryClient(client httpClient, maxRetries int, backoff time.Duration, stderr io.Writer) RetryClient {
+	return RetryClient{
+		client:     client,
+		maxRetries: maxRetries,
+		backoff:    backoff,
+		stderr:     stderr,
+	}
+}
+
+func (c RetryClient) Do(request *http.Request) (*http.Response, error) {
+	retryable := isIdempotent(request.Method) && (request.Body == nil || request.Body == http.NoBody || request.GetBody != nil)
+
+	for attempt := 1; ; attempt++ {
+		response, err := c.client.Do(request)
+		if !retryable || attempt > c.maxRetries || !shouldRetry(response, err) {
+			return response, err
+		}
+
+		if err != nil {
+			fmt.Fprintf(c.stderr, "%s %s failed: %s\n", request.Method, request.URL, err)
+		} else {
+			fmt.Fprintf(c.stderr, "%s %s failed: %s\n", request.Method, request.URL, response.Status)
+			io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		if request.GetBody != nil {
+			request.Body, err = request.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("could not rewind request body to retry: %w", err)
+			}
+		}
+
+		fmt.Fprintf(c.stderr, "Retrying, attempt %d out of %d...\n", attempt+1, c.maxRetries+1)
+		sleep(retryDelay(c.backoff, attempt))
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func shouldRetry(response *http.Response, err error) bool {
+	if err != nil {
+		return canRetry(err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// retryDelay returns a random delay between half and all of backoff doubled
+// for every previous attempt.
+func retryDelay(backoff time.Duration, attempt int) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+
+	delay := backoff
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func canRetry(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// the OAuth client has already retried to retrieve the token
+	var tokenErr TokenError
+	if errors.As(err, &tokenErr) {
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	// a timed out request is not retried, as every attempt could take as long
+	// as the request timeout
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Temporary() && !ne.Timeout() {
+		return true
+	}
+
+	return false
+}
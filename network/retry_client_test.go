@@ -0,0 +1,190 @@
+package network_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-cf/om/network"
+	"github.com/pivotal-cf/om/network/fakes"
+)
+
+var _ = Describe("RetryClient", func() {
+	var (
+		fakeClient *fakes.HttpClient
+		stderr     *gbytes.Buffer
+		delays     []time.Duration
+		client     network.RetryClient
+	)
+
+	response := func(statusCode int) *http.Response {
+		return &http.Response{
+			StatusCode: statusCode,
+			Status:     http.StatusText(statusCode),
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}
+	}
+
+	BeforeEach(func() {
+		fakeClient = &fakes.HttpClient{}
+		stderr = gbytes.NewBuffer()
+		delays = nil
+		network.SetSleep(func(d time.Duration) {
+			delays = append(delays, d)
+		})
+
+		client = network.NewRetryClient(fakeClient, 3, time.Second, stderr)
+	})
+
+	AfterEach(func() {
+		network.ResetSleep()
+	})
+
+	It("returns the response when the request succeeds", func() {
+		fakeClient.DoReturns(response(http.StatusOK), nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+		Expect(delays).To(BeEmpty())
+	})
+
+	It("retries 502, 503 and 504 responses with an exponential backoff", func() {
+		fakeClient.DoReturnsOnCall(0, response(http.StatusBadGateway), nil)
+		fakeClient.DoReturnsOnCall(1, response(http.StatusServiceUnavailable), nil)
+		fakeClient.DoReturnsOnCall(2, response(http.StatusGatewayTimeout), nil)
+		fakeClient.DoReturnsOnCall(3, response(http.StatusOK), nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(fakeClient.DoCallCount()).To(Equal(4))
+
+		Expect(delays).To(HaveLen(3))
+		Expect(delays[0]).To(BeNumerically("~", 750*time.Millisecond, 250*time.Millisecond))
+		Expect(delays[1]).To(BeNumerically("~", 1500*time.Millisecond, 500*time.Millisecond))
+		Expect(delays[2]).To(BeNumerically("~", 3*time.Second, time.Second))
+
+		Expect(stderr).To(gbytes.Say("GET /api/v0/info failed: Bad Gateway"))
+		Expect(stderr).To(gbytes.Say("Retrying, attempt 2 out of 4..."))
+	})
+
+	It("retries connection resets", func() {
+		fakeClient.DoReturnsOnCall(0, nil, syscall.ECONNRESET)
+		fakeClient.DoReturnsOnCall(1, nil, io.EOF)
+		fakeClient.DoReturnsOnCall(2, response(http.StatusOK), nil)
+
+		request, err := http.NewRequest("DELETE", "/api/v0/some-resource", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.DoCallCount()).To(Equal(3))
+	})
+
+	It("rewinds the body of the request before retrying", func() {
+		var bodies []string
+		fakeClient.DoStub = func(request *http.Request) (*http.Response, error) {
+			body, err := ioutil.ReadAll(request.Body)
+			Expect(err).NotTo(HaveOccurred())
+			bodies = append(bodies, string(body))
+
+			if len(bodies) == 1 {
+				return response(http.StatusBadGateway), nil
+			}
+			return response(http.StatusOK), nil
+		}
+
+		request, err := http.NewRequest("PUT", "/api/v0/some-resource", bytes.NewBufferString("some-body"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bodies).To(Equal([]string{"some-body", "some-body"}))
+	})
+
+	It("returns the last response when it runs out of retries", func() {
+		fakeClient.DoReturns(response(http.StatusBadGateway), nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusBadGateway))
+		Expect(fakeClient.DoCallCount()).To(Equal(4))
+	})
+
+	It("does not retry requests that are not idempotent", func() {
+		fakeClient.DoReturns(response(http.StatusBadGateway), nil)
+
+		request, err := http.NewRequest("POST", "/api/v0/installations", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusBadGateway))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("does not retry requests with a body that cannot be rewound", func() {
+		fakeClient.DoReturns(nil, syscall.ECONNRESET)
+
+		request, err := http.NewRequest("PUT", "/api/v0/some-resource", ioutil.NopCloser(strings.NewReader("some-body")))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Do(request)
+		Expect(err).To(MatchError(syscall.ECONNRESET))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("does not retry other errors", func() {
+		fakeClient.DoReturns(nil, errors.New("some error"))
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Do(request)
+		Expect(err).To(MatchError("some error"))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("does not retry token errors, which the OAuth client has already retried", func() {
+		fakeClient.DoReturns(nil, network.TokenError{Err: fmt.Errorf("token could not be retrieved from target url: %w", syscall.ECONNRESET)})
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Do(request)
+		Expect(err).To(MatchError("token could not be retrieved from target url: connection reset by peer"))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("does not retry when max retries is 0", func() {
+		client = network.NewRetryClient(fakeClient, 0, time.Second, stderr)
+		fakeClient.DoReturns(response(http.StatusServiceUnavailable), nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+})
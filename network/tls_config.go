@@ -0,0 +1,70 @@
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// NewTLSConfig returns the TLS configuration shared by the clients. caCert
+// adds certificates to the system trust pool, and clientCert and clientKey
+// configure a certificate for mutual TLS. Each of them can be either a path
+// to a file or the PEM encoded contents.
+func NewTLSConfig(insecureSkipVerify bool, caCert, clientCert, clientKey string) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+
+	if caCert != "" {
+		contents, err := readPEM(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("could not read ca cert: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(contents) {
+			return nil, fmt.Errorf("could not read ca cert: no PEM encoded certificates found")
+		}
+
+		config.RootCAs = pool
+	}
+
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" || clientKey == "" {
+			return nil, fmt.Errorf("both a client certificate and a client key are required for mutual TLS")
+		}
+
+		certContents, err := readPEM(clientCert)
+		if err != nil {
+			return nil, fmt.Errorf("could not read client certificate: %w", err)
+		}
+
+		keyContents, err := readPEM(clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not read client key: %w", err)
+		}
+
+		certificate, err := tls.X509KeyPair(certContents, keyContents)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	return ioutil.ReadFile(value)
+}
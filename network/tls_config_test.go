@@ -0,0 +1,167 @@
+package network_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/pivotal-cf/om/network"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func generateClientCertificate() (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "some-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	certificate, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM), certificate
+}
+
+var _ = Describe("NewTLSConfig", func() {
+	var (
+		server *httptest.Server
+		caCert string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	})
+
+	JustBeforeEach(func() {
+		server.StartTLS()
+		caCert = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	get := func(config *tls.Config) error {
+		client := network.NewUnauthenticatedClient(server.URL, config, time.Duration(30)*time.Second, time.Duration(5)*time.Second)
+
+		req, err := http.NewRequest("GET", "/some/path", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Do(req)
+		return err
+	}
+
+	It("only skips ssl validation when asked to", func() {
+		config, err := network.NewTLSConfig(false, "", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get(config)).To(MatchError(ContainSubstring("certificate signed by unknown authority")))
+
+		config, err = network.NewTLSConfig(true, "", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get(config)).To(Succeed())
+	})
+
+	It("trusts the ca cert when it is given as PEM", func() {
+		config, err := network.NewTLSConfig(false, caCert, "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get(config)).To(Succeed())
+	})
+
+	It("trusts the ca cert when it is given as a file", func() {
+		file, err := ioutil.TempFile("", "ca.pem")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.Remove(file.Name())
+
+		_, err = file.WriteString(caCert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		config, err := network.NewTLSConfig(false, file.Name(), "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get(config)).To(Succeed())
+	})
+
+	Context("when the server requires a client certificate", func() {
+		var clientCert, clientKey string
+
+		BeforeEach(func() {
+			var certificate *x509.Certificate
+			clientCert, clientKey, certificate = generateClientCertificate()
+
+			pool := x509.NewCertPool()
+			pool.AddCert(certificate)
+			server.TLS = &tls.Config{
+				ClientAuth: tls.RequireAndVerifyClientCert,
+				ClientCAs:  pool,
+			}
+		})
+
+		It("presents the client certificate", func() {
+			config, err := network.NewTLSConfig(false, caCert, clientCert, clientKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(get(config)).To(Succeed())
+
+			config, err = network.NewTLSConfig(false, caCert, "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(get(config)).NotTo(Succeed())
+		})
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the ca cert file cannot be read", func() {
+			_, err := network.NewTLSConfig(false, "/path/to/missing/ca.pem", "", "")
+			Expect(err).To(MatchError(ContainSubstring("could not read ca cert: open /path/to/missing/ca.pem")))
+		})
+
+		It("returns an error when the ca cert does not contain a certificate", func() {
+			_, err := network.NewTLSConfig(false, "-----BEGIN CERTIFICATE-----\nnot-a-cert\n-----END CERTIFICATE-----", "", "")
+			Expect(err).To(MatchError("could not read ca cert: no PEM encoded certificates found"))
+		})
+
+		It("returns an error when only the client certificate is given", func() {
+			clientCert, _, _ := generateClientCertificate()
+
+			_, err := network.NewTLSConfig(false, "", clientCert, "")
+			Expect(err).To(MatchError("both a client certificate and a client key are required for mutual TLS"))
+		})
+
+		It("returns an error when the client key does not match", func() {
+			clientCert, _, _ := generateClientCertificate()
+			_, clientKey, _ := generateClientCertificate()
+
+			_, err := network.NewTLSConfig(false, "", clientCert, clientKey)
+			Expect(err).To(MatchError(ContainSubstring("could not load client certificate")))
+		})
+	})
+})
@@ -0,0 +1,104 @@
+package network
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenCache keeps UAA tokens on disk, one file per target and user, so
+// that they can be reused across invocations of om. The directory and files
+// are only accessible by their owner.
+type TokenCache struct {
+	dir string
+}
+
+func NewTokenCache(dir string) TokenCache {
+	return TokenCache{dir: dir}
+}
+
+func (c TokenCache) path(target, user string) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%x.json", sha256.Sum256([]byte(target+"\x00"+user))))
+}
+
+// Load returns the cached token of the user for the target. Files that can
+// be read by other users are not trusted.
+func (c TokenCache) Load(target, user string) (*oauth2.Token, bool, error) {
+	path := c.path(target, user)
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("could not read token cache: %w", err)
+	}
+
+	if info.Mode().Perm()&0077 != 0 {
+		return nil, false, fmt.Errorf("token cache file %s is accessible by other users", path)
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not read token cache: %w", err)
+	}
+
+	var token oauth2.Token
+	err = json.Unmarshal(contents, &token)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not parse token cache file %s: %w", path, err)
+	}
+
+	return &token, true, nil
+}
+
+func (c TokenCache) Store(target, user string, token *oauth2.Token) error {
+	err := os.MkdirAll(c.dir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create token cache directory: %w", err)
+	}
+
+	contents, err := json.Marshal(token)
+	if err != nil {
+		return err // un-tested
+	}
+
+	// ioutil.TempFile creates the file with 0600
+	file, err := ioutil.TempFile(c.dir, ".token")
+	if err != nil {
+		return fmt.Errorf("could not write token cache: %w", err)
+	}
+
+	_, err = file.Write(contents)
+	file.Close()
+	if err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("could not write token cache: %w", err)
+	}
+
+	err = os.Rename(file.Name(), c.path(target, user))
+	if err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("could not write token cache: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the cached token and reports whether there was one.
+func (c TokenCache) Delete(target, user string) (bool, error) {
+	err := os.Remove(c.path(target, user))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not remove cached token: %w", err)
+	}
+
+	return true, nil
+}
@@ -1,47 +1,94 @@
 package network
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
+	"strings"
+	"time"
 )
 
 const maxBodySize = 1024 * 1024
 
+// maxRecordedBodySize is the size up to which bodies are recorded in a trace
+// file, so that, for example, staged manifests and installation logs can be
+// replayed, while exported installations are not held in memory. Larger
+// bodies are recorded as truncated.
+const maxRecordedBodySize = 64 * 1024 * 1024
+
 //go:generate counterfeiter -o ./fakes/httpclient.go --fake-name HttpClient . httpClient
 
 type httpClient interface {
 	Do(*http.Request) (*http.Response, error)
 }
 
+// TraceClient dumps every request and response to the writer, when there is
+// one, and records them with the recorder, when there is one. Unless redact
+// is false, credentials are replaced with [REDACTED] in both.
 type TraceClient struct {
-	client httpClient
-	writer io.Writer
+	client   httpClient
+	writer   io.Writer
+	redact   bool
+	recorder *TraceRecorder
 }
 
-func NewTraceClient(client httpClient, writer io.Writer) *TraceClient {
+func NewTraceClient(client httpClient, writer io.Writer, redact bool) *TraceClient {
 	return &TraceClient{
 		client: client,
 		writer: writer,
+		redact: redact,
 	}
 }
 
+// WithRecorder returns a copy of the client that also records every request
+// and response with the recorder.
+func (c *TraceClient) WithRecorder(recorder *TraceRecorder) *TraceClient {
+	client := *c
+	client.recorder = recorder
+	return &client
+}
+
 func (c *TraceClient) Do(request *http.Request) (*http.Response, error) {
 	dumpRequestBody := true
 	if request.ContentLength >= maxBodySize {
 		dumpRequestBody = false
 	}
 
-	requestOutput, err := httputil.DumpRequest(request, dumpRequestBody)
-	if err != nil {
-		return nil, err
+	hasRequestBody := request.Body != nil && request.Body != http.NoBody
+	readRequestBody := request.ContentLength < c.maxReadBodySize()
+
+	var requestBody []byte
+	if readRequestBody && hasRequestBody {
+		var err error
+		requestBody, err = ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		request.Body.Close()
+		request.Body = ioutil.NopCloser(bytes.NewReader(requestBody))
 	}
 
-	fmt.Fprintf(c.writer, "%s\n", string(requestOutput))
+	if c.writer != nil {
+		requestOutput, err := httputil.DumpRequest(request, dumpRequestBody)
+		if err != nil {
+			return nil, err
+		}
 
+		if c.redact {
+			requestOutput = redactDump(requestOutput)
+		}
+		fmt.Fprintf(c.writer, "%s\n", string(requestOutput))
+	}
+
+	recordedRequest := recordedBody{body: requestBody, truncated: hasRequestBody && !readRequestBody}
+
+	startedAt := time.Now()
 	response, err := c.client.Do(request)
 	if err != nil {
+		c.record(startedAt, request, recordedRequest, nil, recordedBody{}, err)
 		return nil, err
 	}
 
@@ -49,11 +96,113 @@ func (c *TraceClient) Do(request *http.Request) (*http.Response, error) {
 	if response.ContentLength >= maxBodySize {
 		dumpResponseBody = false
 	}
-	responseOutput, err := httputil.DumpResponse(response, dumpResponseBody)
-	if err != nil {
-		return nil, err
+
+	hasResponseBody := response.Body != nil && response.ContentLength != 0
+	readResponseBody := response.ContentLength < c.maxReadBodySize()
+
+	var responseBody []byte
+	if readResponseBody && response.Body != nil {
+		responseBody, err = ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		response.Body.Close()
+		response.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+	}
+
+	if c.writer != nil {
+		responseOutput, err := httputil.DumpResponse(response, dumpResponseBody)
+		if err != nil {
+			return nil, err
+		}
+		if c.redact {
+			responseOutput = redactDump(responseOutput)
+		}
+		fmt.Fprintf(c.writer, "%s\n", string(responseOutput))
 	}
-	fmt.Fprintf(c.writer, "%s\n", string(responseOutput))
+
+	c.record(startedAt, request, recordedRequest, response, recordedBody{body: responseBody, truncated: hasResponseBody && !readResponseBody}, nil)
 
 	return response, nil
 }
+
+// maxReadBodySize is the size up to which bodies are read, to be dumped to
+// the writer or recorded.
+func (c *TraceClient) maxReadBodySize() int64 {
+	if c.recorder != nil {
+		return maxRecordedBodySize
+	}
+
+	return maxBodySize
+}
+
+// recordedBody is a body as it is recorded, truncated when it was too large
+// to be read.
+type recordedBody struct {
+	body      []byte
+	truncated bool
+}
+
+func (c *TraceClient) record(startedAt time.Time, request *http.Request, requestBody recordedBody, response *http.Response, responseBody recordedBody, requestErr error) {
+	if c.recorder == nil {
+		return
+	}
+
+	entry := TraceEntry{
+		StartedAt: startedAt,
+		Duration:  float64(time.Since(startedAt)) / float64(time.Millisecond),
+		Request: TraceRequest{
+			Method: request.Method,
+			URL:    request.URL.String(),
+			Header: c.recordedHeader(request.Header),
+		},
+	}
+	entry.Request.Body, entry.Request.BodyEncoding = encodeBody(c.recordedBody(request.Header, requestBody.body))
+	entry.Request.BodyTruncated = requestBody.truncated
+
+	if requestErr != nil {
+		entry.Error = requestErr.Error()
+	}
+
+	if response != nil {
+		entry.Response = &TraceResponse{
+			StatusCode: response.StatusCode,
+			Header:     c.recordedHeader(response.Header),
+		}
+		entry.Response.Body, entry.Response.BodyEncoding = encodeBody(c.recordedBody(response.Header, responseBody.body))
+		entry.Response.BodyTruncated = responseBody.truncated
+	}
+
+	err := c.recorder.Record(entry)
+	if err != nil && c.writer != nil {
+		fmt.Fprintf(c.writer, "%s\n", err)
+	}
+}
+
+func (c *TraceClient) recordedHeader(header http.Header) http.Header {
+	if !c.redact {
+		return header
+	}
+
+	redactedHeader := http.Header{}
+	for name, values := range header {
+		if sensitiveHeaders[strings.ToLower(name)] {
+			values = []string{redacted}
+		}
+		redactedHeader[name] = values
+	}
+
+	return redactedHeader
+}
+
+func (c *TraceClient) recordedBody(header http.Header, body []byte) []byte {
+	if !c.redact || len(body) == 0 {
+		return body
+	}
+
+	if strings.Contains(header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return redactForm(body)
+	}
+
+	return redactJSON(body)
+}
@@ -38,7 +38,7 @@ var _ = Describe("Trace Client", func() {
 
 		out = gbytes.NewBuffer()
 
-		traceClient = network.NewTraceClient(fakeClient, out)
+		traceClient = network.NewTraceClient(fakeClient, out, true)
 	})
 
 	It("calls the underlying http client", func() {
@@ -109,4 +109,64 @@ var _ = Describe("Trace Client", func() {
 			Expect(out).NotTo(gbytes.Say("aaaaaaaaaaaa"))
 		})
 	})
+
+	Context("when redacting credentials", func() {
+		It("redacts credential headers and values in JSON bodies", func() {
+			var err error
+			request, err = http.NewRequest("PUT", "http://example.com/api/v0/staged/products/some-guid/properties", strings.NewReader(`{"properties":{".properties.some-secret":{"value":{"secret":"some-secret-value"}},".properties.some-string":{"value":"some-value"}}}`))
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Authorization", "Bearer some-token")
+
+			response.Body = ioutil.NopCloser(strings.NewReader(`{"credential":{"type":"simple_credentials","value":{"identity":"admin","password":"some-password"}}}`))
+
+			_, err = traceClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents := string(out.Contents())
+			Expect(contents).To(ContainSubstring("Authorization: [REDACTED]"))
+			Expect(contents).To(ContainSubstring(`"secret":"[REDACTED]"`))
+			Expect(contents).To(ContainSubstring(`"value":"some-value"`))
+			Expect(contents).To(ContainSubstring(`{"credential":{"type":"simple_credentials","value":"[REDACTED]"}}`))
+			Expect(contents).NotTo(ContainSubstring("some-token"))
+			Expect(contents).NotTo(ContainSubstring("some-secret-value"))
+			Expect(contents).NotTo(ContainSubstring("some-password"))
+		})
+
+		It("redacts the values of properties that are credentials", func() {
+			response.Body = ioutil.NopCloser(strings.NewReader(`{"properties":{".properties.some-cert":{"type":"rsa_cert_credentials","credential":true,"value":{"cert_pem":"some-cert","private_key_pem":"some-key"}}}}`))
+
+			_, err := traceClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents := string(out.Contents())
+			Expect(contents).To(ContainSubstring(`"value":"[REDACTED]"`))
+			Expect(contents).NotTo(ContainSubstring("some-key"))
+		})
+
+		It("redacts passwords and passphrases in form and unparseable bodies", func() {
+			var err error
+			request, err = http.NewRequest("POST", "http://example.com/uaa/oauth/token", strings.NewReader("grant_type=password&username=admin&password=some-password"))
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			response.Body = ioutil.NopCloser(strings.NewReader(`{"access_token": "some-access-token", "token_type": "bearer", "passphrase": "some-passphrase"`))
+
+			_, err = traceClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents := string(out.Contents())
+			Expect(contents).To(ContainSubstring("grant_type=password&username=admin&password=%5BREDACTED%5D"))
+			Expect(contents).To(ContainSubstring(`"access_token": "[REDACTED]", "token_type": "bearer", "passphrase": "[REDACTED]"`))
+			Expect(contents).NotTo(ContainSubstring("some-password"))
+		})
+
+		It("does not redact anything when redaction is disabled", func() {
+			traceClient = network.NewTraceClient(fakeClient, out, false)
+			request.Header.Set("Authorization", "Bearer some-token")
+
+			_, err := traceClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(out.Contents())).To(ContainSubstring("Authorization: Bearer some-token"))
+		})
+	})
 })
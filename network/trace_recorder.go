@@ -0,0 +1,358 @@
+package network
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// TraceEntry is a request and its response, or the error it failed with, as
+// written to a trace file. Bodies that are not valid UTF-8 are base64 encoded,
+// and bodies that were too large to be recorded are marked as truncated.
+type TraceEntry struct {
+	StartedAt time.Time      `json:"started_at"`
+	Duration  float64        `json:"duration_ms"`
+	Request   TraceRequest   `json:"request"`
+	Response  *TraceResponse `json:"response,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+type TraceRequest struct {
+	Method        string      `json:"method"`
+	URL           string      `json:"url"`
+	Header        http.Header `json:"header,omitempty"`
+	Body          string      `json:"body,omitempty"`
+	BodyEncoding  string      `json:"body_encoding,omitempty"`
+	BodyTruncated bool        `json:"body_truncated,omitempty"`
+}
+
+type TraceResponse struct {
+	StatusCode    int         `json:"status_code"`
+	Header        http.Header `json:"header,omitempty"`
+	Body          string      `json:"body,omitempty"`
+	BodyEncoding  string      `json:"body_encoding,omitempty"`
+	BodyTruncated bool        `json:"body_truncated,omitempty"`
+}
+
+// truncatedBodyComment marks the bodies of a HAR file that were too large to
+// be recorded.
+const truncatedBodyComment = "the body was too large to be recorded"
+
+// harEnd closes the entries, the log and the file of a HAR file.
+const harEnd = "]}}"
+
+// TraceRecorder writes trace entries to a file. Files ending in .har are
+// written in the HTTP Archive format, where every entry is written over the
+// closing brackets, which are written again after it, so that the file is
+// complete even when om exits early. Any other file is written as JSON lines.
+type TraceRecorder struct {
+	version string
+	file    *os.File
+	har     bool
+	// harOffset is where the closing brackets of the HAR file start
+	harOffset  int64
+	harEntries int
+	mutex      sync.Mutex
+}
+
+func NewTraceRecorder(path, version string) (*TraceRecorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not create trace file: %w", err)
+	}
+
+	recorder := &TraceRecorder{
+		version: version,
+		file:    file,
+		har:     isHARFile(path),
+	}
+
+	if recorder.har {
+		// the entries are the last field of the log, so the HAR file without
+		// entries ends with the closing brackets
+		contents, err := json.Marshal(toHAR(nil, version))
+		if err != nil {
+			return nil, err // un-tested
+		}
+
+		_, err = file.Write(contents)
+		if err != nil {
+			return nil, fmt.Errorf("could not write trace file: %w", err)
+		}
+
+		recorder.harOffset = int64(len(contents) - len(harEnd))
+	}
+
+	return recorder, nil
+}
+
+func (r *TraceRecorder) Record(entry TraceEntry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.har {
+		contents, err := json.Marshal(entry)
+		if err != nil {
+			return err // un-tested
+		}
+
+		_, err = r.file.Write(append(contents, '\n'))
+		if err != nil {
+			return fmt.Errorf("could not write trace file: %w", err)
+		}
+
+		return nil
+	}
+
+	contents, err := json.Marshal(toHAR([]TraceEntry{entry}, r.version).Log.Entries[0])
+	if err != nil {
+		return err // un-tested
+	}
+
+	if r.harEntries > 0 {
+		contents = append([]byte(","), contents...)
+	}
+
+	_, err = r.file.WriteAt(append(contents, harEnd...), r.harOffset)
+	if err != nil {
+		return fmt.Errorf("could not write trace file: %w", err)
+	}
+
+	r.harOffset += int64(len(contents))
+	r.harEntries++
+
+	return nil
+}
+
+func isHARFile(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".har")
+}
+
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	if encoding == "base64" {
+		return base64.StdEncoding.DecodeString(body)
+	}
+
+	return []byte(body), nil
+}
+
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func toHAR(entries []TraceEntry, version string) harFile {
+	har := harFile{
+		Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{Name: "om", Version: version},
+			Entries: []harEntry{},
+		},
+	}
+
+	for _, entry := range entries {
+		harEntry := harEntry{
+			StartedDateTime: entry.StartedAt,
+			Time:            entry.Duration,
+			Request: harRequest{
+				Method:      entry.Request.Method,
+				URL:         entry.Request.URL,
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []harNameValue{},
+				Headers:     toHARHeaders(entry.Request.Header),
+				QueryString: []harNameValue{},
+				HeadersSize: -1,
+				BodySize:    len(entry.Request.Body),
+			},
+			Response: harResponse{
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []harNameValue{},
+				Headers:     []harNameValue{},
+				HeadersSize: -1,
+				BodySize:    -1,
+			},
+			Timings: harTimings{Wait: entry.Duration},
+			Comment: entry.Error,
+		}
+
+		if entry.Request.Body != "" || entry.Request.BodyTruncated {
+			harEntry.Request.PostData = &harPostData{
+				MimeType: entry.Request.Header.Get("Content-Type"),
+				Text:     entry.Request.Body,
+				Encoding: entry.Request.BodyEncoding,
+			}
+			if entry.Request.BodyTruncated {
+				harEntry.Request.PostData.Comment = truncatedBodyComment
+			}
+		}
+
+		if entry.Response != nil {
+			harEntry.Response.Status = entry.Response.StatusCode
+			harEntry.Response.StatusText = http.StatusText(entry.Response.StatusCode)
+			harEntry.Response.Headers = toHARHeaders(entry.Response.Header)
+			harEntry.Response.BodySize = len(entry.Response.Body)
+			harEntry.Response.Content = harContent{
+				Size:     len(entry.Response.Body),
+				MimeType: entry.Response.Header.Get("Content-Type"),
+				Text:     entry.Response.Body,
+				Encoding: entry.Response.BodyEncoding,
+			}
+			if entry.Response.BodyTruncated {
+				harEntry.Response.Content.Comment = truncatedBodyComment
+			}
+		}
+
+		har.Log.Entries = append(har.Log.Entries, harEntry)
+	}
+
+	return har
+}
+
+func fromHAR(har harFile) []TraceEntry {
+	var entries []TraceEntry
+
+	for _, harEntry := range har.Log.Entries {
+		entry := TraceEntry{
+			StartedAt: harEntry.StartedDateTime,
+			Duration:  harEntry.Time,
+			Request: TraceRequest{
+				Method: harEntry.Request.Method,
+				URL:    harEntry.Request.URL,
+				Header: fromHARHeaders(harEntry.Request.Headers),
+			},
+			Error: harEntry.Comment,
+		}
+
+		if harEntry.Request.PostData != nil {
+			entry.Request.Body = harEntry.Request.PostData.Text
+			entry.Request.BodyEncoding = harEntry.Request.PostData.Encoding
+			entry.Request.BodyTruncated = harEntry.Request.PostData.Comment == truncatedBodyComment
+		}
+
+		if harEntry.Response.Status != 0 {
+			entry.Response = &TraceResponse{
+				StatusCode:    harEntry.Response.Status,
+				Header:        fromHARHeaders(harEntry.Response.Headers),
+				Body:          harEntry.Response.Content.Text,
+				BodyEncoding:  harEntry.Response.Content.Encoding,
+				BodyTruncated: harEntry.Response.Content.Comment == truncatedBodyComment,
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func toHARHeaders(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+
+	var names []string
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range header[name] {
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	return headers
+}
+
+func fromHARHeaders(headers []harNameValue) http.Header {
+	header := http.Header{}
+	for _, h := range headers {
+		header.Add(h.Name, h.Value)
+	}
+
+	return header
+}
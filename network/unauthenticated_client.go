@@ -15,7 +15,7 @@ type UnauthenticatedClient struct {
 	client *http.Client
 }
 
-func NewUnauthenticatedClient(target string, insecureSkipVerify bool, requestTimeout time.Duration, connectTimeout time.Duration) UnauthenticatedClient {
+func NewUnauthenticatedClient(target string, tlsConfig *tls.Config, requestTimeout time.Duration, connectTimeout time.Duration) UnauthenticatedClient {
 	return UnauthenticatedClient{
 		target: target,
 		client: &http.Client{
@@ -23,10 +23,8 @@ func NewUnauthenticatedClient(target string, insecureSkipVerify bool, requestTim
 				return http.ErrUseLastResponse
 			},
 			Transport: &http.Transport{
-				Proxy: http.ProxyFromEnvironment,
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: insecureSkipVerify,
-				},
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
 				Dial: (&net.Dialer{
 					Timeout:   connectTimeout,
 					KeepAlive: 30 * time.Second,
@@ -47,7 +45,7 @@ func (c UnauthenticatedClient) Do(request *http.Request) (*http.Response, error)
 
 	targetURL, err := url.Parse(candidateURL)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse target url: %s", err)
+		return nil, fmt.Errorf("could not parse target url: %w", err)
 	}
 
 	if targetURL.Scheme == "" {
@@ -0,0 +1,42 @@
+package presenters
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// NewCSVPresenter returns a presenter that writes the rows of the tables of
+// the table presenter as comma separated values, with the header first.
+func NewCSVPresenter(stdout io.Writer) TablePresenter {
+	return NewTablePresenter(&csvTableWriter{writer: csv.NewWriter(stdout)})
+}
+
+type csvTableWriter struct {
+	writer *csv.Writer
+	header []string
+	rows   [][]string
+}
+
+func (c *csvTableWriter) SetHeader(header []string) {
+	c.header = header
+}
+
+func (c *csvTableWriter) Append(row []string) {
+	c.rows = append(c.rows, row)
+}
+
+func (c *csvTableWriter) Render() {
+	if len(c.header) > 0 {
+		c.writer.Write(c.header)
+	}
+	c.writer.WriteAll(c.rows)
+
+	c.header = nil
+	c.rows = nil
+}
+
+func (c *csvTableWriter) SetAlignment(int) {}
+
+func (c *csvTableWriter) SetAutoFormatHeaders(bool) {}
+
+func (c *csvTableWriter) SetAutoWrapText(bool) {}
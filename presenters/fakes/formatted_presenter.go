@@ -10,80 +10,163 @@ import (
 )
 
 type FormattedPresenter struct {
-	PresentAvailableProductsStub        func([]models.Product)
+	PresentAvailableProductsStub        func([]models.Product) error
 	presentAvailableProductsMutex       sync.RWMutex
 	presentAvailableProductsArgsForCall []struct {
 		arg1 []models.Product
 	}
-	PresentCertificateAuthoritiesStub        func([]api.CA)
+	presentAvailableProductsReturns struct {
+		result1 error
+	}
+	presentAvailableProductsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentCertificateAuthoritiesStub        func([]api.CA) error
 	presentCertificateAuthoritiesMutex       sync.RWMutex
 	presentCertificateAuthoritiesArgsForCall []struct {
 		arg1 []api.CA
 	}
-	PresentCertificateAuthorityStub        func(api.CA)
+	presentCertificateAuthoritiesReturns struct {
+		result1 error
+	}
+	presentCertificateAuthoritiesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentCertificateAuthorityStub        func(api.CA) error
 	presentCertificateAuthorityMutex       sync.RWMutex
 	presentCertificateAuthorityArgsForCall []struct {
 		arg1 api.CA
 	}
-	PresentCredentialReferencesStub        func([]string)
+	presentCertificateAuthorityReturns struct {
+		result1 error
+	}
+	presentCertificateAuthorityReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentCredentialReferencesStub        func([]string) error
 	presentCredentialReferencesMutex       sync.RWMutex
 	presentCredentialReferencesArgsForCall []struct {
 		arg1 []string
 	}
-	PresentCredentialsStub        func(map[string]string)
+	presentCredentialReferencesReturns struct {
+		result1 error
+	}
+	presentCredentialReferencesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentCredentialsStub        func(map[string]string) error
 	presentCredentialsMutex       sync.RWMutex
 	presentCredentialsArgsForCall []struct {
 		arg1 map[string]string
 	}
-	PresentDeployedProductsStub        func([]api.DiagnosticProduct)
+	presentCredentialsReturns struct {
+		result1 error
+	}
+	presentCredentialsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentDeployedProductsStub        func([]api.DiagnosticProduct) error
 	presentDeployedProductsMutex       sync.RWMutex
 	presentDeployedProductsArgsForCall []struct {
 		arg1 []api.DiagnosticProduct
 	}
-	PresentErrandsStub        func([]models.Errand)
+	presentDeployedProductsReturns struct {
+		result1 error
+	}
+	presentDeployedProductsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentErrandsStub        func([]models.Errand) error
 	presentErrandsMutex       sync.RWMutex
 	presentErrandsArgsForCall []struct {
 		arg1 []models.Errand
 	}
-	PresentInstallationsStub        func([]models.Installation)
+	presentErrandsReturns struct {
+		result1 error
+	}
+	presentErrandsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentInfoStub        func(models.OpsManagerInfo) error
+	presentInfoMutex       sync.RWMutex
+	presentInfoArgsForCall []struct {
+		arg1 models.OpsManagerInfo
+	}
+	presentInfoReturns struct {
+		result1 error
+	}
+	presentInfoReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentInstallationsStub        func([]models.Installation) error
 	presentInstallationsMutex       sync.RWMutex
 	presentInstallationsArgsForCall []struct {
 		arg1 []models.Installation
 	}
-	PresentPendingChangesStub        func([]api.ProductChange)
+	presentInstallationsReturns struct {
+		result1 error
+	}
+	presentInstallationsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentPendingChangesStub        func([]api.ProductChange) error
 	presentPendingChangesMutex       sync.RWMutex
 	presentPendingChangesArgsForCall []struct {
 		arg1 []api.ProductChange
 	}
-	PresentStagedProductsStub        func([]api.DiagnosticProduct)
+	presentPendingChangesReturns struct {
+		result1 error
+	}
+	presentPendingChangesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentStagedProductsStub        func([]api.DiagnosticProduct) error
 	presentStagedProductsMutex       sync.RWMutex
 	presentStagedProductsArgsForCall []struct {
 		arg1 []api.DiagnosticProduct
 	}
-	SetFormatStub        func(string)
+	presentStagedProductsReturns struct {
+		result1 error
+	}
+	presentStagedProductsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetFormatStub        func(string) error
 	setFormatMutex       sync.RWMutex
 	setFormatArgsForCall []struct {
 		arg1 string
 	}
+	setFormatReturns struct {
+		result1 error
+	}
+	setFormatReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FormattedPresenter) PresentAvailableProducts(arg1 []models.Product) {
+func (fake *FormattedPresenter) PresentAvailableProducts(arg1 []models.Product) error {
 	var arg1Copy []models.Product
 	if arg1 != nil {
 		arg1Copy = make([]models.Product, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentAvailableProductsMutex.Lock()
+	ret, specificReturn := fake.presentAvailableProductsReturnsOnCall[len(fake.presentAvailableProductsArgsForCall)]
 	fake.presentAvailableProductsArgsForCall = append(fake.presentAvailableProductsArgsForCall, struct {
 		arg1 []models.Product
 	}{arg1Copy})
 	fake.recordInvocation("PresentAvailableProducts", []interface{}{arg1Copy})
 	fake.presentAvailableProductsMutex.Unlock()
 	if fake.PresentAvailableProductsStub != nil {
-		fake.PresentAvailableProductsStub(arg1)
+		return fake.PresentAvailableProductsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.presentAvailableProductsReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) PresentAvailableProductsCallCount() int {
@@ -92,7 +175,7 @@ func (fake *FormattedPresenter) PresentAvailableProductsCallCount() int {
 	return len(fake.presentAvailableProductsArgsForCall)
 }
 
-func (fake *FormattedPresenter) PresentAvailableProductsCalls(stub func([]models.Product)) {
+func (fake *FormattedPresenter) PresentAvailableProductsCalls(stub func([]models.Product) error) {
 	fake.presentAvailableProductsMutex.Lock()
 	defer fake.presentAvailableProductsMutex.Unlock()
 	fake.PresentAvailableProductsStub = stub
@@ -105,21 +188,50 @@ func (fake *FormattedPresenter) PresentAvailableProductsArgsForCall(i int) []mod
 	return argsForCall.arg1
 }
 
-func (fake *FormattedPresenter) PresentCertificateAuthorities(arg1 []api.CA) {
+func (fake *FormattedPresenter) PresentAvailableProductsReturns(result1 error) {
+	fake.presentAvailableProductsMutex.Lock()
+	defer fake.presentAvailableProductsMutex.Unlock()
+	fake.PresentAvailableProductsStub = nil
+	fake.presentAvailableProductsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentAvailableProductsReturnsOnCall(i int, result1 error) {
+	fake.presentAvailableProductsMutex.Lock()
+	defer fake.presentAvailableProductsMutex.Unlock()
+	fake.PresentAvailableProductsStub = nil
+	if fake.presentAvailableProductsReturnsOnCall == nil {
+		fake.presentAvailableProductsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentAvailableProductsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentCertificateAuthorities(arg1 []api.CA) error {
 	var arg1Copy []api.CA
 	if arg1 != nil {
 		arg1Copy = make([]api.CA, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentCertificateAuthoritiesMutex.Lock()
+	ret, specificReturn := fake.presentCertificateAuthoritiesReturnsOnCall[len(fake.presentCertificateAuthoritiesArgsForCall)]
 	fake.presentCertificateAuthoritiesArgsForCall = append(fake.presentCertificateAuthoritiesArgsForCall, struct {
 		arg1 []api.CA
 	}{arg1Copy})
 	fake.recordInvocation("PresentCertificateAuthorities", []interface{}{arg1Copy})
 	fake.presentCertificateAuthoritiesMutex.Unlock()
 	if fake.PresentCertificateAuthoritiesStub != nil {
-		fake.PresentCertificateAuthoritiesStub(arg1)
+		return fake.PresentCertificateAuthoritiesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.presentCertificateAuthoritiesReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) PresentCertificateAuthoritiesCallCount() int {
@@ -128,7 +240,7 @@ func (fake *FormattedPresenter) PresentCertificateAuthoritiesCallCount() int {
 	return len(fake.presentCertificateAuthoritiesArgsForCall)
 }
 
-func (fake *FormattedPresenter) PresentCertificateAuthoritiesCalls(stub func([]api.CA)) {
+func (fake *FormattedPresenter) PresentCertificateAuthoritiesCalls(stub func([]api.CA) error) {
 	fake.presentCertificateAuthoritiesMutex.Lock()
 	defer fake.presentCertificateAuthoritiesMutex.Unlock()
 	fake.PresentCertificateAuthoritiesStub = stub
@@ -141,16 +253,45 @@ func (fake *FormattedPresenter) PresentCertificateAuthoritiesArgsForCall(i int)
 	return argsForCall.arg1
 }
 
-func (fake *FormattedPresenter) PresentCertificateAuthority(arg1 api.CA) {
+func (fake *FormattedPresenter) PresentCertificateAuthoritiesReturns(result1 error) {
+	fake.presentCertificateAuthoritiesMutex.Lock()
+	defer fake.presentCertificateAuthoritiesMutex.Unlock()
+	fake.PresentCertificateAuthoritiesStub = nil
+	fake.presentCertificateAuthoritiesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentCertificateAuthoritiesReturnsOnCall(i int, result1 error) {
+	fake.presentCertificateAuthoritiesMutex.Lock()
+	defer fake.presentCertificateAuthoritiesMutex.Unlock()
+	fake.PresentCertificateAuthoritiesStub = nil
+	if fake.presentCertificateAuthoritiesReturnsOnCall == nil {
+		fake.presentCertificateAuthoritiesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentCertificateAuthoritiesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentCertificateAuthority(arg1 api.CA) error {
 	fake.presentCertificateAuthorityMutex.Lock()
+	ret, specificReturn := fake.presentCertificateAuthorityReturnsOnCall[len(fake.presentCertificateAuthorityArgsForCall)]
 	fake.presentCertificateAuthorityArgsForCall = append(fake.presentCertificateAuthorityArgsForCall, struct {
 		arg1 api.CA
 	}{arg1})
 	fake.recordInvocation("PresentCertificateAuthority", []interface{}{arg1})
 	fake.presentCertificateAuthorityMutex.Unlock()
 	if fake.PresentCertificateAuthorityStub != nil {
-		fake.PresentCertificateAuthorityStub(arg1)
+		return fake.PresentCertificateAuthorityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.presentCertificateAuthorityReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) PresentCertificateAuthorityCallCount() int {
@@ -159,7 +300,7 @@ func (fake *FormattedPresenter) PresentCertificateAuthorityCallCount() int {
 	return len(fake.presentCertificateAuthorityArgsForCall)
 }
 
-func (fake *FormattedPresenter) PresentCertificateAuthorityCalls(stub func(api.CA)) {
+func (fake *FormattedPresenter) PresentCertificateAuthorityCalls(stub func(api.CA) error) {
 	fake.presentCertificateAuthorityMutex.Lock()
 	defer fake.presentCertificateAuthorityMutex.Unlock()
 	fake.PresentCertificateAuthorityStub = stub
@@ -172,21 +313,50 @@ func (fake *FormattedPresenter) PresentCertificateAuthorityArgsForCall(i int) ap
 	return argsForCall.arg1
 }
 
-func (fake *FormattedPresenter) PresentCredentialReferences(arg1 []string) {
+func (fake *FormattedPresenter) PresentCertificateAuthorityReturns(result1 error) {
+	fake.presentCertificateAuthorityMutex.Lock()
+	defer fake.presentCertificateAuthorityMutex.Unlock()
+	fake.PresentCertificateAuthorityStub = nil
+	fake.presentCertificateAuthorityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentCertificateAuthorityReturnsOnCall(i int, result1 error) {
+	fake.presentCertificateAuthorityMutex.Lock()
+	defer fake.presentCertificateAuthorityMutex.Unlock()
+	fake.PresentCertificateAuthorityStub = nil
+	if fake.presentCertificateAuthorityReturnsOnCall == nil {
+		fake.presentCertificateAuthorityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentCertificateAuthorityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentCredentialReferences(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
 		arg1Copy = make([]string, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentCredentialReferencesMutex.Lock()
+	ret, specificReturn := fake.presentCredentialReferencesReturnsOnCall[len(fake.presentCredentialReferencesArgsForCall)]
 	fake.presentCredentialReferencesArgsForCall = append(fake.presentCredentialReferencesArgsForCall, struct {
 		arg1 []string
 	}{arg1Copy})
 	fake.recordInvocation("PresentCredentialReferences", []interface{}{arg1Copy})
 	fake.presentCredentialReferencesMutex.Unlock()
 	if fake.PresentCredentialReferencesStub != nil {
-		fake.PresentCredentialReferencesStub(arg1)
+		return fake.PresentCredentialReferencesStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentCredentialReferencesReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) PresentCredentialReferencesCallCount() int {
@@ -195,7 +365,7 @@ func (fake *FormattedPresenter) PresentCredentialReferencesCallCount() int {
 	return len(fake.presentCredentialReferencesArgsForCall)
 }
 
-func (fake *FormattedPresenter) PresentCredentialReferencesCalls(stub func([]string)) {
+func (fake *FormattedPresenter) PresentCredentialReferencesCalls(stub func([]string) error) {
 	fake.presentCredentialReferencesMutex.Lock()
 	defer fake.presentCredentialReferencesMutex.Unlock()
 	fake.PresentCredentialReferencesStub = stub
@@ -208,16 +378,45 @@ func (fake *FormattedPresenter) PresentCredentialReferencesArgsForCall(i int) []
 	return argsForCall.arg1
 }
 
-func (fake *FormattedPresenter) PresentCredentials(arg1 map[string]string) {
+func (fake *FormattedPresenter) PresentCredentialReferencesReturns(result1 error) {
+	fake.presentCredentialReferencesMutex.Lock()
+	defer fake.presentCredentialReferencesMutex.Unlock()
+	fake.PresentCredentialReferencesStub = nil
+	fake.presentCredentialReferencesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentCredentialReferencesReturnsOnCall(i int, result1 error) {
+	fake.presentCredentialReferencesMutex.Lock()
+	defer fake.presentCredentialReferencesMutex.Unlock()
+	fake.PresentCredentialReferencesStub = nil
+	if fake.presentCredentialReferencesReturnsOnCall == nil {
+		fake.presentCredentialReferencesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentCredentialReferencesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentCredentials(arg1 map[string]string) error {
 	fake.presentCredentialsMutex.Lock()
+	ret, specificReturn := fake.presentCredentialsReturnsOnCall[len(fake.presentCredentialsArgsForCall)]
 	fake.presentCredentialsArgsForCall = append(fake.presentCredentialsArgsForCall, struct {
 		arg1 map[string]string
 	}{arg1})
 	fake.recordInvocation("PresentCredentials", []interface{}{arg1})
 	fake.presentCredentialsMutex.Unlock()
 	if fake.PresentCredentialsStub != nil {
-		fake.PresentCredentialsStub(arg1)
+		return fake.PresentCredentialsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.presentCredentialsReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) PresentCredentialsCallCount() int {
@@ -226,7 +425,7 @@ func (fake *FormattedPresenter) PresentCredentialsCallCount() int {
 	return len(fake.presentCredentialsArgsForCall)
 }
 
-func (fake *FormattedPresenter) PresentCredentialsCalls(stub func(map[string]string)) {
+func (fake *FormattedPresenter) PresentCredentialsCalls(stub func(map[string]string) error) {
 	fake.presentCredentialsMutex.Lock()
 	defer fake.presentCredentialsMutex.Unlock()
 	fake.PresentCredentialsStub = stub
@@ -239,21 +438,50 @@ func (fake *FormattedPresenter) PresentCredentialsArgsForCall(i int) map[string]
 	return argsForCall.arg1
 }
 
-func (fake *FormattedPresenter) PresentDeployedProducts(arg1 []api.DiagnosticProduct) {
+func (fake *FormattedPresenter) PresentCredentialsReturns(result1 error) {
+	fake.presentCredentialsMutex.Lock()
+	defer fake.presentCredentialsMutex.Unlock()
+	fake.PresentCredentialsStub = nil
+	fake.presentCredentialsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentCredentialsReturnsOnCall(i int, result1 error) {
+	fake.presentCredentialsMutex.Lock()
+	defer fake.presentCredentialsMutex.Unlock()
+	fake.PresentCredentialsStub = nil
+	if fake.presentCredentialsReturnsOnCall == nil {
+		fake.presentCredentialsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentCredentialsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentDeployedProducts(arg1 []api.DiagnosticProduct) error {
 	var arg1Copy []api.DiagnosticProduct
 	if arg1 != nil {
 		arg1Copy = make([]api.DiagnosticProduct, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentDeployedProductsMutex.Lock()
+	ret, specificReturn := fake.presentDeployedProductsReturnsOnCall[len(fake.presentDeployedProductsArgsForCall)]
 	fake.presentDeployedProductsArgsForCall = append(fake.presentDeployedProductsArgsForCall, struct {
 		arg1 []api.DiagnosticProduct
 	}{arg1Copy})
 	fake.recordInvocation("PresentDeployedProducts", []interface{}{arg1Copy})
 	fake.presentDeployedProductsMutex.Unlock()
 	if fake.PresentDeployedProductsStub != nil {
-		fake.PresentDeployedProductsStub(arg1)
+		return fake.PresentDeployedProductsStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentDeployedProductsReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) PresentDeployedProductsCallCount() int {
@@ -262,7 +490,7 @@ func (fake *FormattedPresenter) PresentDeployedProductsCallCount() int {
 	return len(fake.presentDeployedProductsArgsForCall)
 }
 
-func (fake *FormattedPresenter) PresentDeployedProductsCalls(stub func([]api.DiagnosticProduct)) {
+func (fake *FormattedPresenter) PresentDeployedProductsCalls(stub func([]api.DiagnosticProduct) error) {
 	fake.presentDeployedProductsMutex.Lock()
 	defer fake.presentDeployedProductsMutex.Unlock()
 	fake.PresentDeployedProductsStub = stub
@@ -275,21 +503,50 @@ func (fake *FormattedPresenter) PresentDeployedProductsArgsForCall(i int) []api.
 	return argsForCall.arg1
 }
 
-func (fake *FormattedPresenter) PresentErrands(arg1 []models.Errand) {
+func (fake *FormattedPresenter) PresentDeployedProductsReturns(result1 error) {
+	fake.presentDeployedProductsMutex.Lock()
+	defer fake.presentDeployedProductsMutex.Unlock()
+	fake.PresentDeployedProductsStub = nil
+	fake.presentDeployedProductsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentDeployedProductsReturnsOnCall(i int, result1 error) {
+	fake.presentDeployedProductsMutex.Lock()
+	defer fake.presentDeployedProductsMutex.Unlock()
+	fake.PresentDeployedProductsStub = nil
+	if fake.presentDeployedProductsReturnsOnCall == nil {
+		fake.presentDeployedProductsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentDeployedProductsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentErrands(arg1 []models.Errand) error {
 	var arg1Copy []models.Errand
 	if arg1 != nil {
 		arg1Copy = make([]models.Errand, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentErrandsMutex.Lock()
+	ret, specificReturn := fake.presentErrandsReturnsOnCall[len(fake.presentErrandsArgsForCall)]
 	fake.presentErrandsArgsForCall = append(fake.presentErrandsArgsForCall, struct {
 		arg1 []models.Errand
 	}{arg1Copy})
 	fake.recordInvocation("PresentErrands", []interface{}{arg1Copy})
 	fake.presentErrandsMutex.Unlock()
 	if fake.PresentErrandsStub != nil {
-		fake.PresentErrandsStub(arg1)
+		return fake.PresentErrandsStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentErrandsReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) PresentErrandsCallCount() int {
@@ -298,7 +555,7 @@ func (fake *FormattedPresenter) PresentErrandsCallCount() int {
 	return len(fake.presentErrandsArgsForCall)
 }
 
-func (fake *FormattedPresenter) PresentErrandsCalls(stub func([]models.Errand)) {
+func (fake *FormattedPresenter) PresentErrandsCalls(stub func([]models.Errand) error) {
 	fake.presentErrandsMutex.Lock()
 	defer fake.presentErrandsMutex.Unlock()
 	fake.PresentErrandsStub = stub
@@ -311,21 +568,110 @@ func (fake *FormattedPresenter) PresentErrandsArgsForCall(i int) []models.Errand
 	return argsForCall.arg1
 }
 
-func (fake *FormattedPresenter) PresentInstallations(arg1 []models.Installation) {
+func (fake *FormattedPresenter) PresentErrandsReturns(result1 error) {
+	fake.presentErrandsMutex.Lock()
+	defer fake.presentErrandsMutex.Unlock()
+	fake.PresentErrandsStub = nil
+	fake.presentErrandsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentErrandsReturnsOnCall(i int, result1 error) {
+	fake.presentErrandsMutex.Lock()
+	defer fake.presentErrandsMutex.Unlock()
+	fake.PresentErrandsStub = nil
+	if fake.presentErrandsReturnsOnCall == nil {
+		fake.presentErrandsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentErrandsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentInfo(arg1 models.OpsManagerInfo) error {
+	fake.presentInfoMutex.Lock()
+	ret, specificReturn := fake.presentInfoReturnsOnCall[len(fake.presentInfoArgsForCall)]
+	fake.presentInfoArgsForCall = append(fake.presentInfoArgsForCall, struct {
+		arg1 models.OpsManagerInfo
+	}{arg1})
+	fake.recordInvocation("PresentInfo", []interface{}{arg1})
+	fake.presentInfoMutex.Unlock()
+	if fake.PresentInfoStub != nil {
+		return fake.PresentInfoStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentInfoReturns
+	return fakeReturns.result1
+}
+
+func (fake *FormattedPresenter) PresentInfoCallCount() int {
+	fake.presentInfoMutex.RLock()
+	defer fake.presentInfoMutex.RUnlock()
+	return len(fake.presentInfoArgsForCall)
+}
+
+func (fake *FormattedPresenter) PresentInfoCalls(stub func(models.OpsManagerInfo) error) {
+	fake.presentInfoMutex.Lock()
+	defer fake.presentInfoMutex.Unlock()
+	fake.PresentInfoStub = stub
+}
+
+func (fake *FormattedPresenter) PresentInfoArgsForCall(i int) models.OpsManagerInfo {
+	fake.presentInfoMutex.RLock()
+	defer fake.presentInfoMutex.RUnlock()
+	argsForCall := fake.presentInfoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FormattedPresenter) PresentInfoReturns(result1 error) {
+	fake.presentInfoMutex.Lock()
+	defer fake.presentInfoMutex.Unlock()
+	fake.PresentInfoStub = nil
+	fake.presentInfoReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentInfoReturnsOnCall(i int, result1 error) {
+	fake.presentInfoMutex.Lock()
+	defer fake.presentInfoMutex.Unlock()
+	fake.PresentInfoStub = nil
+	if fake.presentInfoReturnsOnCall == nil {
+		fake.presentInfoReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentInfoReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentInstallations(arg1 []models.Installation) error {
 	var arg1Copy []models.Installation
 	if arg1 != nil {
 		arg1Copy = make([]models.Installation, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentInstallationsMutex.Lock()
+	ret, specificReturn := fake.presentInstallationsReturnsOnCall[len(fake.presentInstallationsArgsForCall)]
 	fake.presentInstallationsArgsForCall = append(fake.presentInstallationsArgsForCall, struct {
 		arg1 []models.Installation
 	}{arg1Copy})
 	fake.recordInvocation("PresentInstallations", []interface{}{arg1Copy})
 	fake.presentInstallationsMutex.Unlock()
 	if fake.PresentInstallationsStub != nil {
-		fake.PresentInstallationsStub(arg1)
+		return fake.PresentInstallationsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.presentInstallationsReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) PresentInstallationsCallCount() int {
@@ -334,7 +680,7 @@ func (fake *FormattedPresenter) PresentInstallationsCallCount() int {
 	return len(fake.presentInstallationsArgsForCall)
 }
 
-func (fake *FormattedPresenter) PresentInstallationsCalls(stub func([]models.Installation)) {
+func (fake *FormattedPresenter) PresentInstallationsCalls(stub func([]models.Installation) error) {
 	fake.presentInstallationsMutex.Lock()
 	defer fake.presentInstallationsMutex.Unlock()
 	fake.PresentInstallationsStub = stub
@@ -347,21 +693,50 @@ func (fake *FormattedPresenter) PresentInstallationsArgsForCall(i int) []models.
 	return argsForCall.arg1
 }
 
-func (fake *FormattedPresenter) PresentPendingChanges(arg1 []api.ProductChange) {
+func (fake *FormattedPresenter) PresentInstallationsReturns(result1 error) {
+	fake.presentInstallationsMutex.Lock()
+	defer fake.presentInstallationsMutex.Unlock()
+	fake.PresentInstallationsStub = nil
+	fake.presentInstallationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentInstallationsReturnsOnCall(i int, result1 error) {
+	fake.presentInstallationsMutex.Lock()
+	defer fake.presentInstallationsMutex.Unlock()
+	fake.PresentInstallationsStub = nil
+	if fake.presentInstallationsReturnsOnCall == nil {
+		fake.presentInstallationsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentInstallationsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentPendingChanges(arg1 []api.ProductChange) error {
 	var arg1Copy []api.ProductChange
 	if arg1 != nil {
 		arg1Copy = make([]api.ProductChange, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentPendingChangesMutex.Lock()
+	ret, specificReturn := fake.presentPendingChangesReturnsOnCall[len(fake.presentPendingChangesArgsForCall)]
 	fake.presentPendingChangesArgsForCall = append(fake.presentPendingChangesArgsForCall, struct {
 		arg1 []api.ProductChange
 	}{arg1Copy})
 	fake.recordInvocation("PresentPendingChanges", []interface{}{arg1Copy})
 	fake.presentPendingChangesMutex.Unlock()
 	if fake.PresentPendingChangesStub != nil {
-		fake.PresentPendingChangesStub(arg1)
+		return fake.PresentPendingChangesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.presentPendingChangesReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) PresentPendingChangesCallCount() int {
@@ -370,7 +745,7 @@ func (fake *FormattedPresenter) PresentPendingChangesCallCount() int {
 	return len(fake.presentPendingChangesArgsForCall)
 }
 
-func (fake *FormattedPresenter) PresentPendingChangesCalls(stub func([]api.ProductChange)) {
+func (fake *FormattedPresenter) PresentPendingChangesCalls(stub func([]api.ProductChange) error) {
 	fake.presentPendingChangesMutex.Lock()
 	defer fake.presentPendingChangesMutex.Unlock()
 	fake.PresentPendingChangesStub = stub
@@ -383,21 +758,50 @@ func (fake *FormattedPresenter) PresentPendingChangesArgsForCall(i int) []api.Pr
 	return argsForCall.arg1
 }
 
-func (fake *FormattedPresenter) PresentStagedProducts(arg1 []api.DiagnosticProduct) {
+func (fake *FormattedPresenter) PresentPendingChangesReturns(result1 error) {
+	fake.presentPendingChangesMutex.Lock()
+	defer fake.presentPendingChangesMutex.Unlock()
+	fake.PresentPendingChangesStub = nil
+	fake.presentPendingChangesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentPendingChangesReturnsOnCall(i int, result1 error) {
+	fake.presentPendingChangesMutex.Lock()
+	defer fake.presentPendingChangesMutex.Unlock()
+	fake.PresentPendingChangesStub = nil
+	if fake.presentPendingChangesReturnsOnCall == nil {
+		fake.presentPendingChangesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentPendingChangesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentStagedProducts(arg1 []api.DiagnosticProduct) error {
 	var arg1Copy []api.DiagnosticProduct
 	if arg1 != nil {
 		arg1Copy = make([]api.DiagnosticProduct, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentStagedProductsMutex.Lock()
+	ret, specificReturn := fake.presentStagedProductsReturnsOnCall[len(fake.presentStagedProductsArgsForCall)]
 	fake.presentStagedProductsArgsForCall = append(fake.presentStagedProductsArgsForCall, struct {
 		arg1 []api.DiagnosticProduct
 	}{arg1Copy})
 	fake.recordInvocation("PresentStagedProducts", []interface{}{arg1Copy})
 	fake.presentStagedProductsMutex.Unlock()
 	if fake.PresentStagedProductsStub != nil {
-		fake.PresentStagedProductsStub(arg1)
+		return fake.PresentStagedProductsStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentStagedProductsReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) PresentStagedProductsCallCount() int {
@@ -406,7 +810,7 @@ func (fake *FormattedPresenter) PresentStagedProductsCallCount() int {
 	return len(fake.presentStagedProductsArgsForCall)
 }
 
-func (fake *FormattedPresenter) PresentStagedProductsCalls(stub func([]api.DiagnosticProduct)) {
+func (fake *FormattedPresenter) PresentStagedProductsCalls(stub func([]api.DiagnosticProduct) error) {
 	fake.presentStagedProductsMutex.Lock()
 	defer fake.presentStagedProductsMutex.Unlock()
 	fake.PresentStagedProductsStub = stub
@@ -419,16 +823,45 @@ func (fake *FormattedPresenter) PresentStagedProductsArgsForCall(i int) []api.Di
 	return argsForCall.arg1
 }
 
-func (fake *FormattedPresenter) SetFormat(arg1 string) {
+func (fake *FormattedPresenter) PresentStagedProductsReturns(result1 error) {
+	fake.presentStagedProductsMutex.Lock()
+	defer fake.presentStagedProductsMutex.Unlock()
+	fake.PresentStagedProductsStub = nil
+	fake.presentStagedProductsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) PresentStagedProductsReturnsOnCall(i int, result1 error) {
+	fake.presentStagedProductsMutex.Lock()
+	defer fake.presentStagedProductsMutex.Unlock()
+	fake.PresentStagedProductsStub = nil
+	if fake.presentStagedProductsReturnsOnCall == nil {
+		fake.presentStagedProductsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentStagedProductsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) SetFormat(arg1 string) error {
 	fake.setFormatMutex.Lock()
+	ret, specificReturn := fake.setFormatReturnsOnCall[len(fake.setFormatArgsForCall)]
 	fake.setFormatArgsForCall = append(fake.setFormatArgsForCall, struct {
 		arg1 string
 	}{arg1})
 	fake.recordInvocation("SetFormat", []interface{}{arg1})
 	fake.setFormatMutex.Unlock()
 	if fake.SetFormatStub != nil {
-		fake.SetFormatStub(arg1)
+		return fake.SetFormatStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.setFormatReturns
+	return fakeReturns.result1
 }
 
 func (fake *FormattedPresenter) SetFormatCallCount() int {
@@ -437,7 +870,7 @@ func (fake *FormattedPresenter) SetFormatCallCount() int {
 	return len(fake.setFormatArgsForCall)
 }
 
-func (fake *FormattedPresenter) SetFormatCalls(stub func(string)) {
+func (fake *FormattedPresenter) SetFormatCalls(stub func(string) error) {
 	fake.setFormatMutex.Lock()
 	defer fake.setFormatMutex.Unlock()
 	fake.SetFormatStub = stub
@@ -450,6 +883,29 @@ func (fake *FormattedPresenter) SetFormatArgsForCall(i int) string {
 	return argsForCall.arg1
 }
 
+func (fake *FormattedPresenter) SetFormatReturns(result1 error) {
+	fake.setFormatMutex.Lock()
+	defer fake.setFormatMutex.Unlock()
+	fake.SetFormatStub = nil
+	fake.setFormatReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FormattedPresenter) SetFormatReturnsOnCall(i int, result1 error) {
+	fake.setFormatMutex.Lock()
+	defer fake.setFormatMutex.Unlock()
+	fake.SetFormatStub = nil
+	if fake.setFormatReturnsOnCall == nil {
+		fake.setFormatReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setFormatReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FormattedPresenter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -467,6 +923,8 @@ func (fake *FormattedPresenter) Invocations() map[string][][]interface{} {
 	defer fake.presentDeployedProductsMutex.RUnlock()
 	fake.presentErrandsMutex.RLock()
 	defer fake.presentErrandsMutex.RUnlock()
+	fake.presentInfoMutex.RLock()
+	defer fake.presentInfoMutex.RUnlock()
 	fake.presentInstallationsMutex.RLock()
 	defer fake.presentInstallationsMutex.RUnlock()
 	fake.presentPendingChangesMutex.RLock()
@@ -10,75 +10,152 @@ import (
 )
 
 type Presenter struct {
-	PresentAvailableProductsStub        func([]models.Product)
+	PresentAvailableProductsStub        func([]models.Product) error
 	presentAvailableProductsMutex       sync.RWMutex
 	presentAvailableProductsArgsForCall []struct {
 		arg1 []models.Product
 	}
-	PresentCertificateAuthoritiesStub        func([]api.CA)
+	presentAvailableProductsReturns struct {
+		result1 error
+	}
+	presentAvailableProductsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentCertificateAuthoritiesStub        func([]api.CA) error
 	presentCertificateAuthoritiesMutex       sync.RWMutex
 	presentCertificateAuthoritiesArgsForCall []struct {
 		arg1 []api.CA
 	}
-	PresentCertificateAuthorityStub        func(api.CA)
+	presentCertificateAuthoritiesReturns struct {
+		result1 error
+	}
+	presentCertificateAuthoritiesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentCertificateAuthorityStub        func(api.CA) error
 	presentCertificateAuthorityMutex       sync.RWMutex
 	presentCertificateAuthorityArgsForCall []struct {
 		arg1 api.CA
 	}
-	PresentCredentialReferencesStub        func([]string)
+	presentCertificateAuthorityReturns struct {
+		result1 error
+	}
+	presentCertificateAuthorityReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentCredentialReferencesStub        func([]string) error
 	presentCredentialReferencesMutex       sync.RWMutex
 	presentCredentialReferencesArgsForCall []struct {
 		arg1 []string
 	}
-	PresentCredentialsStub        func(map[string]string)
+	presentCredentialReferencesReturns struct {
+		result1 error
+	}
+	presentCredentialReferencesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentCredentialsStub        func(map[string]string) error
 	presentCredentialsMutex       sync.RWMutex
 	presentCredentialsArgsForCall []struct {
 		arg1 map[string]string
 	}
-	PresentDeployedProductsStub        func([]api.DiagnosticProduct)
+	presentCredentialsReturns struct {
+		result1 error
+	}
+	presentCredentialsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentDeployedProductsStub        func([]api.DiagnosticProduct) error
 	presentDeployedProductsMutex       sync.RWMutex
 	presentDeployedProductsArgsForCall []struct {
 		arg1 []api.DiagnosticProduct
 	}
-	PresentErrandsStub        func([]models.Errand)
+	presentDeployedProductsReturns struct {
+		result1 error
+	}
+	presentDeployedProductsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentErrandsStub        func([]models.Errand) error
 	presentErrandsMutex       sync.RWMutex
 	presentErrandsArgsForCall []struct {
 		arg1 []models.Errand
 	}
-	PresentInstallationsStub        func([]models.Installation)
+	presentErrandsReturns struct {
+		result1 error
+	}
+	presentErrandsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentInfoStub        func(models.OpsManagerInfo) error
+	presentInfoMutex       sync.RWMutex
+	presentInfoArgsForCall []struct {
+		arg1 models.OpsManagerInfo
+	}
+	presentInfoReturns struct {
+		result1 error
+	}
+	presentInfoReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentInstallationsStub        func([]models.Installation) error
 	presentInstallationsMutex       sync.RWMutex
 	presentInstallationsArgsForCall []struct {
 		arg1 []models.Installation
 	}
-	PresentPendingChangesStub        func([]api.ProductChange)
+	presentInstallationsReturns struct {
+		result1 error
+	}
+	presentInstallationsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentPendingChangesStub        func([]api.ProductChange) error
 	presentPendingChangesMutex       sync.RWMutex
 	presentPendingChangesArgsForCall []struct {
 		arg1 []api.ProductChange
 	}
-	PresentStagedProductsStub        func([]api.DiagnosticProduct)
+	presentPendingChangesReturns struct {
+		result1 error
+	}
+	presentPendingChangesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PresentStagedProductsStub        func([]api.DiagnosticProduct) error
 	presentStagedProductsMutex       sync.RWMutex
 	presentStagedProductsArgsForCall []struct {
 		arg1 []api.DiagnosticProduct
 	}
+	presentStagedProductsReturns struct {
+		result1 error
+	}
+	presentStagedProductsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *Presenter) PresentAvailableProducts(arg1 []models.Product) {
+func (fake *Presenter) PresentAvailableProducts(arg1 []models.Product) error {
 	var arg1Copy []models.Product
 	if arg1 != nil {
 		arg1Copy = make([]models.Product, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentAvailableProductsMutex.Lock()
+	ret, specificReturn := fake.presentAvailableProductsReturnsOnCall[len(fake.presentAvailableProductsArgsForCall)]
 	fake.presentAvailableProductsArgsForCall = append(fake.presentAvailableProductsArgsForCall, struct {
 		arg1 []models.Product
 	}{arg1Copy})
 	fake.recordInvocation("PresentAvailableProducts", []interface{}{arg1Copy})
 	fake.presentAvailableProductsMutex.Unlock()
 	if fake.PresentAvailableProductsStub != nil {
-		fake.PresentAvailableProductsStub(arg1)
+		return fake.PresentAvailableProductsStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentAvailableProductsReturns
+	return fakeReturns.result1
 }
 
 func (fake *Presenter) PresentAvailableProductsCallCount() int {
@@ -87,7 +164,7 @@ func (fake *Presenter) PresentAvailableProductsCallCount() int {
 	return len(fake.presentAvailableProductsArgsForCall)
 }
 
-func (fake *Presenter) PresentAvailableProductsCalls(stub func([]models.Product)) {
+func (fake *Presenter) PresentAvailableProductsCalls(stub func([]models.Product) error) {
 	fake.presentAvailableProductsMutex.Lock()
 	defer fake.presentAvailableProductsMutex.Unlock()
 	fake.PresentAvailableProductsStub = stub
@@ -100,21 +177,50 @@ func (fake *Presenter) PresentAvailableProductsArgsForCall(i int) []models.Produ
 	return argsForCall.arg1
 }
 
-func (fake *Presenter) PresentCertificateAuthorities(arg1 []api.CA) {
+func (fake *Presenter) PresentAvailableProductsReturns(result1 error) {
+	fake.presentAvailableProductsMutex.Lock()
+	defer fake.presentAvailableProductsMutex.Unlock()
+	fake.PresentAvailableProductsStub = nil
+	fake.presentAvailableProductsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentAvailableProductsReturnsOnCall(i int, result1 error) {
+	fake.presentAvailableProductsMutex.Lock()
+	defer fake.presentAvailableProductsMutex.Unlock()
+	fake.PresentAvailableProductsStub = nil
+	if fake.presentAvailableProductsReturnsOnCall == nil {
+		fake.presentAvailableProductsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentAvailableProductsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentCertificateAuthorities(arg1 []api.CA) error {
 	var arg1Copy []api.CA
 	if arg1 != nil {
 		arg1Copy = make([]api.CA, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentCertificateAuthoritiesMutex.Lock()
+	ret, specificReturn := fake.presentCertificateAuthoritiesReturnsOnCall[len(fake.presentCertificateAuthoritiesArgsForCall)]
 	fake.presentCertificateAuthoritiesArgsForCall = append(fake.presentCertificateAuthoritiesArgsForCall, struct {
 		arg1 []api.CA
 	}{arg1Copy})
 	fake.recordInvocation("PresentCertificateAuthorities", []interface{}{arg1Copy})
 	fake.presentCertificateAuthoritiesMutex.Unlock()
 	if fake.PresentCertificateAuthoritiesStub != nil {
-		fake.PresentCertificateAuthoritiesStub(arg1)
+		return fake.PresentCertificateAuthoritiesStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentCertificateAuthoritiesReturns
+	return fakeReturns.result1
 }
 
 func (fake *Presenter) PresentCertificateAuthoritiesCallCount() int {
@@ -123,7 +229,7 @@ func (fake *Presenter) PresentCertificateAuthoritiesCallCount() int {
 	return len(fake.presentCertificateAuthoritiesArgsForCall)
 }
 
-func (fake *Presenter) PresentCertificateAuthoritiesCalls(stub func([]api.CA)) {
+func (fake *Presenter) PresentCertificateAuthoritiesCalls(stub func([]api.CA) error) {
 	fake.presentCertificateAuthoritiesMutex.Lock()
 	defer fake.presentCertificateAuthoritiesMutex.Unlock()
 	fake.PresentCertificateAuthoritiesStub = stub
@@ -136,16 +242,45 @@ func (fake *Presenter) PresentCertificateAuthoritiesArgsForCall(i int) []api.CA
 	return argsForCall.arg1
 }
 
-func (fake *Presenter) PresentCertificateAuthority(arg1 api.CA) {
+func (fake *Presenter) PresentCertificateAuthoritiesReturns(result1 error) {
+	fake.presentCertificateAuthoritiesMutex.Lock()
+	defer fake.presentCertificateAuthoritiesMutex.Unlock()
+	fake.PresentCertificateAuthoritiesStub = nil
+	fake.presentCertificateAuthoritiesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentCertificateAuthoritiesReturnsOnCall(i int, result1 error) {
+	fake.presentCertificateAuthoritiesMutex.Lock()
+	defer fake.presentCertificateAuthoritiesMutex.Unlock()
+	fake.PresentCertificateAuthoritiesStub = nil
+	if fake.presentCertificateAuthoritiesReturnsOnCall == nil {
+		fake.presentCertificateAuthoritiesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentCertificateAuthoritiesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentCertificateAuthority(arg1 api.CA) error {
 	fake.presentCertificateAuthorityMutex.Lock()
+	ret, specificReturn := fake.presentCertificateAuthorityReturnsOnCall[len(fake.presentCertificateAuthorityArgsForCall)]
 	fake.presentCertificateAuthorityArgsForCall = append(fake.presentCertificateAuthorityArgsForCall, struct {
 		arg1 api.CA
 	}{arg1})
 	fake.recordInvocation("PresentCertificateAuthority", []interface{}{arg1})
 	fake.presentCertificateAuthorityMutex.Unlock()
 	if fake.PresentCertificateAuthorityStub != nil {
-		fake.PresentCertificateAuthorityStub(arg1)
+		return fake.PresentCertificateAuthorityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.presentCertificateAuthorityReturns
+	return fakeReturns.result1
 }
 
 func (fake *Presenter) PresentCertificateAuthorityCallCount() int {
@@ -154,7 +289,7 @@ func (fake *Presenter) PresentCertificateAuthorityCallCount() int {
 	return len(fake.presentCertificateAuthorityArgsForCall)
 }
 
-func (fake *Presenter) PresentCertificateAuthorityCalls(stub func(api.CA)) {
+func (fake *Presenter) PresentCertificateAuthorityCalls(stub func(api.CA) error) {
 	fake.presentCertificateAuthorityMutex.Lock()
 	defer fake.presentCertificateAuthorityMutex.Unlock()
 	fake.PresentCertificateAuthorityStub = stub
@@ -167,21 +302,50 @@ func (fake *Presenter) PresentCertificateAuthorityArgsForCall(i int) api.CA {
 	return argsForCall.arg1
 }
 
-func (fake *Presenter) PresentCredentialReferences(arg1 []string) {
+func (fake *Presenter) PresentCertificateAuthorityReturns(result1 error) {
+	fake.presentCertificateAuthorityMutex.Lock()
+	defer fake.presentCertificateAuthorityMutex.Unlock()
+	fake.PresentCertificateAuthorityStub = nil
+	fake.presentCertificateAuthorityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentCertificateAuthorityReturnsOnCall(i int, result1 error) {
+	fake.presentCertificateAuthorityMutex.Lock()
+	defer fake.presentCertificateAuthorityMutex.Unlock()
+	fake.PresentCertificateAuthorityStub = nil
+	if fake.presentCertificateAuthorityReturnsOnCall == nil {
+		fake.presentCertificateAuthorityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentCertificateAuthorityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentCredentialReferences(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
 		arg1Copy = make([]string, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentCredentialReferencesMutex.Lock()
+	ret, specificReturn := fake.presentCredentialReferencesReturnsOnCall[len(fake.presentCredentialReferencesArgsForCall)]
 	fake.presentCredentialReferencesArgsForCall = append(fake.presentCredentialReferencesArgsForCall, struct {
 		arg1 []string
 	}{arg1Copy})
 	fake.recordInvocation("PresentCredentialReferences", []interface{}{arg1Copy})
 	fake.presentCredentialReferencesMutex.Unlock()
 	if fake.PresentCredentialReferencesStub != nil {
-		fake.PresentCredentialReferencesStub(arg1)
+		return fake.PresentCredentialReferencesStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentCredentialReferencesReturns
+	return fakeReturns.result1
 }
 
 func (fake *Presenter) PresentCredentialReferencesCallCount() int {
@@ -190,7 +354,7 @@ func (fake *Presenter) PresentCredentialReferencesCallCount() int {
 	return len(fake.presentCredentialReferencesArgsForCall)
 }
 
-func (fake *Presenter) PresentCredentialReferencesCalls(stub func([]string)) {
+func (fake *Presenter) PresentCredentialReferencesCalls(stub func([]string) error) {
 	fake.presentCredentialReferencesMutex.Lock()
 	defer fake.presentCredentialReferencesMutex.Unlock()
 	fake.PresentCredentialReferencesStub = stub
@@ -203,16 +367,45 @@ func (fake *Presenter) PresentCredentialReferencesArgsForCall(i int) []string {
 	return argsForCall.arg1
 }
 
-func (fake *Presenter) PresentCredentials(arg1 map[string]string) {
+func (fake *Presenter) PresentCredentialReferencesReturns(result1 error) {
+	fake.presentCredentialReferencesMutex.Lock()
+	defer fake.presentCredentialReferencesMutex.Unlock()
+	fake.PresentCredentialReferencesStub = nil
+	fake.presentCredentialReferencesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentCredentialReferencesReturnsOnCall(i int, result1 error) {
+	fake.presentCredentialReferencesMutex.Lock()
+	defer fake.presentCredentialReferencesMutex.Unlock()
+	fake.PresentCredentialReferencesStub = nil
+	if fake.presentCredentialReferencesReturnsOnCall == nil {
+		fake.presentCredentialReferencesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentCredentialReferencesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentCredentials(arg1 map[string]string) error {
 	fake.presentCredentialsMutex.Lock()
+	ret, specificReturn := fake.presentCredentialsReturnsOnCall[len(fake.presentCredentialsArgsForCall)]
 	fake.presentCredentialsArgsForCall = append(fake.presentCredentialsArgsForCall, struct {
 		arg1 map[string]string
 	}{arg1})
 	fake.recordInvocation("PresentCredentials", []interface{}{arg1})
 	fake.presentCredentialsMutex.Unlock()
 	if fake.PresentCredentialsStub != nil {
-		fake.PresentCredentialsStub(arg1)
+		return fake.PresentCredentialsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.presentCredentialsReturns
+	return fakeReturns.result1
 }
 
 func (fake *Presenter) PresentCredentialsCallCount() int {
@@ -221,7 +414,7 @@ func (fake *Presenter) PresentCredentialsCallCount() int {
 	return len(fake.presentCredentialsArgsForCall)
 }
 
-func (fake *Presenter) PresentCredentialsCalls(stub func(map[string]string)) {
+func (fake *Presenter) PresentCredentialsCalls(stub func(map[string]string) error) {
 	fake.presentCredentialsMutex.Lock()
 	defer fake.presentCredentialsMutex.Unlock()
 	fake.PresentCredentialsStub = stub
@@ -234,21 +427,50 @@ func (fake *Presenter) PresentCredentialsArgsForCall(i int) map[string]string {
 	return argsForCall.arg1
 }
 
-func (fake *Presenter) PresentDeployedProducts(arg1 []api.DiagnosticProduct) {
+func (fake *Presenter) PresentCredentialsReturns(result1 error) {
+	fake.presentCredentialsMutex.Lock()
+	defer fake.presentCredentialsMutex.Unlock()
+	fake.PresentCredentialsStub = nil
+	fake.presentCredentialsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentCredentialsReturnsOnCall(i int, result1 error) {
+	fake.presentCredentialsMutex.Lock()
+	defer fake.presentCredentialsMutex.Unlock()
+	fake.PresentCredentialsStub = nil
+	if fake.presentCredentialsReturnsOnCall == nil {
+		fake.presentCredentialsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentCredentialsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentDeployedProducts(arg1 []api.DiagnosticProduct) error {
 	var arg1Copy []api.DiagnosticProduct
 	if arg1 != nil {
 		arg1Copy = make([]api.DiagnosticProduct, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentDeployedProductsMutex.Lock()
+	ret, specificReturn := fake.presentDeployedProductsReturnsOnCall[len(fake.presentDeployedProductsArgsForCall)]
 	fake.presentDeployedProductsArgsForCall = append(fake.presentDeployedProductsArgsForCall, struct {
 		arg1 []api.DiagnosticProduct
 	}{arg1Copy})
 	fake.recordInvocation("PresentDeployedProducts", []interface{}{arg1Copy})
 	fake.presentDeployedProductsMutex.Unlock()
 	if fake.PresentDeployedProductsStub != nil {
-		fake.PresentDeployedProductsStub(arg1)
+		return fake.PresentDeployedProductsStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentDeployedProductsReturns
+	return fakeReturns.result1
 }
 
 func (fake *Presenter) PresentDeployedProductsCallCount() int {
@@ -257,7 +479,7 @@ func (fake *Presenter) PresentDeployedProductsCallCount() int {
 	return len(fake.presentDeployedProductsArgsForCall)
 }
 
-func (fake *Presenter) PresentDeployedProductsCalls(stub func([]api.DiagnosticProduct)) {
+func (fake *Presenter) PresentDeployedProductsCalls(stub func([]api.DiagnosticProduct) error) {
 	fake.presentDeployedProductsMutex.Lock()
 	defer fake.presentDeployedProductsMutex.Unlock()
 	fake.PresentDeployedProductsStub = stub
@@ -270,21 +492,50 @@ func (fake *Presenter) PresentDeployedProductsArgsForCall(i int) []api.Diagnosti
 	return argsForCall.arg1
 }
 
-func (fake *Presenter) PresentErrands(arg1 []models.Errand) {
+func (fake *Presenter) PresentDeployedProductsReturns(result1 error) {
+	fake.presentDeployedProductsMutex.Lock()
+	defer fake.presentDeployedProductsMutex.Unlock()
+	fake.PresentDeployedProductsStub = nil
+	fake.presentDeployedProductsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentDeployedProductsReturnsOnCall(i int, result1 error) {
+	fake.presentDeployedProductsMutex.Lock()
+	defer fake.presentDeployedProductsMutex.Unlock()
+	fake.PresentDeployedProductsStub = nil
+	if fake.presentDeployedProductsReturnsOnCall == nil {
+		fake.presentDeployedProductsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentDeployedProductsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentErrands(arg1 []models.Errand) error {
 	var arg1Copy []models.Errand
 	if arg1 != nil {
 		arg1Copy = make([]models.Errand, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentErrandsMutex.Lock()
+	ret, specificReturn := fake.presentErrandsReturnsOnCall[len(fake.presentErrandsArgsForCall)]
 	fake.presentErrandsArgsForCall = append(fake.presentErrandsArgsForCall, struct {
 		arg1 []models.Errand
 	}{arg1Copy})
 	fake.recordInvocation("PresentErrands", []interface{}{arg1Copy})
 	fake.presentErrandsMutex.Unlock()
 	if fake.PresentErrandsStub != nil {
-		fake.PresentErrandsStub(arg1)
+		return fake.PresentErrandsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.presentErrandsReturns
+	return fakeReturns.result1
 }
 
 func (fake *Presenter) PresentErrandsCallCount() int {
@@ -293,7 +544,7 @@ func (fake *Presenter) PresentErrandsCallCount() int {
 	return len(fake.presentErrandsArgsForCall)
 }
 
-func (fake *Presenter) PresentErrandsCalls(stub func([]models.Errand)) {
+func (fake *Presenter) PresentErrandsCalls(stub func([]models.Errand) error) {
 	fake.presentErrandsMutex.Lock()
 	defer fake.presentErrandsMutex.Unlock()
 	fake.PresentErrandsStub = stub
@@ -306,21 +557,110 @@ func (fake *Presenter) PresentErrandsArgsForCall(i int) []models.Errand {
 	return argsForCall.arg1
 }
 
-func (fake *Presenter) PresentInstallations(arg1 []models.Installation) {
+func (fake *Presenter) PresentErrandsReturns(result1 error) {
+	fake.presentErrandsMutex.Lock()
+	defer fake.presentErrandsMutex.Unlock()
+	fake.PresentErrandsStub = nil
+	fake.presentErrandsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentErrandsReturnsOnCall(i int, result1 error) {
+	fake.presentErrandsMutex.Lock()
+	defer fake.presentErrandsMutex.Unlock()
+	fake.PresentErrandsStub = nil
+	if fake.presentErrandsReturnsOnCall == nil {
+		fake.presentErrandsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentErrandsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentInfo(arg1 models.OpsManagerInfo) error {
+	fake.presentInfoMutex.Lock()
+	ret, specificReturn := fake.presentInfoReturnsOnCall[len(fake.presentInfoArgsForCall)]
+	fake.presentInfoArgsForCall = append(fake.presentInfoArgsForCall, struct {
+		arg1 models.OpsManagerInfo
+	}{arg1})
+	fake.recordInvocation("PresentInfo", []interface{}{arg1})
+	fake.presentInfoMutex.Unlock()
+	if fake.PresentInfoStub != nil {
+		return fake.PresentInfoStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentInfoReturns
+	return fakeReturns.result1
+}
+
+func (fake *Presenter) PresentInfoCallCount() int {
+	fake.presentInfoMutex.RLock()
+	defer fake.presentInfoMutex.RUnlock()
+	return len(fake.presentInfoArgsForCall)
+}
+
+func (fake *Presenter) PresentInfoCalls(stub func(models.OpsManagerInfo) error) {
+	fake.presentInfoMutex.Lock()
+	defer fake.presentInfoMutex.Unlock()
+	fake.PresentInfoStub = stub
+}
+
+func (fake *Presenter) PresentInfoArgsForCall(i int) models.OpsManagerInfo {
+	fake.presentInfoMutex.RLock()
+	defer fake.presentInfoMutex.RUnlock()
+	argsForCall := fake.presentInfoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Presenter) PresentInfoReturns(result1 error) {
+	fake.presentInfoMutex.Lock()
+	defer fake.presentInfoMutex.Unlock()
+	fake.PresentInfoStub = nil
+	fake.presentInfoReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentInfoReturnsOnCall(i int, result1 error) {
+	fake.presentInfoMutex.Lock()
+	defer fake.presentInfoMutex.Unlock()
+	fake.PresentInfoStub = nil
+	if fake.presentInfoReturnsOnCall == nil {
+		fake.presentInfoReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentInfoReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentInstallations(arg1 []models.Installation) error {
 	var arg1Copy []models.Installation
 	if arg1 != nil {
 		arg1Copy = make([]models.Installation, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentInstallationsMutex.Lock()
+	ret, specificReturn := fake.presentInstallationsReturnsOnCall[len(fake.presentInstallationsArgsForCall)]
 	fake.presentInstallationsArgsForCall = append(fake.presentInstallationsArgsForCall, struct {
 		arg1 []models.Installation
 	}{arg1Copy})
 	fake.recordInvocation("PresentInstallations", []interface{}{arg1Copy})
 	fake.presentInstallationsMutex.Unlock()
 	if fake.PresentInstallationsStub != nil {
-		fake.PresentInstallationsStub(arg1)
+		return fake.PresentInstallationsStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentInstallationsReturns
+	return fakeReturns.result1
 }
 
 func (fake *Presenter) PresentInstallationsCallCount() int {
@@ -329,7 +669,7 @@ func (fake *Presenter) PresentInstallationsCallCount() int {
 	return len(fake.presentInstallationsArgsForCall)
 }
 
-func (fake *Presenter) PresentInstallationsCalls(stub func([]models.Installation)) {
+func (fake *Presenter) PresentInstallationsCalls(stub func([]models.Installation) error) {
 	fake.presentInstallationsMutex.Lock()
 	defer fake.presentInstallationsMutex.Unlock()
 	fake.PresentInstallationsStub = stub
@@ -342,21 +682,50 @@ func (fake *Presenter) PresentInstallationsArgsForCall(i int) []models.Installat
 	return argsForCall.arg1
 }
 
-func (fake *Presenter) PresentPendingChanges(arg1 []api.ProductChange) {
+func (fake *Presenter) PresentInstallationsReturns(result1 error) {
+	fake.presentInstallationsMutex.Lock()
+	defer fake.presentInstallationsMutex.Unlock()
+	fake.PresentInstallationsStub = nil
+	fake.presentInstallationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentInstallationsReturnsOnCall(i int, result1 error) {
+	fake.presentInstallationsMutex.Lock()
+	defer fake.presentInstallationsMutex.Unlock()
+	fake.PresentInstallationsStub = nil
+	if fake.presentInstallationsReturnsOnCall == nil {
+		fake.presentInstallationsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentInstallationsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentPendingChanges(arg1 []api.ProductChange) error {
 	var arg1Copy []api.ProductChange
 	if arg1 != nil {
 		arg1Copy = make([]api.ProductChange, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentPendingChangesMutex.Lock()
+	ret, specificReturn := fake.presentPendingChangesReturnsOnCall[len(fake.presentPendingChangesArgsForCall)]
 	fake.presentPendingChangesArgsForCall = append(fake.presentPendingChangesArgsForCall, struct {
 		arg1 []api.ProductChange
 	}{arg1Copy})
 	fake.recordInvocation("PresentPendingChanges", []interface{}{arg1Copy})
 	fake.presentPendingChangesMutex.Unlock()
 	if fake.PresentPendingChangesStub != nil {
-		fake.PresentPendingChangesStub(arg1)
+		return fake.PresentPendingChangesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	fakeReturns := fake.presentPendingChangesReturns
+	return fakeReturns.result1
 }
 
 func (fake *Presenter) PresentPendingChangesCallCount() int {
@@ -365,7 +734,7 @@ func (fake *Presenter) PresentPendingChangesCallCount() int {
 	return len(fake.presentPendingChangesArgsForCall)
 }
 
-func (fake *Presenter) PresentPendingChangesCalls(stub func([]api.ProductChange)) {
+func (fake *Presenter) PresentPendingChangesCalls(stub func([]api.ProductChange) error) {
 	fake.presentPendingChangesMutex.Lock()
 	defer fake.presentPendingChangesMutex.Unlock()
 	fake.PresentPendingChangesStub = stub
@@ -378,21 +747,50 @@ func (fake *Presenter) PresentPendingChangesArgsForCall(i int) []api.ProductChan
 	return argsForCall.arg1
 }
 
-func (fake *Presenter) PresentStagedProducts(arg1 []api.DiagnosticProduct) {
+func (fake *Presenter) PresentPendingChangesReturns(result1 error) {
+	fake.presentPendingChangesMutex.Lock()
+	defer fake.presentPendingChangesMutex.Unlock()
+	fake.PresentPendingChangesStub = nil
+	fake.presentPendingChangesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentPendingChangesReturnsOnCall(i int, result1 error) {
+	fake.presentPendingChangesMutex.Lock()
+	defer fake.presentPendingChangesMutex.Unlock()
+	fake.PresentPendingChangesStub = nil
+	if fake.presentPendingChangesReturnsOnCall == nil {
+		fake.presentPendingChangesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentPendingChangesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentStagedProducts(arg1 []api.DiagnosticProduct) error {
 	var arg1Copy []api.DiagnosticProduct
 	if arg1 != nil {
 		arg1Copy = make([]api.DiagnosticProduct, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.presentStagedProductsMutex.Lock()
+	ret, specificReturn := fake.presentStagedProductsReturnsOnCall[len(fake.presentStagedProductsArgsForCall)]
 	fake.presentStagedProductsArgsForCall = append(fake.presentStagedProductsArgsForCall, struct {
 		arg1 []api.DiagnosticProduct
 	}{arg1Copy})
 	fake.recordInvocation("PresentStagedProducts", []interface{}{arg1Copy})
 	fake.presentStagedProductsMutex.Unlock()
 	if fake.PresentStagedProductsStub != nil {
-		fake.PresentStagedProductsStub(arg1)
+		return fake.PresentStagedProductsStub(arg1)
 	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.presentStagedProductsReturns
+	return fakeReturns.result1
 }
 
 func (fake *Presenter) PresentStagedProductsCallCount() int {
@@ -401,7 +799,7 @@ func (fake *Presenter) PresentStagedProductsCallCount() int {
 	return len(fake.presentStagedProductsArgsForCall)
 }
 
-func (fake *Presenter) PresentStagedProductsCalls(stub func([]api.DiagnosticProduct)) {
+func (fake *Presenter) PresentStagedProductsCalls(stub func([]api.DiagnosticProduct) error) {
 	fake.presentStagedProductsMutex.Lock()
 	defer fake.presentStagedProductsMutex.Unlock()
 	fake.PresentStagedProductsStub = stub
@@ -414,6 +812,29 @@ func (fake *Presenter) PresentStagedProductsArgsForCall(i int) []api.DiagnosticP
 	return argsForCall.arg1
 }
 
+func (fake *Presenter) PresentStagedProductsReturns(result1 error) {
+	fake.presentStagedProductsMutex.Lock()
+	defer fake.presentStagedProductsMutex.Unlock()
+	fake.PresentStagedProductsStub = nil
+	fake.presentStagedProductsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Presenter) PresentStagedProductsReturnsOnCall(i int, result1 error) {
+	fake.presentStagedProductsMutex.Lock()
+	defer fake.presentStagedProductsMutex.Unlock()
+	fake.PresentStagedProductsStub = nil
+	if fake.presentStagedProductsReturnsOnCall == nil {
+		fake.presentStagedProductsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.presentStagedProductsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Presenter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -431,6 +852,8 @@ func (fake *Presenter) Invocations() map[string][][]interface{} {
 	defer fake.presentDeployedProductsMutex.RUnlock()
 	fake.presentErrandsMutex.RLock()
 	defer fake.presentErrandsMutex.RUnlock()
+	fake.presentInfoMutex.RLock()
+	defer fake.presentInfoMutex.RUnlock()
 	fake.presentInstallationsMutex.RLock()
 	defer fake.presentInstallationsMutex.RUnlock()
 	fake.presentPendingChangesMutex.RLock()
@@ -0,0 +1,84 @@
+package presenters_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/models"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+var _ = Describe("Formats", func() {
+	var (
+		stdout   *bytes.Buffer
+		products []models.Product
+	)
+
+	BeforeEach(func() {
+		stdout = &bytes.Buffer{}
+		products = []models.Product{
+			{Name: "some-name", Version: "1.2.3"},
+			{Name: "some,other-name", Version: "4.5.6"},
+		}
+	})
+
+	Describe("YAMLPresenter", func() {
+		It("presents values as YAML with the JSON keys", func() {
+			presenters.NewYAMLPresenter(stdout).PresentAvailableProducts(products)
+
+			Expect(stdout.String()).To(MatchYAML(`[{name: some-name, version: 1.2.3}, {name: "some,other-name", version: 4.5.6}]`))
+		})
+	})
+
+	Describe("CSVPresenter", func() {
+		It("presents the rows of the table with the header first", func() {
+			presenters.NewCSVPresenter(stdout).PresentAvailableProducts(products)
+
+			Expect(stdout.String()).To(Equal("Name,Version\nsome-name,1.2.3\n\"some,other-name\",4.5.6\n"))
+		})
+
+		It("does not repeat rows when presenting again", func() {
+			presenter := presenters.NewCSVPresenter(stdout)
+			presenter.PresentStagedProducts([]api.DiagnosticProduct{{Name: "some-name", Version: "1.2.3"}})
+			presenter.PresentStagedProducts([]api.DiagnosticProduct{{Name: "other-name", Version: "4.5.6"}})
+
+			Expect(stdout.String()).To(Equal("Name,Version\nsome-name,1.2.3\nName,Version\nother-name,4.5.6\n"))
+		})
+	})
+
+	Describe("TemplatePresenter", func() {
+		It("executes the template against the JSON keys of the value", func() {
+			presenter, err := presenters.NewTemplatePresenter(stdout, `{{range .}}{{.name}}={{.version}};{{end}}`)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = presenter.PresentAvailableProducts(products)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stdout.String()).To(Equal("some-name=1.2.3;some,other-name=4.5.6;"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the template is empty", func() {
+				_, err := presenters.NewTemplatePresenter(stdout, "")
+				Expect(err).To(MatchError(ContainSubstring("a template is required")))
+			})
+
+			It("returns an error when the template cannot be parsed", func() {
+				_, err := presenters.NewTemplatePresenter(stdout, "{{.name")
+				Expect(err).To(MatchError(ContainSubstring("could not parse template")))
+			})
+
+			It("returns an error for keys that are missing, without writing any output", func() {
+				presenter, err := presenters.NewTemplatePresenter(stdout, "{{.guid}}{{.missing}}")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = presenter.PresentCertificateAuthority(api.CA{GUID: "some-guid"})
+				Expect(err).To(MatchError(ContainSubstring("could not execute template")))
+				Expect(err).To(MatchError(ContainSubstring("missing")))
+				Expect(stdout.String()).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -18,49 +18,56 @@ func NewJSONPresenter(stdout io.Writer) JSONPresenter {
 	}
 }
 
-func (j JSONPresenter) PresentAvailableProducts(products []models.Product) {
-	j.encodeJSON(products)
+func (j JSONPresenter) PresentAvailableProducts(products []models.Product) error {
+	return j.encodeJSON(products)
 }
 
-func (j JSONPresenter) PresentCertificateAuthorities(certificateAuthorities []api.CA) {
-	j.encodeJSON(certificateAuthorities)
+func (j JSONPresenter) PresentCertificateAuthorities(certificateAuthorities []api.CA) error {
+	return j.encodeJSON(certificateAuthorities)
 }
 
-func (j JSONPresenter) PresentCredentialReferences(credentialReferences []string) {
-	j.encodeJSON(credentialReferences)
+func (j JSONPresenter) PresentCredentialReferences(credentialReferences []string) error {
+	return j.encodeJSON(credentialReferences)
 }
 
-func (j JSONPresenter) PresentCredentials(credentials map[string]string) {
-	j.encodeJSON(credentials)
+func (j JSONPresenter) PresentCredentials(credentials map[string]string) error {
+	return j.encodeJSON(credentials)
 }
 
-func (j JSONPresenter) PresentDeployedProducts(deployedProducts []api.DiagnosticProduct) {
-	j.encodeJSON(deployedProducts)
+func (j JSONPresenter) PresentDeployedProducts(deployedProducts []api.DiagnosticProduct) error {
+	return j.encodeJSON(deployedProducts)
 }
 
-func (j JSONPresenter) PresentErrands(errands []models.Errand) {
-	j.encodeJSON(errands)
+func (j JSONPresenter) PresentErrands(errands []models.Errand) error {
+	return j.encodeJSON(errands)
 }
 
-func (j JSONPresenter) PresentCertificateAuthority(certificateAuthority api.CA) {
-	j.encodeJSON(certificateAuthority)
+func (j JSONPresenter) PresentCertificateAuthority(certificateAuthority api.CA) error {
+	return j.encodeJSON(certificateAuthority)
 }
 
-func (j JSONPresenter) PresentInstallations(installations []models.Installation) {
-	j.encodeJSON(installations)
+func (j JSONPresenter) PresentInfo(info models.OpsManagerInfo) error {
+	return j.encodeJSON(info)
 }
 
-func (j JSONPresenter) PresentPendingChanges(pendingChanges []api.ProductChange) {
-	j.encodeJSON(pendingChanges)
+func (j JSONPresenter) PresentInstallations(installations []models.Installation) error {
+	return j.encodeJSON(installations)
 }
 
-func (j JSONPresenter) PresentStagedProducts(stagedProducts []api.DiagnosticProduct) {
-	j.encodeJSON(stagedProducts)
+func (j JSONPresenter) PresentPendingChanges(pendingChanges []api.ProductChange) error {
+	return j.encodeJSON(pendingChanges)
 }
 
-func (j JSONPresenter) encodeJSON(v interface{}) {
-	b, _ := json.MarshalIndent(&v, "", "  ")
+func (j JSONPresenter) PresentStagedProducts(stagedProducts []api.DiagnosticProduct) error {
+	return j.encodeJSON(stagedProducts)
+}
+
+func (j JSONPresenter) encodeJSON(v interface{}) error {
+	b, err := json.MarshalIndent(&v, "", "  ")
+	if err != nil {
+		return err
+	}
 
-	j.stdout.Write(b)
-	j.stdout.Write([]byte("\n"))
+	_, err = j.stdout.Write(append(b, '\n'))
+	return err
 }
@@ -1,6 +1,10 @@
 package presenters
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/models"
 )
@@ -8,128 +12,135 @@ import (
 //go:generate counterfeiter -o fakes/presenter.go --fake-name Presenter . Presenter
 
 type Presenter interface {
-	PresentAvailableProducts([]models.Product)
-	PresentCertificateAuthorities([]api.CA)
-	PresentCertificateAuthority(api.CA)
-	PresentCredentialReferences([]string)
-	PresentCredentials(map[string]string)
-	PresentDeployedProducts([]api.DiagnosticProduct)
-	PresentErrands([]models.Errand)
-	PresentInstallations([]models.Installation)
-	PresentPendingChanges([]api.ProductChange)
-	PresentStagedProducts([]api.DiagnosticProduct)
+	PresentAvailableProducts([]models.Product) error
+	PresentCertificateAuthorities([]api.CA) error
+	PresentCertificateAuthority(api.CA) error
+	PresentCredentialReferences([]string) error
+	PresentCredentials(map[string]string) error
+	PresentDeployedProducts([]api.DiagnosticProduct) error
+	PresentErrands([]models.Errand) error
+	PresentInfo(models.OpsManagerInfo) error
+	PresentInstallations([]models.Installation) error
+	PresentPendingChanges([]api.ProductChange) error
+	PresentStagedProducts([]api.DiagnosticProduct) error
 }
 
 //go:generate counterfeiter -o fakes/formatted_presenter.go --fake-name FormattedPresenter . FormattedPresenter
 
 type FormattedPresenter interface {
 	Presenter
-	SetFormat(string)
+	SetFormat(string) error
 }
 
+// PresenterFactory builds the presenter for a format. The argument is
+// whatever follows the first "=" in the format, such as the template in
+// "template={{.name}}", and is empty when there is none.
+type PresenterFactory func(argument string) (Presenter, error)
+
+// MultiPresenter presents with the presenter of the format it was last set
+// to, which is the table presenter until then.
 type MultiPresenter struct {
-	tablePresenter Presenter
-	jsonPresenter  Presenter
-	format         string
+	factories map[string]PresenterFactory
+	presenter Presenter
 }
 
 func NewPresenter(tablePresenter Presenter, jsonPresenter Presenter) *MultiPresenter {
-	return &MultiPresenter{
-		tablePresenter: tablePresenter,
-		jsonPresenter:  jsonPresenter,
-		format:         "table",
+	p := &MultiPresenter{
+		factories: map[string]PresenterFactory{},
+		presenter: tablePresenter,
 	}
+
+	p.AddFormat("table", Static(tablePresenter))
+	p.AddFormat("json", Static(jsonPresenter))
+
+	return p
 }
 
-func (p *MultiPresenter) SetFormat(format string) {
-	p.format = format
+// AddFormat makes the format available to SetFormat, replacing the
+// factory of a format with the same name.
+func (p *MultiPresenter) AddFormat(name string, factory PresenterFactory) *MultiPresenter {
+	p.factories[name] = factory
+	return p
 }
 
-func (p *MultiPresenter) PresentAvailableProducts(products []models.Product) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentAvailableProducts(products)
-	default:
-		p.tablePresenter.PresentAvailableProducts(products)
+// Static returns a factory for a format that does not take an argument.
+func Static(presenter Presenter) PresenterFactory {
+	return func(argument string) (Presenter, error) {
+		if argument != "" {
+			return nil, fmt.Errorf("the format does not take an argument: %q", argument)
+		}
+
+		return presenter, nil
 	}
 }
 
-func (p *MultiPresenter) PresentCertificateAuthorities(cas []api.CA) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentCertificateAuthorities(cas)
-	default:
-		p.tablePresenter.PresentCertificateAuthorities(cas)
+func (p *MultiPresenter) SetFormat(format string) error {
+	name, argument := format, ""
+	if i := strings.Index(format, "="); i >= 0 {
+		name, argument = format[:i], format[i+1:]
 	}
-}
 
-func (p *MultiPresenter) PresentCertificateAuthority(ca api.CA) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentCertificateAuthority(ca)
-	default:
-		p.tablePresenter.PresentCertificateAuthority(ca)
+	factory, ok := p.factories[name]
+	if !ok {
+		var names []string
+		for name := range p.factories {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return fmt.Errorf("unknown format %q, the formats are: %s", name, strings.Join(names, ", "))
 	}
-}
 
-func (p *MultiPresenter) PresentCredentialReferences(ref []string) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentCredentialReferences(ref)
-	default:
-		p.tablePresenter.PresentCredentialReferences(ref)
+	presenter, err := factory(argument)
+	if err != nil {
+		return fmt.Errorf("invalid %s format: %s", name, err)
 	}
+
+	p.presenter = presenter
+
+	return nil
 }
 
-func (p *MultiPresenter) PresentCredentials(creds map[string]string) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentCredentials(creds)
-	default:
-		p.tablePresenter.PresentCredentials(creds)
-	}
+func (p *MultiPresenter) PresentAvailableProducts(products []models.Product) error {
+	return p.presenter.PresentAvailableProducts(products)
 }
 
-func (p *MultiPresenter) PresentDeployedProducts(products []api.DiagnosticProduct) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentDeployedProducts(products)
-	default:
-		p.tablePresenter.PresentDeployedProducts(products)
-	}
+func (p *MultiPresenter) PresentCertificateAuthorities(cas []api.CA) error {
+	return p.presenter.PresentCertificateAuthorities(cas)
 }
 
-func (p *MultiPresenter) PresentErrands(errands []models.Errand) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentErrands(errands)
-	default:
-		p.tablePresenter.PresentErrands(errands)
-	}
+func (p *MultiPresenter) PresentCertificateAuthority(ca api.CA) error {
+	return p.presenter.PresentCertificateAuthority(ca)
 }
 
-func (p *MultiPresenter) PresentInstallations(i []models.Installation) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentInstallations(i)
-	default:
-		p.tablePresenter.PresentInstallations(i)
-	}
+func (p *MultiPresenter) PresentCredentialReferences(ref []string) error {
+	return p.presenter.PresentCredentialReferences(ref)
 }
-func (p *MultiPresenter) PresentPendingChanges(c []api.ProductChange) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentPendingChanges(c)
-	default:
-		p.tablePresenter.PresentPendingChanges(c)
-	}
+
+func (p *MultiPresenter) PresentCredentials(creds map[string]string) error {
+	return p.presenter.PresentCredentials(creds)
 }
 
-func (p *MultiPresenter) PresentStagedProducts(products []api.DiagnosticProduct) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentStagedProducts(products)
-	default:
-		p.tablePresenter.PresentStagedProducts(products)
-	}
+func (p *MultiPresenter) PresentDeployedProducts(products []api.DiagnosticProduct) error {
+	return p.presenter.PresentDeployedProducts(products)
+}
+
+func (p *MultiPresenter) PresentErrands(errands []models.Errand) error {
+	return p.presenter.PresentErrands(errands)
+}
+
+func (p *MultiPresenter) PresentInfo(info models.OpsManagerInfo) error {
+	return p.presenter.PresentInfo(info)
+}
+
+func (p *MultiPresenter) PresentInstallations(i []models.Installation) error {
+	return p.presenter.PresentInstallations(i)
+}
+
+func (p *MultiPresenter) PresentPendingChanges(c []api.ProductChange) error {
+	return p.presenter.PresentPendingChanges(c)
+}
+
+func (p *MultiPresenter) PresentStagedProducts(products []api.DiagnosticProduct) error {
+	return p.presenter.PresentStagedProducts(products)
 }
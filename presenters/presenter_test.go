@@ -0,0 +1,78 @@
+package presenters_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/om/models"
+	"github.com/pivotal-cf/om/presenters"
+	"github.com/pivotal-cf/om/presenters/fakes"
+)
+
+var _ = Describe("MultiPresenter", func() {
+	var (
+		tablePresenter *fakes.Presenter
+		jsonPresenter  *fakes.Presenter
+		otherPresenter *fakes.Presenter
+		presenter      *presenters.MultiPresenter
+		arguments      []string
+	)
+
+	BeforeEach(func() {
+		tablePresenter = &fakes.Presenter{}
+		jsonPresenter = &fakes.Presenter{}
+		otherPresenter = &fakes.Presenter{}
+		arguments = nil
+
+		presenter = presenters.NewPresenter(tablePresenter, jsonPresenter).
+			AddFormat("other", func(argument string) (presenters.Presenter, error) {
+				arguments = append(arguments, argument)
+				if argument == "bad" {
+					return nil, errors.New("bad argument")
+				}
+				return otherPresenter, nil
+			})
+	})
+
+	It("presents as a table by default", func() {
+		presenter.PresentAvailableProducts([]models.Product{{Name: "some-name"}})
+
+		Expect(tablePresenter.PresentAvailableProductsCallCount()).To(Equal(1))
+		Expect(jsonPresenter.PresentAvailableProductsCallCount()).To(Equal(0))
+	})
+
+	It("presents with the presenter of the format", func() {
+		Expect(presenter.SetFormat("json")).To(Succeed())
+		presenter.PresentCredentialReferences([]string{"some-reference"})
+
+		Expect(jsonPresenter.PresentCredentialReferencesCallCount()).To(Equal(1))
+		Expect(jsonPresenter.PresentCredentialReferencesArgsForCall(0)).To(Equal([]string{"some-reference"}))
+		Expect(tablePresenter.PresentCredentialReferencesCallCount()).To(Equal(0))
+	})
+
+	It("passes whatever follows the first equals sign to the factory", func() {
+		Expect(presenter.SetFormat("other={{.a}}={{.b}}")).To(Succeed())
+		presenter.PresentErrands(nil)
+
+		Expect(arguments).To(Equal([]string{"{{.a}}={{.b}}"}))
+		Expect(otherPresenter.PresentErrandsCallCount()).To(Equal(1))
+	})
+
+	Context("failure cases", func() {
+		It("returns an error for an unknown format", func() {
+			err := presenter.SetFormat("xml")
+			Expect(err).To(MatchError(`unknown format "xml", the formats are: json, other, table`))
+		})
+
+		It("returns an error when a static format is given an argument", func() {
+			err := presenter.SetFormat("json=something")
+			Expect(err).To(MatchError(`invalid json format: the format does not take an argument: "something"`))
+		})
+
+		It("returns an error when the factory fails", func() {
+			err := presenter.SetFormat("other=bad")
+			Expect(err).To(MatchError("invalid other format: bad argument"))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package presenters
 
 import (
+	"fmt"
 	"sort"
 	"strconv"
 	"time"
@@ -31,7 +32,7 @@ func NewTablePresenter(tableWriter tableWriter) TablePresenter {
 	}
 }
 
-func (t TablePresenter) PresentAvailableProducts(products []models.Product) {
+func (t TablePresenter) PresentAvailableProducts(products []models.Product) error {
 	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
 	t.tableWriter.SetHeader([]string{"Name", "Version"})
 
@@ -40,9 +41,11 @@ func (t TablePresenter) PresentAvailableProducts(products []models.Product) {
 	}
 
 	t.tableWriter.Render()
+
+	return nil
 }
 
-func (t TablePresenter) PresentCertificateAuthorities(certificateAuthorities []api.CA) {
+func (t TablePresenter) PresentCertificateAuthorities(certificateAuthorities []api.CA) error {
 	t.tableWriter.SetAutoWrapText(false)
 	t.tableWriter.SetHeader([]string{"id", "issuer", "active", "created on", "expires on", "certicate pem"})
 
@@ -51,9 +54,11 @@ func (t TablePresenter) PresentCertificateAuthorities(certificateAuthorities []a
 	}
 
 	t.tableWriter.Render()
+
+	return nil
 }
 
-func (t TablePresenter) PresentCredentialReferences(credentialReferences []string) {
+func (t TablePresenter) PresentCredentialReferences(credentialReferences []string) error {
 	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
 	t.tableWriter.SetHeader([]string{"Credentials"})
 
@@ -62,9 +67,11 @@ func (t TablePresenter) PresentCredentialReferences(credentialReferences []strin
 	}
 
 	t.tableWriter.Render()
+
+	return nil
 }
 
-func (t TablePresenter) PresentCredentials(credentials map[string]string) {
+func (t TablePresenter) PresentCredentials(credentials map[string]string) error {
 	t.tableWriter.SetAlignment(tablewriter.ALIGN_LEFT)
 
 	header, credential := sortCredentialMap(credentials)
@@ -74,9 +81,11 @@ func (t TablePresenter) PresentCredentials(credentials map[string]string) {
 	t.tableWriter.SetAutoWrapText(false)
 	t.tableWriter.Append(credential)
 	t.tableWriter.Render()
+
+	return nil
 }
 
-func (t TablePresenter) PresentDeployedProducts(deployedProducts []api.DiagnosticProduct) {
+func (t TablePresenter) PresentDeployedProducts(deployedProducts []api.DiagnosticProduct) error {
 	t.tableWriter.SetHeader([]string{"Name", "Version"})
 
 	for _, product := range deployedProducts {
@@ -84,9 +93,11 @@ func (t TablePresenter) PresentDeployedProducts(deployedProducts []api.Diagnosti
 	}
 
 	t.tableWriter.Render()
+
+	return nil
 }
 
-func (t TablePresenter) PresentErrands(errands []models.Errand) {
+func (t TablePresenter) PresentErrands(errands []models.Errand) error {
 	t.tableWriter.SetHeader([]string{"Name", "Post Deploy Enabled", "Pre Delete Enabled"})
 
 	for _, errand := range errands {
@@ -94,9 +105,11 @@ func (t TablePresenter) PresentErrands(errands []models.Errand) {
 	}
 
 	t.tableWriter.Render()
+
+	return nil
 }
 
-func (t TablePresenter) PresentCertificateAuthority(certificateAuthority api.CA) {
+func (t TablePresenter) PresentCertificateAuthority(certificateAuthority api.CA) error {
 	t.tableWriter.SetAutoWrapText(false)
 	t.tableWriter.SetHeader([]string{"id", "issuer", "active", "created on", "expires on", "certicate pem"})
 	t.tableWriter.Append([]string{certificateAuthority.GUID,
@@ -106,9 +119,34 @@ func (t TablePresenter) PresentCertificateAuthority(certificateAuthority api.CA)
 		certificateAuthority.ExpiresOn,
 		certificateAuthority.CertPEM})
 	t.tableWriter.Render()
+
+	return nil
+}
+
+func (t TablePresenter) PresentInfo(info models.OpsManagerInfo) error {
+	infrastructureType := info.InfrastructureType
+	if infrastructureType == "" {
+		infrastructureType = "unavailable"
+	}
+
+	t.tableWriter.SetHeader([]string{"Name", "Value"})
+	t.tableWriter.Append([]string{"version", info.Version})
+	t.tableWriter.Append([]string{"infrastructure type", infrastructureType})
+
+	for _, feature := range info.Features {
+		supported := "supported"
+		if !feature.Supported {
+			supported = fmt.Sprintf("not supported (requires %s)", feature.MinimumVersion)
+		}
+		t.tableWriter.Append([]string{"feature " + feature.Name, supported})
+	}
+
+	t.tableWriter.Render()
+
+	return nil
 }
 
-func (t TablePresenter) PresentInstallations(installations []models.Installation) {
+func (t TablePresenter) PresentInstallations(installations []models.Installation) error {
 	t.tableWriter.SetHeader([]string{"ID", "User", "Status", "Started At", "Finished At"})
 
 	for _, installation := range installations {
@@ -131,9 +169,11 @@ func (t TablePresenter) PresentInstallations(installations []models.Installation
 	}
 
 	t.tableWriter.Render()
+
+	return nil
 }
 
-func (t TablePresenter) PresentPendingChanges(pendingChanges []api.ProductChange) {
+func (t TablePresenter) PresentPendingChanges(pendingChanges []api.ProductChange) error {
 	t.tableWriter.SetHeader([]string{"PRODUCT", "ACTION", "ERRANDS"})
 
 	for _, change := range pendingChanges {
@@ -150,9 +190,11 @@ func (t TablePresenter) PresentPendingChanges(pendingChanges []api.ProductChange
 	}
 
 	t.tableWriter.Render()
+
+	return nil
 }
 
-func (t TablePresenter) PresentStagedProducts(stagedProducts []api.DiagnosticProduct) {
+func (t TablePresenter) PresentStagedProducts(stagedProducts []api.DiagnosticProduct) error {
 	t.tableWriter.SetHeader([]string{"Name", "Version"})
 
 	for _, product := range stagedProducts {
@@ -160,6 +202,8 @@ func (t TablePresenter) PresentStagedProducts(stagedProducts []api.DiagnosticPro
 	}
 
 	t.tableWriter.Render()
+
+	return nil
 }
 
 func sortCredentialMap(cm map[string]string) ([]string, []string) {
@@ -167,6 +167,29 @@ var _ = Describe("TablePresenter", func() {
 		})
 	})
 
+	Describe("PresentInfo", func() {
+		It("creates a table of the version, infrastructure type and features", func() {
+			tablePresenter.PresentInfo(models.OpsManagerInfo{
+				Version:            "2.1-build.212",
+				InfrastructureType: "vsphere",
+				Features: []models.Feature{
+					{Name: "some-feature", MinimumVersion: "2.0", Supported: true},
+					{Name: "other-feature", MinimumVersion: "2.2", Supported: false},
+				},
+			})
+
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"Name", "Value"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(4))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"version", "2.1-build.212"}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"infrastructure type", "vsphere"}))
+			Expect(fakeTableWriter.AppendArgsForCall(2)).To(Equal([]string{"feature some-feature", "supported"}))
+			Expect(fakeTableWriter.AppendArgsForCall(3)).To(Equal([]string{"feature other-feature", "not supported (requires 2.2)"}))
+
+			Expect(fakeTableWriter.RenderCallCount()).To(Equal(1))
+		})
+	})
+
 	Describe("PresentInstallations", func() {
 		var installations []models.Installation
 
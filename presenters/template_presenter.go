@@ -0,0 +1,55 @@
+package presenters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// TemplatePresenter presents values with a Go template. The template is
+// executed against the value as it is presented as JSON, so it refers to
+// the same keys, such as {{range .}}{{.name}}{{end}}.
+type TemplatePresenter struct {
+	valuePresenter
+}
+
+func NewTemplatePresenter(stdout io.Writer, text string) (TemplatePresenter, error) {
+	if text == "" {
+		return TemplatePresenter{}, fmt.Errorf("a template is required, for example: template='{{range .}}{{.name}}{{\"\\n\"}}{{end}}'")
+	}
+
+	tmpl, err := template.New("format").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return TemplatePresenter{}, fmt.Errorf("could not parse template: %s", err)
+	}
+
+	return TemplatePresenter{
+		valuePresenter: valuePresenter{
+			present: func(v interface{}) error {
+				var data interface{}
+				b, err := json.Marshal(&v)
+				if err != nil {
+					return err
+				}
+
+				err = json.Unmarshal(b, &data)
+				if err != nil {
+					return err
+				}
+
+				// the template is executed into a buffer, so that nothing is
+				// written when it fails part way
+				var output bytes.Buffer
+				err = tmpl.Execute(&output, data)
+				if err != nil {
+					return fmt.Errorf("could not execute template: %s", err)
+				}
+
+				_, err = output.WriteTo(stdout)
+				return err
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,56 @@
+package presenters
+
+import (
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/models"
+)
+
+// valuePresenter presents every value the same way, for the formats that
+// do not lay out each kind of value differently.
+type valuePresenter struct {
+	present func(v interface{}) error
+}
+
+func (p valuePresenter) PresentAvailableProducts(products []models.Product) error {
+	return p.present(products)
+}
+
+func (p valuePresenter) PresentCertificateAuthorities(certificateAuthorities []api.CA) error {
+	return p.present(certificateAuthorities)
+}
+
+func (p valuePresenter) PresentCertificateAuthority(certificateAuthority api.CA) error {
+	return p.present(certificateAuthority)
+}
+
+func (p valuePresenter) PresentCredentialReferences(credentialReferences []string) error {
+	return p.present(credentialReferences)
+}
+
+func (p valuePresenter) PresentCredentials(credentials map[string]string) error {
+	return p.present(credentials)
+}
+
+func (p valuePresenter) PresentDeployedProducts(deployedProducts []api.DiagnosticProduct) error {
+	return p.present(deployedProducts)
+}
+
+func (p valuePresenter) PresentErrands(errands []models.Errand) error {
+	return p.present(errands)
+}
+
+func (p valuePresenter) PresentInfo(info models.OpsManagerInfo) error {
+	return p.present(info)
+}
+
+func (p valuePresenter) PresentInstallations(installations []models.Installation) error {
+	return p.present(installations)
+}
+
+func (p valuePresenter) PresentPendingChanges(pendingChanges []api.ProductChange) error {
+	return p.present(pendingChanges)
+}
+
+func (p valuePresenter) PresentStagedProducts(stagedProducts []api.DiagnosticProduct) error {
+	return p.present(stagedProducts)
+}
@@ -0,0 +1,35 @@
+package presenters
+
+import (
+	"encoding/json"
+	"io"
+
+	yamlConverter "github.com/ghodss/yaml"
+)
+
+// YAMLPresenter presents values as YAML, with the same keys as the JSON
+// presenter.
+type YAMLPresenter struct {
+	valuePresenter
+}
+
+func NewYAMLPresenter(stdout io.Writer) YAMLPresenter {
+	return YAMLPresenter{
+		valuePresenter: valuePresenter{
+			present: func(v interface{}) error {
+				b, err := json.Marshal(&v)
+				if err != nil {
+					return err
+				}
+
+				b, err = yamlConverter.JSONToYAML(b)
+				if err != nil {
+					return err
+				}
+
+				_, err = stdout.Write(b)
+				return err
+			},
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package varsstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// CredHub reads variables from the data endpoint of a CredHub server. The
+// name of each variable is appended to the prefix, the same way BOSH and
+// Concourse look up their credentials.
+type CredHub struct {
+	client httpClient
+	url    string
+	prefix string
+	token  string
+}
+
+func NewCredHub(client httpClient, url string, prefix string, token string) CredHub {
+	return CredHub{
+		client: client,
+		url:    url,
+		prefix: prefix,
+		token:  token,
+	}
+}
+
+func (c CredHub) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
+	name := path.Join("/", c.prefix, varDef.Name)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/data?current=true&name=%s", c.url, url.QueryEscape(name)), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not fetch %q from credhub: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, ResponseError{Name: name, StatusCode: resp.StatusCode}
+	}
+
+	var output struct {
+		Data []struct {
+			Value interface{} `json:"value"`
+		} `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&output)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not parse response for %q from credhub: %s", name, err)
+	}
+
+	if len(output.Data) == 0 {
+		return nil, false, nil
+	}
+
+	return output.Data[0].Value, true, nil
+}
+
+// ResponseError is returned when CredHub responds with an unexpected status.
+// Server errors are temporary, so that they can be retried.
+type ResponseError struct {
+	Name       string
+	StatusCode int
+}
+
+func (e ResponseError) Error() string {
+	return fmt.Sprintf("could not fetch %q from credhub: unexpected response %d", e.Name, e.StatusCode)
+}
+
+func (e ResponseError) Temporary() bool {
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
+func (c CredHub) List() ([]boshtpl.VariableDefinition, error) {
+	return nil, nil
+}
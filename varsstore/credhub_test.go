@@ -0,0 +1,103 @@
+package varsstore_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/pivotal-cf/om/varsstore"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CredHub", func() {
+	var (
+		server   *httptest.Server
+		requests []*http.Request
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			requests = append(requests, req)
+
+			if req.URL.Path != "/api/v1/data" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			switch req.URL.Query().Get("name") {
+			case "/concourse/main/password":
+				w.Write([]byte(`{"data": [{"type": "password", "value": "some-password"}]}`))
+			case "/concourse/main/certificate":
+				w.Write([]byte(`{"data": [{"type": "certificate", "value": {"certificate": "some-cert", "private_key": "some-key"}}]}`))
+			case "/concourse/main/broken":
+				w.WriteHeader(http.StatusInternalServerError)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error": "The request could not be completed because the credential does not exist or you do not have sufficient authorization."}`))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Get", func() {
+		It("returns the current value of the credential under the prefix", func() {
+			store := varsstore.NewCredHub(server.Client(), server.URL, "/concourse/main", "some-token")
+
+			value, found, err := store.Get(boshtpl.VariableDefinition{Name: "password"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-password"))
+
+			Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer some-token"))
+			Expect(requests[0].URL.Query().Get("current")).To(Equal("true"))
+		})
+
+		It("returns structured credentials as they are", func() {
+			store := varsstore.NewCredHub(server.Client(), server.URL, "concourse/main", "")
+
+			value, found, err := store.Get(boshtpl.VariableDefinition{Name: "certificate"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[string]interface{}{
+				"certificate": "some-cert",
+				"private_key": "some-key",
+			}))
+
+			Expect(requests[0].Header.Get("Authorization")).To(BeEmpty())
+		})
+
+		It("reports credentials that do not exist as not found", func() {
+			store := varsstore.NewCredHub(server.Client(), server.URL, "/concourse/main", "some-token")
+
+			_, found, err := store.Get(boshtpl.VariableDefinition{Name: "missing"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns an error when CredHub fails", func() {
+			store := varsstore.NewCredHub(server.Client(), server.URL, "/concourse/main", "some-token")
+
+			_, _, err := store.Get(boshtpl.VariableDefinition{Name: "broken"})
+			Expect(err).To(MatchError(`could not fetch "/concourse/main/broken" from credhub: unexpected response 500`))
+
+			var responseErr varsstore.ResponseError
+			Expect(errors.As(err, &responseErr)).To(BeTrue())
+			Expect(responseErr.Temporary()).To(BeTrue())
+		})
+	})
+
+	It("can be used to interpolate a template", func() {
+		store := varsstore.NewCredHub(server.Client(), server.URL, "/concourse/main", "some-token")
+
+		contents, err := boshtpl.NewTemplate([]byte(`password: ((password))`)).Evaluate(store, nil, boshtpl.EvaluateOpts{ExpectAllKeys: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contents).To(MatchYAML(`password: some-password`))
+	})
+})
@@ -0,0 +1,53 @@
+package varsstore
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	"gopkg.in/yaml.v2"
+)
+
+// FileExec runs an executable with the name of a variable as its only
+// argument and reads the value of the variable, as YAML, from its standard
+// output. Empty output means the variable does not exist.
+type FileExec struct {
+	command string
+}
+
+func NewFileExec(command string) FileExec {
+	return FileExec{
+		command: command,
+	}
+}
+
+func (f FileExec) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(f.command, varDef.Name)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return nil, false, fmt.Errorf("could not get %q from %s: %s: %s", varDef.Name, f.command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	if strings.TrimSpace(stdout.String()) == "" {
+		return nil, false, nil
+	}
+
+	var value interface{}
+	err = yaml.Unmarshal(stdout.Bytes(), &value)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not parse %q from %s: %s", varDef.Name, f.command, err)
+	}
+
+	return value, true, nil
+}
+
+func (f FileExec) List() ([]boshtpl.VariableDefinition, error) {
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+package varsstore_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/pivotal-cf/om/varsstore"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileExec", func() {
+	var (
+		tempDir    string
+		executable string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "file-exec")
+		Expect(err).NotTo(HaveOccurred())
+
+		executable = filepath.Join(tempDir, "get-secret")
+		err = ioutil.WriteFile(executable, []byte(`#!/bin/sh
+case "$1" in
+  password) echo some-password ;;
+  certificate) printf 'certificate: some-cert\nprivate_key: some-key\n' ;;
+  broken) echo "vault is sealed" >&2; exit 1 ;;
+esac
+`), 0700)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	Describe("Get", func() {
+		It("reads the value from the output of the executable", func() {
+			value, found, err := varsstore.NewFileExec(executable).Get(boshtpl.VariableDefinition{Name: "password"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-password"))
+		})
+
+		It("parses the output as YAML", func() {
+			value, found, err := varsstore.NewFileExec(executable).Get(boshtpl.VariableDefinition{Name: "certificate"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[interface{}]interface{}{
+				"certificate": "some-cert",
+				"private_key": "some-key",
+			}))
+		})
+
+		It("reports empty output as not found", func() {
+			_, found, err := varsstore.NewFileExec(executable).Get(boshtpl.VariableDefinition{Name: "missing"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns an error when the executable fails", func() {
+			_, _, err := varsstore.NewFileExec(executable).Get(boshtpl.VariableDefinition{Name: "broken"})
+			Expect(err).To(MatchError(ContainSubstring(`could not get "broken" from ` + executable + `: exit status 1: vault is sealed`)))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package varsstore_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestVarsStore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "varsstore")
+}
@@ -0,0 +1,89 @@
+package varsstore
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/pivotal-cf/om/network"
+)
+
+type httpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// New returns the variables store described by uri. Supported stores are:
+//
+//	credhub://host[:port][/path/prefix]  reads variables from CredHub, authenticating with $CREDHUB_TOKEN
+//	file-exec:path/to/executable         runs the executable with the variable name as its only argument
+//
+// CredHub is contacted with its own TLS options, given as the ca_cert (a path
+// or PEM encoded certificates) and skip_ssl_validation query parameters, so
+// that the options of Ops Manager do not apply to it.
+func New(uri string) (boshtpl.Variables, error) {
+	if strings.HasPrefix(uri, "file-exec:") {
+		command := strings.TrimPrefix(uri, "file-exec:")
+		if command == "" {
+			return nil, fmt.Errorf("could not parse vars store %q: an executable is required", uri)
+		}
+
+		return NewFileExec(command), nil
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse vars store %q: %w", uri, err)
+	}
+
+	switch parsed.Scheme {
+	case "credhub":
+		if parsed.Host == "" {
+			return nil, fmt.Errorf("could not parse vars store %q: a host is required", uri)
+		}
+
+		tlsConfig, err := credHubTLSConfig(parsed.Query())
+		if err != nil {
+			return nil, fmt.Errorf("could not parse vars store %q: %w", uri, err)
+		}
+
+		token := os.Getenv("CREDHUB_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("could not use vars store %q: $CREDHUB_TOKEN is required to authenticate with CredHub", uri)
+		}
+
+		client := &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		}
+
+		return NewCredHub(client, "https://"+parsed.Host, parsed.Path, token), nil
+	default:
+		return nil, fmt.Errorf("could not parse vars store %q: unsupported store, expected credhub:// or file-exec:", uri)
+	}
+}
+
+func credHubTLSConfig(query url.Values) (*tls.Config, error) {
+	var skipSSLValidation bool
+	for name, values := range query {
+		switch name {
+		case "ca_cert":
+		case "skip_ssl_validation":
+			var err error
+			skipSSLValidation, err = strconv.ParseBool(values[0])
+			if err != nil {
+				return nil, fmt.Errorf("skip_ssl_validation must be true or false")
+			}
+		default:
+			return nil, fmt.Errorf("unknown option %q, expected ca_cert or skip_ssl_validation", name)
+		}
+	}
+
+	return network.NewTLSConfig(skipSSLValidation, query.Get("ca_cert"), "", "")
+}
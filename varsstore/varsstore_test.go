@@ -0,0 +1,114 @@
+package varsstore_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/pivotal-cf/om/varsstore"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("New", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		os.Setenv("CREDHUB_TOKEN", "some-token")
+
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Authorization") != "Bearer some-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"data": [{"type": "password", "value": "some-password"}]}`))
+		}))
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("CREDHUB_TOKEN")
+		server.Close()
+	})
+
+	credHubURI := func(query string) string {
+		return "credhub://" + strings.TrimPrefix(server.URL, "https://") + "/concourse/main" + query
+	}
+
+	It("returns a CredHub store for credhub:// URIs", func() {
+		store, err := varsstore.New("credhub://credhub.example.com:8844/concourse/main")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(BeAssignableToTypeOf(varsstore.CredHub{}))
+	})
+
+	It("connects to CredHub with the ca_cert of the URI", func() {
+		caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		store, err := varsstore.New(credHubURI("?ca_cert=" + url.QueryEscape(string(caCert))))
+		Expect(err).NotTo(HaveOccurred())
+
+		value, found, err := store.Get(boshtpl.VariableDefinition{Name: "password"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-password"))
+	})
+
+	It("skips the certificate checks when skip_ssl_validation is set in the URI", func() {
+		store, err := varsstore.New(credHubURI("?skip_ssl_validation=true"))
+		Expect(err).NotTo(HaveOccurred())
+
+		value, _, err := store.Get(boshtpl.VariableDefinition{Name: "password"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("some-password"))
+	})
+
+	It("checks the certificate of CredHub by default", func() {
+		store, err := varsstore.New(credHubURI(""))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = store.Get(boshtpl.VariableDefinition{Name: "password"})
+		Expect(err).To(MatchError(ContainSubstring("certificate")))
+	})
+
+	It("returns a file-exec store for file-exec: URIs", func() {
+		store, err := varsstore.New("file-exec:./get-secret")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(Equal(varsstore.NewFileExec("./get-secret")))
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when $CREDHUB_TOKEN is empty", func() {
+			os.Unsetenv("CREDHUB_TOKEN")
+
+			_, err := varsstore.New("credhub://credhub.example.com/concourse")
+			Expect(err).To(MatchError(`could not use vars store "credhub://credhub.example.com/concourse": $CREDHUB_TOKEN is required to authenticate with CredHub`))
+		})
+
+		It("returns an error for unknown options of the CredHub URI", func() {
+			_, err := varsstore.New("credhub://credhub.example.com/concourse?insecure=true")
+			Expect(err).To(MatchError(`could not parse vars store "credhub://credhub.example.com/concourse?insecure=true": unknown option "insecure", expected ca_cert or skip_ssl_validation`))
+
+			_, err = varsstore.New("credhub://credhub.example.com/concourse?skip_ssl_validation=maybe")
+			Expect(err).To(MatchError(ContainSubstring("skip_ssl_validation must be true or false")))
+		})
+
+		It("returns an error for unsupported stores", func() {
+			_, err := varsstore.New("vault://vault.example.com")
+			Expect(err).To(MatchError(`could not parse vars store "vault://vault.example.com": unsupported store, expected credhub:// or file-exec:`))
+		})
+
+		It("returns an error when the CredHub host is missing", func() {
+			_, err := varsstore.New("credhub:///concourse")
+			Expect(err).To(MatchError(`could not parse vars store "credhub:///concourse": a host is required`))
+		})
+
+		It("returns an error when the executable is missing", func() {
+			_, err := varsstore.New("file-exec:")
+			Expect(err).To(MatchError(`could not parse vars store "file-exec:": an executable is required`))
+		})
+	})
+})
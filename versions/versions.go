@@ -0,0 +1,347 @@
+package versions
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	versionPattern    = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:[-+#](.*))?$`)
+	partialPattern    = regexp.MustCompile(`^v?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?(?:-(.*))?$`)
+	constraintOperand = regexp.MustCompile(`^(!=|>=|<=|=|>|<|~>|~|\^)?\s*(.+)$`)
+)
+
+// Version is a release version as published on Pivotal Network, e.g. 2.3.4,
+// 2.3 or 2.3.0-build.12. Missing minor and patch numbers are treated as 0.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	original   string
+}
+
+func ParseVersion(version string) (Version, error) {
+	matches := versionPattern.FindStringSubmatch(strings.TrimSpace(version))
+	if matches == nil {
+		return Version{}, fmt.Errorf("could not parse version %q", version)
+	}
+
+	v := Version{original: version}
+	v.Major, _ = strconv.Atoi(matches[1])
+	if matches[2] != "" {
+		v.Minor, _ = strconv.Atoi(matches[2])
+	}
+	if matches[3] != "" {
+		v.Patch, _ = strconv.Atoi(matches[3])
+	}
+	v.Prerelease = matches[4]
+
+	return v, nil
+}
+
+func (v Version) String() string {
+	return v.original
+}
+
+// Compare returns -1, 0 or 1 if v is lower than, equal to or higher than
+// other. A version with a prerelease is lower than the same version without.
+func (v Version) Compare(other Version) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+func comparePrerelease(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+
+		switch {
+		case aErr == nil && bErr == nil && aNum != bNum:
+			if aNum < bNum {
+				return -1
+			}
+			return 1
+		case aErr == nil && bErr != nil:
+			return -1
+		case aErr != nil && bErr == nil:
+			return 1
+		case aParts[i] != bParts[i]:
+			if aParts[i] < bParts[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case len(aParts) < len(bParts):
+		return -1
+	case len(aParts) > len(bParts):
+		return 1
+	}
+
+	return 0
+}
+
+type versionCheck struct {
+	operator string
+	version  Version
+}
+
+func (c versionCheck) matches(v Version) bool {
+	cmp := v.Compare(c.version)
+
+	switch c.operator {
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+
+	return cmp == 0
+}
+
+// VersionConstraint is a semver-style constraint such as "~2.3", "^2.3.1",
+// "2.3.x" or ">=2.2.5 <2.3", or a pessimistic constraint such as "~> 2.2" as
+// used in product metadata. Comparisons separated by spaces or commas must
+// all match, and alternatives can be given with "||".
+type VersionConstraint struct {
+	alternatives      [][]versionCheck
+	allowsPrereleases bool
+	original          string
+}
+
+// IsVersionConstraint reports whether value is a constraint rather than a
+// version to be matched exactly.
+func IsVersionConstraint(value string) bool {
+	if strings.ContainsAny(value, "~^<>=!*|, ") {
+		return true
+	}
+
+	for _, part := range strings.Split(value, ".") {
+		if part == "x" || part == "X" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func NewVersionConstraint(constraint string) (VersionConstraint, error) {
+	c := VersionConstraint{original: constraint}
+
+	for _, alternative := range strings.Split(constraint, "||") {
+		var checks []versionCheck
+
+		terms := strings.FieldsFunc(alternative, func(r rune) bool { return r == ' ' || r == ',' })
+		for i := 0; i < len(terms); i++ {
+			term := terms[i]
+			if strings.Trim(term, "!=<>~^") == "" && i+1 < len(terms) {
+				i++
+				term += terms[i]
+			}
+
+			termChecks, prerelease, err := parseTerm(term)
+			if err != nil {
+				return VersionConstraint{}, fmt.Errorf("could not parse version constraint %q: %s", constraint, err)
+			}
+
+			if prerelease {
+				c.allowsPrereleases = true
+			}
+
+			checks = append(checks, termChecks...)
+		}
+
+		if len(checks) == 0 {
+			return VersionConstraint{}, fmt.Errorf("could not parse version constraint %q: empty constraint", constraint)
+		}
+
+		c.alternatives = append(c.alternatives, checks)
+	}
+
+	return c, nil
+}
+
+func parseTerm(term string) ([]versionCheck, bool, error) {
+	operand := constraintOperand.FindStringSubmatch(term)
+	if operand == nil {
+		return nil, false, fmt.Errorf("invalid term %q", term)
+	}
+
+	operator, value := operand[1], operand[2]
+
+	parts := partialPattern.FindStringSubmatch(value)
+	if parts == nil {
+		return nil, false, fmt.Errorf("invalid version %q", value)
+	}
+
+	var numbers []int
+	for _, part := range parts[1:4] {
+		if part == "" || part == "x" || part == "X" || part == "*" {
+			break
+		}
+		number, _ := strconv.Atoi(part)
+		numbers = append(numbers, number)
+	}
+
+	prerelease := parts[4] != ""
+	lower := Version{Prerelease: parts[4], original: value}
+	for i, number := range numbers {
+		switch i {
+		case 0:
+			lower.Major = number
+		case 1:
+			lower.Minor = number
+		case 2:
+			lower.Patch = number
+		}
+	}
+
+	// the first version that is not matched when the missing numbers are
+	// wildcards, e.g. 2.4.0 for 2.3.x
+	upper := func(significant int) Version {
+		switch significant {
+		case 0:
+			return Version{Major: int(^uint(0) >> 1)}
+		case 1:
+			return Version{Major: lower.Major + 1, Prerelease: "0"}
+		case 2:
+			return Version{Major: lower.Major, Minor: lower.Minor + 1, Prerelease: "0"}
+		}
+		return Version{Major: lower.Major, Minor: lower.Minor, Patch: lower.Patch + 1, Prerelease: "0"}
+	}
+
+	switch operator {
+	case "~":
+		significant := len(numbers)
+		if significant > 2 {
+			significant = 2
+		}
+		return []versionCheck{{">=", lower}, {"<", upper(significant)}}, prerelease, nil
+	case "~>":
+		significant := len(numbers) - 1
+		if significant < 1 {
+			significant = 1
+		}
+		return []versionCheck{{">=", lower}, {"<", upper(significant)}}, prerelease, nil
+	case "^":
+		significant := 1
+		if lower.Major == 0 {
+			significant = 2
+			if lower.Minor == 0 && len(numbers) == 3 {
+				significant = 3
+			}
+		}
+		if len(numbers) < significant {
+			significant = len(numbers)
+		}
+		return []versionCheck{{">=", lower}, {"<", upper(significant)}}, prerelease, nil
+	case "", "=":
+		if len(numbers) == 3 {
+			return []versionCheck{{"=", lower}}, prerelease, nil
+		}
+		return []versionCheck{{">=", lower}, {"<", upper(len(numbers))}}, prerelease, nil
+	case ">":
+		if len(numbers) < 3 {
+			return []versionCheck{{">=", upper(len(numbers))}}, prerelease, nil
+		}
+	case "<=":
+		if len(numbers) < 3 {
+			return []versionCheck{{"<", upper(len(numbers))}}, prerelease, nil
+		}
+	}
+
+	return []versionCheck{{operator, lower}}, prerelease, nil
+}
+
+// Check reports whether version satisfies the constraint. Prereleases only
+// match when the constraint itself mentions one.
+func (c VersionConstraint) Check(version string) bool {
+	v, err := ParseVersion(version)
+	if err != nil {
+		return false
+	}
+
+	if v.Prerelease != "" && !c.allowsPrereleases {
+		return false
+	}
+
+	for _, checks := range c.alternatives {
+		matched := true
+		for _, check := range checks {
+			if !check.matches(v) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c VersionConstraint) String() string {
+	return c.original
+}
+
+// LatestVersion returns the highest of the versions for which match returns
+// true. Versions that cannot be parsed are ignored.
+func LatestVersion(versions []string, match func(string) bool) (string, bool) {
+	var candidates []Version
+	for _, version := range versions {
+		if !match(version) {
+			continue
+		}
+
+		v, err := ParseVersion(version)
+		if err != nil {
+			continue
+		}
+
+		candidates = append(candidates, v)
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Compare(candidates[j]) > 0
+	})
+
+	return candidates[0].String(), true
+}
@@ -0,0 +1,105 @@
+package versions_test
+
+import (
+	"github.com/pivotal-cf/om/versions"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VersionConstraint", func() {
+	matching := func(constraint string, candidates ...string) []string {
+		c, err := versions.NewVersionConstraint(constraint)
+		Expect(err).NotTo(HaveOccurred())
+
+		var matched []string
+		for _, version := range candidates {
+			if c.Check(version) {
+				matched = append(matched, version)
+			}
+		}
+		return matched
+	}
+
+	candidates := []string{"2.2.4", "2.2.5", "2.2.10", "2.3", "2.3.0", "2.3.7", "2.4.0", "2.4.0-build.12", "3.0.1", "not-a-version"}
+
+	It("matches versions within the same minor with ~", func() {
+		Expect(matching("~2.3", candidates...)).To(Equal([]string{"2.3", "2.3.0", "2.3.7"}))
+		Expect(matching("~2.2.5", candidates...)).To(Equal([]string{"2.2.5", "2.2.10"}))
+		Expect(matching("~2", candidates...)).To(Equal([]string{"2.2.4", "2.2.5", "2.2.10", "2.3", "2.3.0", "2.3.7", "2.4.0"}))
+	})
+
+	It("matches versions within the same major with ^", func() {
+		Expect(matching("^2.3.7", candidates...)).To(Equal([]string{"2.3.7", "2.4.0"}))
+		Expect(matching("^0.3.1", "0.3.1", "0.3.9", "0.4.0")).To(Equal([]string{"0.3.1", "0.3.9"}))
+	})
+
+	It("matches pessimistic constraints", func() {
+		Expect(matching("~> 2.3", candidates...)).To(Equal([]string{"2.3", "2.3.0", "2.3.7", "2.4.0"}))
+		Expect(matching("~> 2.2.5", candidates...)).To(Equal([]string{"2.2.5", "2.2.10"}))
+		Expect(matching("~>2", candidates...)).To(Equal([]string{"2.2.4", "2.2.5", "2.2.10", "2.3", "2.3.0", "2.3.7", "2.4.0"}))
+	})
+
+	It("matches ranges of comparisons", func() {
+		Expect(matching(">=2.2.5 <2.3", candidates...)).To(Equal([]string{"2.2.5", "2.2.10"}))
+		Expect(matching(">= 2.2.5, < 2.3", candidates...)).To(Equal([]string{"2.2.5", "2.2.10"}))
+		Expect(matching(">2.3", candidates...)).To(Equal([]string{"2.4.0", "3.0.1"}))
+		Expect(matching("<=2.2", candidates...)).To(Equal([]string{"2.2.4", "2.2.5", "2.2.10"}))
+		Expect(matching("!=2.2.5 <2.3", candidates...)).To(Equal([]string{"2.2.4", "2.2.10"}))
+	})
+
+	It("matches wildcards", func() {
+		Expect(matching("2.2.x", candidates...)).To(Equal([]string{"2.2.4", "2.2.5", "2.2.10"}))
+		Expect(matching("2.*", candidates...)).To(Equal([]string{"2.2.4", "2.2.5", "2.2.10", "2.3", "2.3.0", "2.3.7", "2.4.0"}))
+	})
+
+	It("matches any of the alternatives", func() {
+		Expect(matching("~2.2.5 || >=3", candidates...)).To(Equal([]string{"2.2.5", "2.2.10", "3.0.1"}))
+	})
+
+	It("only matches prereleases when the constraint contains one", func() {
+		Expect(matching(">=2.4.0-build.1", candidates...)).To(Equal([]string{"2.4.0", "2.4.0-build.12", "3.0.1"}))
+		Expect(matching(">=2.4.0-build.13", candidates...)).To(Equal([]string{"2.4.0", "3.0.1"}))
+	})
+
+	Context("when the constraint cannot be parsed", func() {
+		It("returns an error", func() {
+			_, err := versions.NewVersionConstraint(">=two")
+			Expect(err).To(MatchError(`could not parse version constraint ">=two": invalid version "two"`))
+
+			_, err = versions.NewVersionConstraint("~2.3 ||")
+			Expect(err).To(MatchError(`could not parse version constraint "~2.3 ||": empty constraint`))
+		})
+	})
+
+	Describe("IsVersionConstraint", func() {
+		It("distinguishes constraints from exact versions", func() {
+			Expect(versions.IsVersionConstraint("2.3.4")).To(BeFalse())
+			Expect(versions.IsVersionConstraint("2.3.0-build.12")).To(BeFalse())
+			Expect(versions.IsVersionConstraint("~2.3")).To(BeTrue())
+			Expect(versions.IsVersionConstraint("2.3.x")).To(BeTrue())
+			Expect(versions.IsVersionConstraint(">=2.2.5 <2.3")).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("LatestVersion", func() {
+	It("returns the highest matching version", func() {
+		latest, found := versions.LatestVersion([]string{"2.2.10", "2.3.0-build.1", "2.2.9", "junk", "2.3.0"}, func(string) bool { return true })
+		Expect(found).To(BeTrue())
+		Expect(latest).To(Equal("2.3.0"))
+
+		latest, found = versions.LatestVersion([]string{"2.2.10", "2.3.0-build.1", "2.3.0-build.2"}, func(version string) bool {
+			return version != "2.2.10"
+		})
+		Expect(found).To(BeTrue())
+		Expect(latest).To(Equal("2.3.0-build.2"))
+	})
+
+	Context("when no version matches", func() {
+		It("returns false", func() {
+			_, found := versions.LatestVersion([]string{"2.2.10"}, func(string) bool { return false })
+			Expect(found).To(BeFalse())
+		})
+	})
+})